@@ -0,0 +1,297 @@
+// Package accountsmock provides a configurable accounts.AccountsService for unit tests which must not call the API
+package accountsmock
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Method names recorded in Call.Method
+const (
+	MethodCreate     = "Create"
+	MethodFetch      = "Fetch"
+	MethodList       = "List"
+	MethodUpdate     = "Update"
+	MethodDelete     = "Delete"
+	MethodCreateMany = "CreateMany"
+	MethodFetchMany  = "FetchMany"
+	MethodDeleteMany = "DeleteMany"
+	MethodPing       = "Ping"
+)
+
+// ErrNotStubbed is returned by the methods which have neither a scripted Result nor a Func field, so that a
+// forgotten stub fails where it is called rather than with a nil response further on
+var ErrNotStubbed = errors.New("accountsmock: method not stubbed")
+
+// Call is a recorded invocation of the mock
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Result is a scripted outcome returned by the next call to a method. Response holds an *accounts.AccountResponse
+// for Create, Fetch and Update, an *accounts.AccountListResponse for List, an *accounts.Health for Ping and is
+// ignored for Delete
+type Result struct {
+	Response interface{}
+	Err      error
+}
+
+// Mock implements accounts.AccountsService. Each call is recorded and answered, in order of precedence, by the
+// next scripted Result for the method, by the matching Func field, or with ErrNotStubbed.
+// Batch methods fall back to calling Create, Fetch and Delete sequentially, so scripts apply per item
+type Mock struct {
+	CreateFunc     func(ctx context.Context, accountData *accounts.AccountData) (*accounts.AccountResponse, error)
+	FetchFunc      func(ctx context.Context, accountId uuid.UUID) (*accounts.AccountResponse, error)
+	ListFunc       func(ctx context.Context, options *accounts.ListOptions) (*accounts.AccountListResponse, error)
+	UpdateFunc     func(ctx context.Context, accountData *accounts.AccountData) (*accounts.AccountResponse, error)
+	DeleteFunc     func(ctx context.Context, accountId uuid.UUID, version int) error
+	CreateManyFunc func(ctx context.Context, accountsData []*accounts.AccountData, options *accounts.BatchOptions) ([]accounts.BatchResult, error)
+	FetchManyFunc  func(ctx context.Context, accountIds []uuid.UUID, options *accounts.BatchOptions) ([]accounts.BatchResult, error)
+	DeleteManyFunc func(ctx context.Context, items []accounts.DeleteItem, options *accounts.BatchOptions) ([]accounts.BatchResult, error)
+	PingFunc       func(ctx context.Context) (*accounts.Health, error)
+
+	mu      sync.Mutex
+	calls   []Call
+	scripts map[string][]Result
+}
+
+var _ accounts.AccountsService = (*Mock)(nil)
+
+// New constructs an empty Mock
+func New() *Mock {
+	return &Mock{}
+}
+
+// Script queues results returned, one per call, by the next invocations of method
+func (m *Mock) Script(method string, results ...Result) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.scripts == nil {
+		m.scripts = map[string][]Result{}
+	}
+
+	m.scripts[method] = append(m.scripts[method], results...)
+
+	return m
+}
+
+// Calls returns every recorded call, in invocation order
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the recorded calls to method, in invocation order
+func (m *Mock) CallsTo(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset clears the recorded calls and the pending scripts
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+	m.scripts = nil
+}
+
+// Create records the call and returns the configured outcome
+func (m *Mock) Create(ctx context.Context, accountData *accounts.AccountData) (*accounts.AccountResponse, error) {
+
+	if result, ok := m.record(MethodCreate, accountData); ok {
+		response, _ := result.Response.(*accounts.AccountResponse)
+		return response, result.Err
+	}
+
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, accountData)
+	}
+
+	return nil, notStubbed(MethodCreate)
+}
+
+// Fetch records the call and returns the configured outcome
+func (m *Mock) Fetch(ctx context.Context, accountId uuid.UUID) (*accounts.AccountResponse, error) {
+
+	if result, ok := m.record(MethodFetch, accountId); ok {
+		response, _ := result.Response.(*accounts.AccountResponse)
+		return response, result.Err
+	}
+
+	if m.FetchFunc != nil {
+		return m.FetchFunc(ctx, accountId)
+	}
+
+	return nil, notStubbed(MethodFetch)
+}
+
+// List records the call and returns the configured outcome
+func (m *Mock) List(ctx context.Context, options *accounts.ListOptions) (*accounts.AccountListResponse, error) {
+
+	if result, ok := m.record(MethodList, options); ok {
+		response, _ := result.Response.(*accounts.AccountListResponse)
+		return response, result.Err
+	}
+
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, options)
+	}
+
+	return nil, notStubbed(MethodList)
+}
+
+// Update records the call and returns the configured outcome
+func (m *Mock) Update(ctx context.Context, accountData *accounts.AccountData) (*accounts.AccountResponse, error) {
+
+	if result, ok := m.record(MethodUpdate, accountData); ok {
+		response, _ := result.Response.(*accounts.AccountResponse)
+		return response, result.Err
+	}
+
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, accountData)
+	}
+
+	return nil, notStubbed(MethodUpdate)
+}
+
+// Delete records the call and returns the configured outcome
+func (m *Mock) Delete(ctx context.Context, accountId uuid.UUID, version int) error {
+
+	if result, ok := m.record(MethodDelete, accountId, version); ok {
+		return result.Err
+	}
+
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, accountId, version)
+	}
+
+	return notStubbed(MethodDelete)
+}
+
+// CreateMany records the call and returns the configured outcome
+func (m *Mock) CreateMany(ctx context.Context, accountsData []*accounts.AccountData, options *accounts.BatchOptions) ([]accounts.BatchResult, error) {
+
+	m.record(MethodCreateMany, accountsData, options)
+
+	if m.CreateManyFunc != nil {
+		return m.CreateManyFunc(ctx, accountsData, options)
+	}
+
+	return runSequentially(len(accountsData), options, func(i int) (*accounts.AccountResponse, error) {
+		return m.Create(ctx, accountsData[i])
+	})
+}
+
+// FetchMany records the call and returns the configured outcome
+func (m *Mock) FetchMany(ctx context.Context, accountIds []uuid.UUID, options *accounts.BatchOptions) ([]accounts.BatchResult, error) {
+
+	m.record(MethodFetchMany, accountIds, options)
+
+	if m.FetchManyFunc != nil {
+		return m.FetchManyFunc(ctx, accountIds, options)
+	}
+
+	return runSequentially(len(accountIds), options, func(i int) (*accounts.AccountResponse, error) {
+		return m.Fetch(ctx, accountIds[i])
+	})
+}
+
+// DeleteMany records the call and returns the configured outcome
+func (m *Mock) DeleteMany(ctx context.Context, items []accounts.DeleteItem, options *accounts.BatchOptions) ([]accounts.BatchResult, error) {
+
+	m.record(MethodDeleteMany, items, options)
+
+	if m.DeleteManyFunc != nil {
+		return m.DeleteManyFunc(ctx, items, options)
+	}
+
+	return runSequentially(len(items), options, func(i int) (*accounts.AccountResponse, error) {
+		return nil, m.Delete(ctx, items[i].AccountID, items[i].Version)
+	})
+}
+
+// Ping records the call and returns the configured outcome
+func (m *Mock) Ping(ctx context.Context) (*accounts.Health, error) {
+
+	if result, ok := m.record(MethodPing); ok {
+		response, _ := result.Response.(*accounts.Health)
+		return response, result.Err
+	}
+
+	if m.PingFunc != nil {
+		return m.PingFunc(ctx)
+	}
+
+	return nil, notStubbed(MethodPing)
+}
+
+// record stores the call and pops the next scripted result of the method, if any
+func (m *Mock) record(method string, args ...interface{}) (Result, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+
+	scripted := m.scripts[method]
+
+	if len(scripted) == 0 {
+		return Result{}, false
+	}
+
+	m.scripts[method] = scripted[1:]
+
+	return scripted[0], true
+}
+
+func runSequentially(size int, options *accounts.BatchOptions, operation func(i int) (*accounts.AccountResponse, error)) ([]accounts.BatchResult, error) {
+
+	results := make([]accounts.BatchResult, size)
+
+	for i := range results {
+		results[i].Index = i
+	}
+
+	for i := range results {
+
+		response, err := operation(i)
+
+		results[i].Response = response
+		results[i].Err = err
+
+		if err != nil && options != nil && options.Mode == accounts.FailFast {
+
+			for j := i + 1; j < size; j++ {
+				results[j].Err = accounts.BatchAbortedError
+			}
+
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotStubbed, method)
+}
@@ -0,0 +1,111 @@
+package accountsmock
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMock_ScriptedResults_AreReturnedInOrderThenFallBackToFunc(t *testing.T) {
+
+	// Arrange
+
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	scriptedErr := errors.New("scripted failure")
+	fallbackResponse := &accounts.AccountResponse{AccountData: &accounts.AccountData{Data: &accounts.Data{ID: "fallback"}}}
+
+	mock := New().Script(MethodFetch,
+		Result{Err: scriptedErr},
+		Result{Response: &accounts.AccountResponse{AccountData: &accounts.AccountData{Data: &accounts.Data{ID: accountId.String()}}}},
+	)
+
+	mock.FetchFunc = func(ctx context.Context, id uuid.UUID) (*accounts.AccountResponse, error) {
+		return fallbackResponse, nil
+	}
+
+	var service accounts.AccountsService = mock
+
+	ctx := context.Background()
+
+	// Act
+
+	_, firstErr := service.Fetch(ctx, accountId)
+	second, _ := service.Fetch(ctx, accountId)
+	third, _ := service.Fetch(ctx, accountId)
+
+	// Assert
+
+	if firstErr != scriptedErr {
+		t.Errorf("mock returned unexpected error: got %v want %v", firstErr, scriptedErr)
+	}
+
+	if second.Data.ID != accountId.String() {
+		t.Errorf("mock returned unexpected scripted response: got %s want %s", second.Data.ID, accountId)
+	}
+
+	if third != fallbackResponse {
+		t.Errorf("mock did not fall back to FetchFunc: got %v want %v", third, fallbackResponse)
+	}
+
+	calls := mock.CallsTo(MethodFetch)
+
+	if len(calls) != 3 || calls[0].Args[0] != accountId {
+		t.Errorf("mock recorded unexpected calls: got %+v", calls)
+	}
+}
+
+func TestMock_DeleteMany_AppliesScriptsPerItem(t *testing.T) {
+
+	// Arrange
+
+	scriptedErr := errors.New("version conflict")
+
+	mock := New().Script(MethodDelete, Result{}, Result{Err: scriptedErr})
+
+	items := []accounts.DeleteItem{{AccountID: uuid.New()}, {AccountID: uuid.New()}, {AccountID: uuid.New()}}
+
+	// Act
+
+	results, err := mock.DeleteMany(context.Background(), items, &accounts.BatchOptions{Mode: accounts.FailFast})
+
+	// Assert
+
+	if err != scriptedErr {
+		t.Errorf("mock returned unexpected error: got %v want %v", err, scriptedErr)
+	}
+
+	if results[0].Err != nil || results[1].Err != scriptedErr || !errors.Is(results[2].Err, accounts.BatchAbortedError) {
+		t.Errorf("mock returned unexpected results: got %+v", results)
+	}
+
+	if len(mock.CallsTo(MethodDeleteMany)) != 1 || len(mock.CallsTo(MethodDelete)) != 2 {
+		t.Errorf("mock recorded unexpected calls: got %+v", mock.Calls())
+	}
+}
+
+func TestMock_NotStubbed_ReturnsErrNotStubbed(t *testing.T) {
+
+	// Arrange
+
+	mock := New()
+
+	ctx := context.Background()
+
+	// Act
+
+	response, fetchErr := mock.Fetch(ctx, uuid.New())
+	deleteErr := mock.Delete(ctx, uuid.New(), 0)
+
+	// Assert
+
+	if response != nil || !errors.Is(fetchErr, ErrNotStubbed) {
+		t.Errorf("mock returned unexpected fetch outcome: got %v and %v want %v", response, fetchErr, ErrNotStubbed)
+	}
+
+	if !errors.Is(deleteErr, ErrNotStubbed) {
+		t.Errorf("mock returned unexpected delete error: got %v want %v", deleteErr, ErrNotStubbed)
+	}
+}
@@ -0,0 +1,167 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+// AuditRecord is the record of an account mutation, the request sent by Create, Update or Delete
+type AuditRecord struct {
+
+	// Sequence, PrevHash and Hash chain the records together. They are set by the sink when appending
+	Sequence int64  `json:"sequence"`
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+
+	Timestamp time.Time `json:"timestamp"`
+	Operator  string    `json:"operator"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+
+	// BodyHash is the hex encoded SHA-256 of the request body, empty when the request has none
+	BodyHash string `json:"body_hash"`
+
+	// Status is the response status, 0 when no response was received
+	Status int `json:"status"`
+
+	// Error describes the transport failure when no response was received
+	Error string `json:"error,omitempty"`
+
+	AccountID string `json:"account_id"`
+	Version   int    `json:"version"`
+}
+
+// AuditSink stores audit records. Append must only return once the record is durably stored
+type AuditSink interface {
+	Append(ctx context.Context, record *AuditRecord) error
+}
+
+type operatorKey struct{}
+
+// WithOperator returns a context carrying the identity of the operator issuing requests, recorded in the audit trail
+func WithOperator(ctx context.Context, operator string) context.Context {
+	return context.WithValue(ctx, operatorKey{}, operator)
+}
+
+// OperatorFromContext returns the operator set with WithOperator, or an empty string
+func OperatorFromContext(ctx context.Context) string {
+	operator, _ := ctx.Value(operatorKey{}).(string)
+	return operator
+}
+
+// WithAuditSink appends a record to sink for every account mutation, sent by Create, Update or Delete, whether it
+// succeeds or not. Other requests, including the POSTs of other resources such as name verifications, are not
+// recorded. When the record cannot be stored the operation fails with AuditError, although the API may have applied it
+func WithAuditSink(sink AuditSink) ClientOption {
+	return func(c *Client) error {
+		c.auditSink = sink
+		return nil
+	}
+}
+
+type mutationKey struct{}
+
+// withMutation marks the requests sent with ctx as account mutations, which are audited. Create, Update and Delete
+// set it: requests are not classified by method since some POSTs only read, e.g. name verifications, and other
+// resources' requests are not account mutations
+func withMutation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mutationKey{}, true)
+}
+
+// isMutation reports whether ctx was marked by withMutation
+func isMutation(ctx context.Context) bool {
+	mutation, _ := ctx.Value(mutationKey{}).(bool)
+	return mutation
+}
+
+// audit appends the record of a mutation. The response body is buffered so the account id and version it holds
+// can be recorded and the response still decoded by the caller
+func (c *Client) audit(ctx context.Context, req *http.Request, body []byte, httpResp *http.Response, sendErr error) error {
+
+	record := &AuditRecord{
+		Timestamp: time.Now().UTC(),
+		Operator:  OperatorFromContext(ctx),
+		Method:    req.Method,
+		Path:      req.URL.Path,
+	}
+
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		record.BodyHash = hex.EncodeToString(sum[:])
+	}
+
+	record.AccountID, record.Version = auditedResource(req.URL, body)
+
+	if sendErr != nil {
+		record.Error = sendErr.Error()
+	}
+
+	if httpResp != nil {
+
+		record.Status = httpResp.StatusCode
+
+		respBody, err := ioutil.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		httpResp.Body = http.NoBody
+
+		if len(respBody) > 0 {
+			httpResp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+		}
+
+		if err != nil {
+			record.Error = err.Error()
+		}
+
+		if id, version := auditedResource(nil, respBody); id != "" {
+			record.AccountID, record.Version = id, version
+		}
+	}
+
+	if err := c.auditSink.Append(ctx, record); err != nil {
+
+		status := http.StatusInternalServerError
+
+		if httpResp != nil {
+			status = httpResp.StatusCode
+			httpResp.Body.Close()
+		}
+
+		return fmt.Errorf("%w | %d | %s", AuditError, status, err)
+	}
+
+	return nil
+}
+
+// auditedResource returns the id and version of the resource found in a JSON:API body, falling back to the last
+// path segment and the version query parameter of requestURL
+func auditedResource(requestURL *url.URL, body []byte) (string, int) {
+
+	document := struct {
+		Data *struct {
+			ID      string `json:"id"`
+			Version int    `json:"version"`
+		} `json:"data"`
+	}{}
+
+	if json.Unmarshal(body, &document) == nil && document.Data != nil && document.Data.ID != "" {
+		return document.Data.ID, document.Data.Version
+	}
+
+	if requestURL == nil {
+		return "", 0
+	}
+
+	version, _ := strconv.Atoi(requestURL.Query().Get("version"))
+
+	return path.Base(requestURL.Path), version
+}
@@ -0,0 +1,138 @@
+// Package audit provides append-only sinks for the accounts client audit trail, a JSONL file and a PostgreSQL
+// table. Records are hash chained: each one holds the hash of its predecessor, so altering, inserting, reordering or
+// removing a record followed by others breaks the chain, which Verify detects. Removing the trailing records leaves a
+// shorter chain which still verifies: to detect it, keep the Head of the chain outside the log and check it with
+// VerifyHead
+package audit
+
+import (
+	"crypto/sha256"
+	"ei09010/form3-api-client/accounts"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTampered is wrapped by the *TamperError returned when a chain does not verify
+var ErrTampered = errors.New("Audit trail has been tampered with")
+
+// TamperError locates the first record breaking the chain
+type TamperError struct {
+	Sequence int64
+	Reason   string
+}
+
+func (e *TamperError) Error() string {
+	return fmt.Sprintf("%s | record %d | %s", ErrTampered, e.Sequence, e.Reason)
+}
+
+func (e *TamperError) Unwrap() error {
+	return ErrTampered
+}
+
+// Head identifies the last record of a chain, to be kept apart from the log so truncating it can be detected
+type Head struct {
+	Sequence int64
+	Hash     string
+}
+
+// HeadOf returns the head of records, the zero Head for an empty chain
+func HeadOf(records []*accounts.AuditRecord) Head {
+
+	if len(records) == 0 {
+		return Head{}
+	}
+
+	last := records[len(records)-1]
+
+	return Head{Sequence: last.Sequence, Hash: last.Hash}
+}
+
+// Seal chains record to prev, the last stored record or nil for the first one, setting its sequence and hashes.
+// The timestamp is truncated to the microsecond, the precision PostgreSQL stores
+func Seal(record *accounts.AuditRecord, prev *accounts.AuditRecord) {
+
+	record.Sequence = 1
+	record.PrevHash = ""
+
+	if prev != nil {
+		record.Sequence = prev.Sequence + 1
+		record.PrevHash = prev.Hash
+	}
+
+	record.Timestamp = record.Timestamp.UTC().Truncate(time.Microsecond)
+	record.Hash = Hash(record)
+}
+
+// Hash returns the hex encoded SHA-256 of every field of record but its own hash
+func Hash(record *accounts.AuditRecord) string {
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s|%q|%s|%q|%s|%d|%q|%q|%d",
+		record.Sequence,
+		record.PrevHash,
+		record.Timestamp.UTC().Format(time.RFC3339Nano),
+		record.Operator,
+		record.Method,
+		record.Path,
+		record.BodyHash,
+		record.Status,
+		record.Error,
+		record.AccountID,
+		record.Version,
+	)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks records, in sequence order, form an unbroken chain starting at the first record
+func Verify(records []*accounts.AuditRecord) error {
+
+	var prev *accounts.AuditRecord
+
+	for _, record := range records {
+
+		expectedSequence, expectedPrevHash := int64(1), ""
+
+		if prev != nil {
+			expectedSequence, expectedPrevHash = prev.Sequence+1, prev.Hash
+		}
+
+		switch {
+		case record.Sequence != expectedSequence:
+			return &TamperError{Sequence: record.Sequence, Reason: fmt.Sprintf("expected sequence %d", expectedSequence)}
+		case record.PrevHash != expectedPrevHash:
+			return &TamperError{Sequence: record.Sequence, Reason: "previous hash does not match"}
+		case record.Hash != Hash(record):
+			return &TamperError{Sequence: record.Sequence, Reason: "hash does not match the record content"}
+		}
+
+		prev = record
+	}
+
+	return nil
+}
+
+// VerifyHead checks records form an unbroken chain, see Verify, which still holds the record of head. A head kept
+// before later appends verifies, while a chain truncated before it, or whose record at that sequence differs, does
+// not
+func VerifyHead(records []*accounts.AuditRecord, head Head) error {
+
+	if err := Verify(records); err != nil {
+		return err
+	}
+
+	if head.Sequence == 0 {
+		return nil
+	}
+
+	if int64(len(records)) < head.Sequence {
+		return &TamperError{Sequence: head.Sequence, Reason: fmt.Sprintf("record is missing, the chain ends at record %d", len(records))}
+	}
+
+	if records[head.Sequence-1].Hash != head.Hash {
+		return &TamperError{Sequence: head.Sequence, Reason: "hash does not match the head"}
+	}
+
+	return nil
+}
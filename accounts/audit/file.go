@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileSink appends records as JSON lines to a file. A file must have a single writing process
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+	last *accounts.AuditRecord
+}
+
+var _ accounts.AuditSink = (*FileSink)(nil)
+
+// OpenFile opens, or creates, the JSONL audit file at path and verifies the chain already stored in it
+func OpenFile(path string) (*FileSink, error) {
+
+	records, err := ReadFile(path)
+
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := Verify(records); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &FileSink{file: file}
+
+	if len(records) > 0 {
+		sink.last = records[len(records)-1]
+	}
+
+	return sink, nil
+}
+
+// Append chains record to the last one and writes it, syncing the file before returning
+func (s *FileSink) Append(_ context.Context, record *accounts.AuditRecord) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	Seal(record, s.last)
+
+	line, err := json.Marshal(record)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	s.last = record
+
+	return nil
+}
+
+// Head returns the head of the chain, to be stored apart from the file and checked with VerifyFileHead
+func (s *FileSink) Head() Head {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.last == nil {
+		return Head{}
+	}
+
+	return Head{Sequence: s.last.Sequence, Hash: s.last.Hash}
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// ReadFile reads the records of a JSONL audit file, in order
+func ReadFile(path string) ([]*accounts.AuditRecord, error) {
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	records := []*accounts.AuditRecord{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		record := &accounts.AuditRecord{}
+
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, &TamperError{Sequence: int64(line), Reason: fmt.Sprintf("line %d is not a record: %s", line, err)}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// VerifyFile reads and verifies a JSONL audit file
+func VerifyFile(path string) error {
+
+	records, err := ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	return Verify(records)
+}
+
+// VerifyFileHead reads a JSONL audit file and verifies it still holds the chain up to head, see VerifyHead
+func VerifyFileHead(path string, head Head) error {
+
+	records, err := ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	return VerifyHead(records, head)
+}
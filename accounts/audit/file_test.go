@@ -0,0 +1,208 @@
+package audit
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func appendRecords(t *testing.T, path string, n int) {
+
+	sink, err := OpenFile(path)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	defer sink.Close()
+
+	for i := 0; i < n; i++ {
+
+		record := &accounts.AuditRecord{
+			Timestamp: time.Now(),
+			Operator:  "alice",
+			Method:    "DELETE",
+			Path:      "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			Status:    204,
+			AccountID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			Version:   i,
+		}
+
+		if err := sink.Append(context.Background(), record); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+}
+
+func TestFileSink_ChainsRecordsAcrossReopens(t *testing.T) {
+
+	// Arrange
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	// Act
+
+	appendRecords(t, path, 2)
+	appendRecords(t, path, 1)
+
+	records, err := ReadFile(path)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("unexpected number of records: got %v want 3", len(records))
+	}
+
+	if records[2].Sequence != 3 || records[2].PrevHash != records[1].Hash {
+		t.Errorf("unexpected chaining: got sequence %v previous hash %v", records[2].Sequence, records[2].PrevHash)
+	}
+
+	if err := VerifyFile(path); err != nil {
+		t.Errorf("unexpected error: got %v want nil", err)
+	}
+}
+
+func TestVerifyFile_DetectsTampering(t *testing.T) {
+
+	cases := map[string]struct {
+		tamper   func(lines []string) []string
+		sequence int64
+	}{
+		"altered record": {
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"operator":"alice"`, `"operator":"mallory"`, 1)
+				return lines
+			},
+			sequence: 2,
+		},
+		"removed record": {
+			tamper:   func(lines []string) []string { return append(lines[:1], lines[2:]...) },
+			sequence: 3,
+		},
+		"reordered records": {
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+			sequence: 3,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+			appendRecords(t, path, 3)
+
+			content, err := ioutil.ReadFile(path)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			lines := c.tamper(strings.Split(strings.TrimSpace(string(content)), "\n"))
+
+			if err := ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			// Act
+
+			err = VerifyFile(path)
+
+			// Assert
+
+			var tamperErr *TamperError
+
+			if !errors.Is(err, ErrTampered) || !errors.As(err, &tamperErr) || tamperErr.Sequence != c.sequence {
+				t.Errorf("unexpected error: got %v want tampering at record %v", err, c.sequence)
+			}
+
+			if _, err := OpenFile(path); !errors.Is(err, ErrTampered) {
+				t.Errorf("unexpected open error: got %v want %v", err, ErrTampered)
+			}
+		})
+	}
+}
+
+func TestVerifyFileHead_DetectsTruncation(t *testing.T) {
+
+	cases := map[string]struct {
+		keep     int
+		append   int
+		sequence int64
+	}{
+		"untouched chain":               {keep: 3},
+		"chain appended after the head": {keep: 3, append: 2},
+		"truncated chain":               {keep: 2, sequence: 3},
+		"emptied chain":                 {keep: 0, sequence: 3},
+		"truncated then appended chain": {keep: 2, append: 1, sequence: 3},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+			appendRecords(t, path, 3)
+
+			sink, err := OpenFile(path)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			head := sink.Head()
+			sink.Close()
+
+			content, err := ioutil.ReadFile(path)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			lines := strings.SplitAfter(string(content), "\n")
+
+			if err := ioutil.WriteFile(path, []byte(strings.Join(lines[:c.keep], "")), 0600); err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			appendRecords(t, path, c.append)
+
+			// Act
+
+			err = VerifyFileHead(path, head)
+
+			// Assert
+
+			if c.sequence == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: got %v want nil", err)
+				}
+				return
+			}
+
+			var tamperErr *TamperError
+
+			if !errors.As(err, &tamperErr) || tamperErr.Sequence != c.sequence {
+				t.Errorf("unexpected error: got %v want tampering at record %v", err, c.sequence)
+			}
+
+			if err := VerifyFile(path); err != nil {
+				t.Errorf("unexpected error without head: got %v want the truncated chain to verify", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Row is an audit record as stored in the audit_records table
+type Row struct {
+	Sequence  int64     `gorm:"primary_key;auto_increment:false"`
+	PrevHash  string    `gorm:"type:char(64)"`
+	Hash      string    `gorm:"type:char(64);not null"`
+	Timestamp time.Time `gorm:"not null;index"`
+	Operator  string    `gorm:"type:varchar(255);index"`
+	Method    string    `gorm:"type:varchar(16);not null"`
+	Path      string    `gorm:"type:text;not null"`
+	BodyHash  string    `gorm:"type:varchar(64)"`
+	Status    int       `gorm:"not null"`
+	Error     string    `gorm:"type:text"`
+	AccountID string    `gorm:"type:varchar(255);index"`
+	Version   int       `gorm:"not null"`
+}
+
+func (*Row) TableName() string {
+	return "audit_records"
+}
+
+func newRow(record *accounts.AuditRecord) *Row {
+	row := Row(*record)
+	return &row
+}
+
+func (r *Row) record() *accounts.AuditRecord {
+	record := accounts.AuditRecord(*r)
+	record.Timestamp = record.Timestamp.UTC()
+	return &record
+}
+
+// appendOnlyTrigger rejects updates and deletes of stored records
+const appendOnlyTrigger = `
+CREATE OR REPLACE FUNCTION audit_records_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_records is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_records_append_only ON audit_records;
+
+CREATE TRIGGER audit_records_append_only BEFORE UPDATE OR DELETE ON audit_records
+	FOR EACH ROW EXECUTE PROCEDURE audit_records_append_only();
+`
+
+// PostgresSink appends records to the audit_records table through gorm. Several processes may share the table
+type PostgresSink struct {
+	db *gorm.DB
+}
+
+var _ accounts.AuditSink = (*PostgresSink)(nil)
+
+// NewPostgresSink constructs a PostgresSink using the given connection
+func NewPostgresSink(db *gorm.DB) *PostgresSink {
+	return &PostgresSink{db: db}
+}
+
+// Migrate creates the audit_records table along with a trigger rejecting updates and deletes
+func (s *PostgresSink) Migrate(_ context.Context) error {
+
+	if err := s.db.AutoMigrate(&Row{}).Error; err != nil {
+		return err
+	}
+
+	return s.db.Exec(appendOnlyTrigger).Error
+}
+
+// Append chains record to the last stored one and inserts it. The table is locked for the duration of the
+// transaction so concurrent writers extend the chain one at a time
+func (s *PostgresSink) Append(ctx context.Context, record *accounts.AuditRecord) error {
+
+	tx := s.db.BeginTx(ctx, nil)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer tx.Rollback()
+
+	if err := tx.Exec("LOCK TABLE audit_records IN EXCLUSIVE MODE").Error; err != nil {
+		return err
+	}
+
+	var prev *accounts.AuditRecord
+	last := &Row{}
+
+	err := tx.Order("sequence desc").First(last).Error
+
+	switch {
+	case err == nil:
+		prev = last.record()
+	case !gorm.IsRecordNotFoundError(err):
+		return err
+	}
+
+	Seal(record, prev)
+
+	if err := tx.Create(newRow(record)).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Records returns the stored records in sequence order
+func (s *PostgresSink) Records(_ context.Context) ([]*accounts.AuditRecord, error) {
+
+	var rows []*Row
+
+	if err := s.db.Order("sequence asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]*accounts.AuditRecord, 0, len(rows))
+
+	for _, row := range rows {
+		records = append(records, row.record())
+	}
+
+	return records, nil
+}
+
+// Verify reads and verifies the stored chain
+func (s *PostgresSink) Verify(ctx context.Context) error {
+
+	records, err := s.Records(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return Verify(records)
+}
+
+// VerifyHead reads the stored chain and verifies it still holds the chain up to head, see VerifyHead
+func (s *PostgresSink) VerifyHead(ctx context.Context, head Head) error {
+
+	records, err := s.Records(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return VerifyHead(records, head)
+}
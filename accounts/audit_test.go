@@ -0,0 +1,200 @@
+package accounts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type memoryAuditSink struct {
+	records []*AuditRecord
+	err     error
+}
+
+func (s *memoryAuditSink) Append(_ context.Context, record *AuditRecord) error {
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.records = append(s.records, record)
+
+	return nil
+}
+
+func TestWithAuditSink_RecordsMutations(t *testing.T) {
+
+	// Arrange
+
+	accountId := "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	var receivedBody []byte
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			receivedBody, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data":{"id":"` + accountId + `","version":0}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Write([]byte(`{"data":{"id":"` + accountId + `","version":0}}`))
+		}
+	}))
+
+	defer ts.Close()
+
+	sink := &memoryAuditSink{}
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithAuditSink(sink))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx := WithOperator(context.Background(), "alice")
+
+	// Act
+
+	_, createErr := accountsClient.Create(ctx, &AccountData{Data: &Data{ID: accountId, Type: "accounts", Attributes: &AccountAttributes{Country: "GB"}}})
+	_, fetchErr := accountsClient.Fetch(ctx, uuid.MustParse(accountId))
+	deleteErr := accountsClient.Delete(ctx, uuid.MustParse(accountId), 3)
+
+	// Assert
+
+	if createErr != nil || fetchErr != nil || deleteErr != nil {
+		t.Fatalf("unexpected errors: got %v %v %v", createErr, fetchErr, deleteErr)
+	}
+
+	if len(sink.records) != 2 {
+		t.Fatalf("unexpected number of records: got %v want 2", len(sink.records))
+	}
+
+	sum := sha256.Sum256(receivedBody)
+
+	cases := map[string]struct {
+		got  *AuditRecord
+		want AuditRecord
+	}{
+		"create": {got: sink.records[0], want: AuditRecord{Operator: "alice", Method: http.MethodPost, Path: "/v1/organisation/accounts", BodyHash: hex.EncodeToString(sum[:]), Status: http.StatusCreated, AccountID: accountId}},
+		"delete": {got: sink.records[1], want: AuditRecord{Operator: "alice", Method: http.MethodDelete, Path: "/v1/organisation/accounts/" + accountId, Status: http.StatusNoContent, AccountID: accountId, Version: 3}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			if c.got.Timestamp.IsZero() {
+				t.Errorf("unexpected timestamp: got zero")
+			}
+
+			c.got.Timestamp = c.want.Timestamp
+
+			if *c.got != c.want {
+				t.Errorf("unexpected record: got %+v want %+v", *c.got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithAuditSink_FailingSinkFailsTheOperation(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(`/v1/organisation/accounts/`, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithAuditSink(&memoryAuditSink{err: errors.New("disk full")}))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	err = accountsClient.Delete(context.Background(), uuid.New(), 0)
+
+	// Assert
+
+	if !errors.Is(err, AuditError) {
+		t.Errorf("unexpected error: got %v want %v", err, AuditError)
+	}
+}
+
+func TestWithAuditSink_RecordsTransportFailures(t *testing.T) {
+
+	// Arrange
+
+	sink := &memoryAuditSink{}
+
+	accountsClient, err := NewClient(WithBaseURL("http://127.0.0.1:1"), WithAuditSink(sink))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	err = accountsClient.Delete(context.Background(), uuid.New(), 0)
+
+	// Assert
+
+	if !errors.Is(err, BuildingRequestError) {
+		t.Errorf("unexpected error: got %v want %v", err, BuildingRequestError)
+	}
+
+	if len(sink.records) != 1 || sink.records[0].Status != 0 || sink.records[0].Error == "" {
+		t.Errorf("unexpected records: got %+v want one transport failure", sink.records)
+	}
+}
+
+func TestWithAuditSink_RecordsAccountMutationsOnly(t *testing.T) {
+
+	// Arrange
+
+	accountId := "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"data":{"id":"` + accountId + `","version":1}}`))
+	}))
+
+	defer ts.Close()
+
+	sink := &memoryAuditSink{}
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithAuditSink(sink))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	verifications := NewResource[Data](accountsClient, "cop", "/v1/validations/name-verifications")
+	ctx := context.Background()
+
+	// Act
+
+	_, verifyErr := verifications.Create(ctx, &Document[Data]{Data: &Data{ID: accountId}})
+	_, updateErr := accountsClient.Update(ctx, &AccountData{Data: &Data{ID: accountId, Version: 0, Attributes: &AccountAttributes{Country: "GB"}}})
+
+	// Assert
+
+	if verifyErr != nil || updateErr != nil {
+		t.Fatalf("unexpected errors: got %v %v", verifyErr, updateErr)
+	}
+
+	if len(sink.records) != 1 || sink.records[0].Method != http.MethodPatch || sink.records[0].AccountID != accountId {
+		t.Errorf("unexpected records: got %+v want the update only", sink.records)
+	}
+}
@@ -0,0 +1,213 @@
+// Package bankdirectory indexes public bank directories, such as EISCD sort code data or SEPA BIC lists, to look
+// up bank ids and BICs before accounts are created
+package bankdirectory
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Bank id codes of the directories loaded by this package
+const (
+	BankIDCodeGBDSC = "GBDSC"
+	BankIDCodeBIC   = "BIC"
+)
+
+// Entry is a bank, or bank branch, listed in a directory
+type Entry struct {
+	BankID     string
+	BankIDCode string
+	Bic        string
+	Country    string
+	Name       string
+
+	// Schemes lists the payment schemes the bank can be reached through, e.g. "FPS" or "SCT"
+	Schemes []string
+}
+
+// Directory is an in-memory store of directory entries indexed by bank id, BIC and country. It is safe for
+// concurrent use and implements accounts.AccountValidator
+type Directory struct {
+	mu        sync.RWMutex
+	byBankID  map[string]*Entry
+	byBic     map[string][]*Entry
+	byBank    map[string][]*Entry
+	byCountry map[string][]*Entry
+
+	// bankIdCodes holds the upper cased bank id codes of the indexed entries
+	bankIdCodes map[string]bool
+}
+
+var _ accounts.AccountValidator = (*Directory)(nil)
+
+// New constructs an empty Directory
+func New() *Directory {
+	return &Directory{
+		byBankID:    map[string]*Entry{},
+		byBic:       map[string][]*Entry{},
+		byBank:      map[string][]*Entry{},
+		byCountry:   map[string][]*Entry{},
+		bankIdCodes: map[string]bool{},
+	}
+}
+
+// Add indexes the given entries, replacing the entries with the same bank id and code, so loading a file again
+// refreshes the directory
+func (d *Directory) Add(entries ...*Entry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, entry := range entries {
+
+		entry.Bic = strings.ToUpper(entry.Bic)
+		entry.Country = strings.ToUpper(entry.Country)
+
+		if entry.BankID != "" {
+
+			key := bankKey(entry.BankIDCode, entry.BankID)
+
+			if previous, ok := d.byBankID[key]; ok {
+				d.remove(previous)
+			}
+
+			d.byBankID[key] = entry
+			d.bankIdCodes[strings.ToUpper(entry.BankIDCode)] = true
+		}
+
+		if entry.Bic != "" {
+			d.byBic[entry.Bic] = append(d.byBic[entry.Bic], entry)
+
+			if len(entry.Bic) == 11 {
+				d.byBank[entry.Bic[:8]] = append(d.byBank[entry.Bic[:8]], entry)
+			}
+		}
+
+		if entry.Country != "" {
+			d.byCountry[entry.Country] = append(d.byCountry[entry.Country], entry)
+		}
+	}
+}
+
+// remove drops entry from the BIC and country indexes
+func (d *Directory) remove(entry *Entry) {
+
+	if entry.Bic != "" {
+		removeFrom(d.byBic, entry.Bic, entry)
+
+		if len(entry.Bic) == 11 {
+			removeFrom(d.byBank, entry.Bic[:8], entry)
+		}
+	}
+
+	if entry.Country != "" {
+		removeFrom(d.byCountry, entry.Country, entry)
+	}
+}
+
+func removeFrom(index map[string][]*Entry, key string, entry *Entry) {
+
+	entries := index[key]
+
+	for i := range entries {
+		if entries[i] == entry {
+			entries = append(entries[:i:i], entries[i+1:]...)
+			break
+		}
+	}
+
+	if len(entries) == 0 {
+		delete(index, key)
+		return
+	}
+
+	index[key] = entries
+}
+
+// Len returns the number of entries indexed by bank id
+func (d *Directory) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return len(d.byBankID)
+}
+
+// LookupBankID returns the entry of the given bank id and bank id code, e.g. "400300" and "GBDSC"
+func (d *Directory) LookupBankID(bankIdCode string, bankId string) (*Entry, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	entry, ok := d.byBankID[bankKey(bankIdCode, bankId)]
+
+	return entry, ok
+}
+
+// LookupBic returns the latest entry added with the given BIC. An 8 character BIC which is not listed matches the
+// first branch of its bank
+func (d *Directory) LookupBic(bic string) (*Entry, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	bic = strings.ToUpper(bic)
+
+	if entries := d.byBic[bic]; len(entries) > 0 {
+		return entries[len(entries)-1], true
+	}
+
+	if entries := d.byBank[bic]; len(bic) == 8 && len(entries) > 0 {
+		return entries[0], true
+	}
+
+	return nil, false
+}
+
+// ByCountry returns the entries of the given ISO 3166 country
+func (d *Directory) ByCountry(country string) []*Entry {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return append([]*Entry(nil), d.byCountry[strings.ToUpper(country)]...)
+}
+
+// ValidateAccount rejects accounts whose bank id is not listed, and fills the bic when it is missing. Only the bank
+// id codes of loaded directories are checked, e.g. GBDSC once an EISCD file is loaded. Accounts without a bank id,
+// e.g. identified by BIC only, or with a bank id code no entry has, are left untouched
+func (d *Directory) ValidateAccount(ctx context.Context, account *accounts.Data) error {
+
+	if account == nil || account.Attributes == nil || account.Attributes.BankID == "" {
+		return nil
+	}
+
+	attributes := account.Attributes
+
+	if !d.hasBankIdCode(attributes.BankIDCode) {
+		return nil
+	}
+
+	entry, ok := d.LookupBankID(attributes.BankIDCode, attributes.BankID)
+
+	if !ok {
+		return fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest,
+			fmt.Sprintf("bank id %s %s is not listed in the directory", attributes.BankIDCode, attributes.BankID))
+	}
+
+	if attributes.Bic == "" {
+		attributes.Bic = entry.Bic
+	}
+
+	return nil
+}
+
+func (d *Directory) hasBankIdCode(bankIdCode string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.bankIdCodes[strings.ToUpper(bankIdCode)]
+}
+
+func bankKey(bankIdCode string, bankId string) string {
+	return strings.ToUpper(bankIdCode) + "/" + strings.ReplaceAll(bankId, "-", "")
+}
@@ -0,0 +1,231 @@
+package bankdirectory
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const eiscdFile = `Sort Code,BIC Bank,BIC Branch,Bank Name,FPS Status,BACS Status,CHAPS Status
+40-03-00,NWBK,GB22,National Westminster Bank,M,M,N
+20-00-00,BARC,GB22,Barclays Bank,A,M,M
+`
+
+const sepaFile = `BIC,Name,SCT,SCT Inst,SDD Core
+DEUTDEFFXXX,Deutsche Bank,Y,N,Y
+BNPAFRPP,BNP Paribas,Y,Y,Y
+`
+
+func newTestDirectory(t *testing.T) *Directory {
+
+	directory := New()
+
+	if _, err := directory.LoadEISCD(strings.NewReader(eiscdFile)); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if _, err := directory.LoadSEPA(strings.NewReader(sepaFile)); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return directory
+}
+
+func TestLoad_indexesEntriesByBankIdBicAndCountry(t *testing.T) {
+
+	// Arrange
+
+	directory := newTestDirectory(t)
+
+	// Act
+
+	bySortCode, sortCodeFound := directory.LookupBankID(BankIDCodeGBDSC, "400300")
+	byBic8, bicFound := directory.LookupBic("deutdeff")
+	french := directory.ByCountry("FR")
+
+	// Assert
+
+	if directory.Len() != 4 {
+		t.Errorf("directory holds unexpected number of entries: got %d want %d", directory.Len(), 4)
+	}
+
+	if !sortCodeFound || bySortCode.Bic != "NWBKGB22" || !reflect.DeepEqual(bySortCode.Schemes, []string{"FPS", "BACS"}) {
+		t.Errorf("lookup by sort code returned unexpected entry: got %+v", bySortCode)
+	}
+
+	if !bicFound || byBic8.Name != "Deutsche Bank" || byBic8.Country != "DE" {
+		t.Errorf("lookup by bic returned unexpected entry: got %+v", byBic8)
+	}
+
+	if len(french) != 1 || french[0].Bic != "BNPAFRPP" || len(french[0].Schemes) != 3 {
+		t.Errorf("lookup by country returned unexpected entries: got %+v", french)
+	}
+}
+
+func TestValidateAccountCases(t *testing.T) {
+
+	// Arrange
+
+	directory := newTestDirectory(t)
+
+	cases := map[string]struct {
+		attributes        *accounts.AccountAttributes
+		expectedBic       string
+		expectedErrorType error
+	}{
+		"Missing bic is filled": {
+			attributes:  &accounts.AccountAttributes{BankID: "400300", BankIDCode: "GBDSC"},
+			expectedBic: "NWBKGB22",
+		},
+		"Given bic is kept": {
+			attributes:  &accounts.AccountAttributes{BankID: "200000", BankIDCode: "GBDSC", Bic: "BARCGB2LXXX"},
+			expectedBic: "BARCGB2LXXX",
+		},
+		"Unknown bank id is rejected": {
+			attributes:        &accounts.AccountAttributes{BankID: "999999", BankIDCode: "GBDSC"},
+			expectedErrorType: accounts.ValidationError,
+		},
+		"Account without bank id is left untouched": {
+			attributes: &accounts.AccountAttributes{Country: "GB"},
+		},
+		"Bank id code without loaded directory is left untouched": {
+			attributes: &accounts.AccountAttributes{BankID: "20041", BankIDCode: "FRBDT", Country: "FR"},
+		},
+	}
+
+	for name, testCase := range cases {
+
+		// Act
+
+		err := directory.ValidateAccount(context.Background(), &accounts.Data{Attributes: testCase.attributes})
+
+		// Assert
+
+		if !errors.Is(err, testCase.expectedErrorType) || (err != nil && testCase.expectedErrorType == nil) {
+			t.Errorf("%s: unexpected error: got %v want %v", name, err, testCase.expectedErrorType)
+		}
+
+		if testCase.attributes.Bic != testCase.expectedBic {
+			t.Errorf("%s: unexpected bic: got %s want %s", name, testCase.attributes.Bic, testCase.expectedBic)
+		}
+	}
+}
+
+func TestLoadEISCD_MissingSortCodeColumn_ReturnsMalformedFileError(t *testing.T) {
+
+	// Act
+
+	loaded, err := New().LoadEISCD(strings.NewReader("BIC,Bank Name\nNWBKGB22,NatWest\n"))
+
+	// Assert
+
+	if loaded != 0 || !errors.Is(err, MalformedFileError) {
+		t.Errorf("load returned unexpected result: got %d, %v want %d, %v", loaded, err, 0, MalformedFileError)
+	}
+}
+
+func TestLoad_InvalidBicLength_ReturnsMalformedFileError(t *testing.T) {
+
+	cases := map[string]struct {
+		load func(*Directory) (int, error)
+	}{
+		"EISCD short BIC": {
+			load: func(d *Directory) (int, error) {
+				return d.LoadEISCD(strings.NewReader("Sort Code,BIC,Bank Name\n40-03-00,NWBK,NatWest\n"))
+			},
+		},
+		"SEPA short BIC": {
+			load: func(d *Directory) (int, error) {
+				return d.LoadSEPA(strings.NewReader("BIC,Name\nDEUTDE,Deutsche Bank\n"))
+			},
+		},
+		"SEPA BIC between 8 and 11 characters": {
+			load: func(d *Directory) (int, error) {
+				return d.LoadSEPA(strings.NewReader("BIC,Name\nDEUTDEFFX,Deutsche Bank\n"))
+			},
+		},
+	}
+
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			directory := New()
+
+			// Act
+
+			loaded, err := testCase.load(directory)
+
+			// Assert
+
+			if loaded != 0 || !errors.Is(err, MalformedFileError) {
+				t.Errorf("load returned unexpected result: got %d, %v want %d, %v", loaded, err, 0, MalformedFileError)
+			}
+
+			if directory.Len() != 0 {
+				t.Errorf("directory holds unexpected number of entries: got %d want %d", directory.Len(), 0)
+			}
+		})
+	}
+}
+
+func TestAdd_ShortBic_IsIndexedAsGiven(t *testing.T) {
+
+	// Arrange
+
+	directory := New()
+
+	// Act
+
+	directory.Add(&Entry{BankID: "400300", BankIDCode: BankIDCodeGBDSC, Bic: "nwbk"})
+
+	// Assert
+
+	entry, found := directory.LookupBic("NWBK")
+
+	if !found || entry.BankID != "400300" {
+		t.Errorf("lookup by bic returned unexpected entry: got %+v", entry)
+	}
+}
+
+func TestLoad_SameBankIdsAgain_ReplacesTheirEntries(t *testing.T) {
+
+	// Arrange
+
+	directory := newTestDirectory(t)
+
+	reloaded := `Sort Code,BIC,Bank Name
+40-03-00,NWBKGB2LXXX,National Westminster Bank
+20-00-00,BARCGB22,Barclays Bank
+`
+
+	// Act
+
+	if _, err := directory.LoadEISCD(strings.NewReader(reloaded)); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Assert
+
+	if directory.Len() != 4 {
+		t.Errorf("directory holds unexpected number of entries: got %d want %d", directory.Len(), 4)
+	}
+
+	if british := directory.ByCountry("GB"); len(british) != 2 {
+		t.Errorf("lookup by country returned unexpected entries: got %+v", british)
+	}
+
+	if stale, found := directory.LookupBic("NWBKGB22"); found {
+		t.Errorf("lookup by replaced bic returned unexpected entry: got %+v", stale)
+	}
+
+	for _, bic := range []string{"NWBKGB2LXXX", "NWBKGB2L"} {
+		if entry, found := directory.LookupBic(bic); !found || entry.BankID != "400300" {
+			t.Errorf("lookup by bic %s returned unexpected entry: got %+v", bic, entry)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package bankdirectory
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MalformedFileError is returned when a directory file cannot be parsed
+var MalformedFileError = errors.New("Malformed directory file")
+
+var (
+	eiscdSchemes = []string{"FPS", "BACS", "CHAPS"}
+	sepaSchemes  = []string{"SCT", "SCT Inst", "SDD Core", "SDD B2B"}
+)
+
+// LoadEISCD indexes an EISCD-style sort code CSV file and returns the number of entries loaded. The header row must
+// have a "Sort Code" column and may have "BIC" (or "BIC Bank" and "BIC Branch"), "Bank Name" and one column per
+// scheme ("FPS", "BACS", "CHAPS", optionally suffixed with "Status"), where any value but "N" marks the scheme reachable
+func (d *Directory) LoadEISCD(r io.Reader) (int, error) {
+
+	rows, err := readCSV(r, "sort code")
+
+	if err != nil {
+		return 0, err
+	}
+
+	entries := make([]*Entry, 0, len(rows))
+
+	for i, row := range rows {
+
+		bic := row.get("bic")
+
+		if bic == "" {
+			bic = row.get("bic bank") + row.get("bic branch")
+		}
+
+		if bic != "" && !validBic(bic) {
+			return 0, invalidBicError(i, bic)
+		}
+
+		entries = append(entries, &Entry{
+			BankID:     strings.ReplaceAll(row.get("sort code"), "-", ""),
+			BankIDCode: BankIDCodeGBDSC,
+			Bic:        bic,
+			Country:    "GB",
+			Name:       row.get("bank name", "short name of owning bank"),
+			Schemes:    row.schemes(eiscdSchemes),
+		})
+	}
+
+	d.Add(entries...)
+
+	return len(entries), nil
+}
+
+// LoadSEPA indexes a SEPA participants CSV file and returns the number of entries loaded. The header row must have
+// a "BIC" column and may have "Name", "Country" and one column per scheme ("SCT", "SCT Inst", "SDD Core",
+// "SDD B2B"). The country defaults to the one encoded in the BIC. Entries are indexed by BIC as their bank id
+func (d *Directory) LoadSEPA(r io.Reader) (int, error) {
+
+	rows, err := readCSV(r, "bic")
+
+	if err != nil {
+		return 0, err
+	}
+
+	entries := make([]*Entry, 0, len(rows))
+
+	for i, row := range rows {
+
+		bic := strings.ToUpper(row.get("bic"))
+
+		if !validBic(bic) {
+			return 0, invalidBicError(i, bic)
+		}
+
+		country := row.get("country")
+
+		if country == "" && len(bic) >= 6 {
+			country = bic[4:6]
+		}
+
+		entries = append(entries, &Entry{
+			BankID:     bic,
+			BankIDCode: BankIDCodeBIC,
+			Bic:        bic,
+			Country:    country,
+			Name:       row.get("name", "institution name"),
+			Schemes:    row.schemes(sepaSchemes),
+		})
+	}
+
+	d.Add(entries...)
+
+	return len(entries), nil
+}
+
+// validBic checks bic has the 8 or 11 characters of a bank or branch BIC
+func validBic(bic string) bool {
+	return len(bic) == 8 || len(bic) == 11
+}
+
+func invalidBicError(row int, bic string) error {
+	return fmt.Errorf("%w: row %d: BIC must have 8 or 11 characters: %q", MalformedFileError, row+1, bic)
+}
+
+// csvRow maps the lower cased header names of a file to the values of a row
+type csvRow map[string]string
+
+// get returns the value of the first of the named columns having one
+func (r csvRow) get(names ...string) string {
+
+	for _, name := range names {
+		if value := r[name]; value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func (r csvRow) schemes(schemes []string) []string {
+
+	var reachable []string
+
+	for _, scheme := range schemes {
+
+		name := strings.ToLower(scheme)
+		value := strings.ToUpper(r.get(name, name+" status", strings.ReplaceAll(name, " ", "_"), strings.ReplaceAll(name, " ", "_")+"_status"))
+
+		if value != "" && value != "N" {
+			reachable = append(reachable, scheme)
+		}
+	}
+
+	return reachable
+}
+
+// readCSV reads every row of a CSV file whose header row holds the required column
+func readCSV(r io.Reader, required string) ([]csvRow, error) {
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", MalformedFileError, err)
+	}
+
+	columns := make([]string, len(header))
+	hasRequired := false
+
+	for i, name := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		hasRequired = hasRequired || columns[i] == required || strings.ReplaceAll(columns[i], "_", " ") == required
+	}
+
+	if !hasRequired {
+		return nil, fmt.Errorf("%w: missing %q column", MalformedFileError, required)
+	}
+
+	var rows []csvRow
+
+	for {
+
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			return rows, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", MalformedFileError, err)
+		}
+
+		row := csvRow{}
+
+		for i, value := range record {
+			if i < len(columns) {
+				row[columns[i]] = strings.TrimSpace(value)
+				row[strings.ReplaceAll(columns[i], "_", " ")] = strings.TrimSpace(value)
+			}
+		}
+
+		if row.get(required) == "" {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("%w: line %d: empty %q", MalformedFileError, line, required)
+		}
+
+		rows = append(rows, row)
+	}
+}
@@ -0,0 +1,163 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Default number of concurrent requests issued by the batch operations
+const (
+	DefaultBatchConcurrency = 4
+)
+
+// BatchAbortedError is set on the items of a fail-fast batch which were not processed after another item failed
+var BatchAbortedError = errors.New("Batch aborted before processing the item")
+
+// BatchMode selects how a batch reacts to a failing item
+type BatchMode int
+
+const (
+	// BestEffort processes every item regardless of failures
+	BestEffort BatchMode = iota
+
+	// FailFast stops dispatching items after the first failure
+	FailFast
+)
+
+// BatchOptions holds the settings of CreateMany, FetchMany and DeleteMany
+type BatchOptions struct {
+	Concurrency int
+	Mode        BatchMode
+}
+
+// BatchResult is the outcome of a single batch item. Index is the position of the item in the batch input
+type BatchResult struct {
+	Index    int
+	Response *AccountResponse
+	Err      error
+}
+
+// DeleteItem identifies an account to remove with DeleteMany
+type DeleteItem struct {
+	AccountID uuid.UUID
+	Version   int
+}
+
+// CreateMany creates the given accounts concurrently. It returns one result per item, in input order,
+// and the first item error when running in FailFast mode or the context error when it is cancelled
+func (c *Client) CreateMany(ctx context.Context, accountsData []*AccountData, options *BatchOptions) ([]BatchResult, error) {
+	return c.runBatch(ctx, len(accountsData), options, func(ctx context.Context, i int) (*AccountResponse, error) {
+		return c.Create(ctx, accountsData[i])
+	})
+}
+
+// FetchMany fetches the given accounts concurrently. Results and errors follow the same rules as CreateMany
+func (c *Client) FetchMany(ctx context.Context, accountIds []uuid.UUID, options *BatchOptions) ([]BatchResult, error) {
+	return c.runBatch(ctx, len(accountIds), options, func(ctx context.Context, i int) (*AccountResponse, error) {
+		return c.Fetch(ctx, accountIds[i])
+	})
+}
+
+// DeleteMany deletes the given accounts concurrently. Results and errors follow the same rules as CreateMany
+func (c *Client) DeleteMany(ctx context.Context, items []DeleteItem, options *BatchOptions) ([]BatchResult, error) {
+	return c.runBatch(ctx, len(items), options, func(ctx context.Context, i int) (*AccountResponse, error) {
+		return nil, c.Delete(ctx, items[i].AccountID, items[i].Version)
+	})
+}
+
+func (c *Client) runBatch(parent context.Context, size int, options *BatchOptions, operation func(ctx context.Context, i int) (*AccountResponse, error)) ([]BatchResult, error) {
+
+	if options == nil {
+		options = &BatchOptions{}
+	}
+
+	concurrency := options.Concurrency
+
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	if concurrency > size {
+		concurrency = size
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	results := make([]BatchResult, size)
+	dispatched := make([]bool, size)
+
+	var firstErr error
+	var failOnce sync.Once
+
+	abortReason := func() error {
+		if err := parent.Err(); err != nil {
+			return err
+		}
+		return BatchAbortedError
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+
+				if ctx.Err() != nil {
+					results[i] = BatchResult{Index: i, Err: abortReason()}
+					continue
+				}
+
+				response, err := operation(ctx, i)
+
+				results[i] = BatchResult{Index: i, Response: response, Err: err}
+
+				if err != nil && options.Mode == FailFast {
+					failOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i := 0; i < size; i++ {
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case indexes <- i:
+			dispatched[i] = true
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	for i := range results {
+		if !dispatched[i] {
+			results[i] = BatchResult{Index: i, Err: abortReason()}
+		}
+	}
+
+	if firstErr != nil {
+		return results, firstErr
+	}
+
+	return results, parent.Err()
+}
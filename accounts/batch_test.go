@@ -0,0 +1,200 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFetchMany_BestEffort_ReturnsOneResultPerItemInOrder(t *testing.T) {
+
+	// Arrange
+
+	missingId := uuid.MustParse("b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d")
+
+	ts := newTestServer(`/v1/organisation/accounts/`, func(w http.ResponseWriter, r *http.Request) {
+
+		id := strings.TrimPrefix(r.URL.Path, "/v1/organisation/accounts/")
+
+		if id == missingId.String() {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, fmt.Sprintf(`{"error_message":"record %s does not exist"}`, id))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, fmt.Sprintf(`{"data":{"id":"%s","type":"accounts","version":0}}`, id))
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	accountIds := []uuid.UUID{
+		uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"),
+		missingId,
+		uuid.MustParse("c6d2f2a4-2c7d-4c9b-8e2a-3f4a5b6c7d8e"),
+	}
+
+	// Act
+
+	results, err := accountsClient.FetchMany(context.Background(), accountIds, &BatchOptions{Concurrency: 2})
+
+	// Assert
+
+	if err != nil {
+		t.Errorf("best effort batch returned an error: got %v want %v", err, nil)
+	}
+
+	if len(results) != len(accountIds) {
+		t.Fatalf("batch returned unexpected number of results: got %d want %d", len(results), len(accountIds))
+	}
+
+	for i, result := range results {
+
+		if result.Index != i {
+			t.Errorf("batch returned unexpected index: got %d want %d", result.Index, i)
+		}
+
+		if accountIds[i] == missingId {
+			assertClientError(result.Err, fmt.Sprintf("record %s does not exist", missingId), t, ApiHttpErrorType, http.StatusNotFound)
+			continue
+		}
+
+		if result.Err != nil || result.Response.Data.ID != accountIds[i].String() {
+			t.Errorf("batch returned unexpected result for item %d: got %+v", i, result)
+		}
+	}
+}
+
+func TestCreateMany_FailFast_StopsDispatchingAfterFirstError(t *testing.T) {
+
+	// Arrange
+
+	var requests int32
+
+	ts := newTestServer(`/v1/organisation/accounts`, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusConflict)
+		io.WriteString(w, `{"error_message":"Account cannot be created as it violates a duplicate constraint"}`)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	accountsData := make([]*AccountData, 10)
+
+	for i := range accountsData {
+		accountsData[i] = generateValidGenericAccountData()
+	}
+
+	// Act
+
+	results, err := accountsClient.CreateMany(context.Background(), accountsData, &BatchOptions{Concurrency: 1, Mode: FailFast})
+
+	// Assert
+
+	assertClientError(err, "Account cannot be created as it violates a duplicate constraint", t, ApiHttpErrorType, http.StatusConflict)
+
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 1)
+	}
+
+	for _, result := range results[1:] {
+		if !errors.Is(result.Err, BatchAbortedError) {
+			t.Errorf("batch returned unexpected error for skipped item %d: got %v want %v", result.Index, result.Err, BatchAbortedError)
+		}
+	}
+}
+
+func TestDeleteMany_CancelledContext_ReturnsContextError(t *testing.T) {
+
+	// Arrange
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ts := newTestServer(`/v1/organisation/accounts/`, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	items := make([]DeleteItem, 5)
+
+	for i := range items {
+		items[i] = DeleteItem{AccountID: uuid.New()}
+	}
+
+	// Act
+
+	results, err := accountsClient.DeleteMany(ctx, items, &BatchOptions{Concurrency: 1})
+
+	// Assert
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("batch returned unexpected error: got %v want %v", err, context.Canceled)
+	}
+
+	if !errors.Is(results[len(results)-1].Err, context.Canceled) {
+		t.Errorf("batch returned unexpected error for the last item: got %v want %v", results[len(results)-1].Err, context.Canceled)
+	}
+}
+
+func TestWithRateLimit_SpacesRequestsAfterBurst(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(`/v1/organisation/accounts/`, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithRateLimit(20, 1))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	items := []DeleteItem{{AccountID: uuid.New()}, {AccountID: uuid.New()}, {AccountID: uuid.New()}}
+
+	start := time.Now()
+
+	// Act
+
+	_, err = accountsClient.DeleteMany(context.Background(), items, &BatchOptions{Concurrency: 3})
+
+	// Assert
+
+	if err != nil {
+		t.Errorf("batch returned an error: got %v want %v", err, nil)
+	}
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("rate limiter did not delay requests: took %s want at least %s", elapsed, 100*time.Millisecond)
+	}
+}
@@ -0,0 +1,334 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Endpoint names used to select the operations guarded by the circuit breaker
+const (
+	EndpointCreate = "create"
+	EndpointFetch  = "fetch"
+	EndpointList   = "list"
+	EndpointDelete = "delete"
+	EndpointUpdate = "update"
+)
+
+// Default circuit breaker settings
+const (
+	DefaultBreakerFailureRatio = 0.5
+	DefaultBreakerMinRequests  = 10
+	DefaultBreakerWindow       = 30 * time.Second
+	DefaultBreakerBuckets      = 10
+	DefaultBreakerOpenTimeout  = 30 * time.Second
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through while recording failures
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects every request with ErrCircuitOpen
+	CircuitOpen
+
+	// CircuitHalfOpen lets a limited number of probe requests through to decide whether to close again
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreakerSettings configures WithCircuitBreaker. Zero values are replaced with the defaults
+type CircuitBreakerSettings struct {
+
+	// FailureRatio opens the circuit once failures/requests reaches it within the window
+	FailureRatio float64
+
+	// MinRequests is the number of requests needed within the window before the ratio is evaluated
+	MinRequests int
+
+	// Window is the length of the rolling window, split in Buckets slots
+	Window  time.Duration
+	Buckets int
+
+	// OpenTimeout is how long the circuit stays open before letting probes through
+	OpenTimeout time.Duration
+
+	// HalfOpenProbes is the number of concurrent probes allowed while half-open. The circuit closes once
+	// that many probes succeed and opens again on the first failed probe
+	HalfOpenProbes int
+
+	// Endpoints selects the guarded operations, e.g. EndpointCreate, or "payments.create" for resources built
+	// with NewResource. Every account endpoint is guarded when empty. Each endpoint has its own breaker
+	Endpoints []string
+
+	// OnStateChange is called, outside of any lock, every time a breaker changes state
+	OnStateChange func(endpoint string, from CircuitState, to CircuitState)
+}
+
+// WithCircuitBreaker guards the API endpoints with circuit breakers. A request counts as failed when it cannot be
+// sent or the API answers with a 5xx or 429 status. While open, requests fail immediately with ErrCircuitOpen
+func WithCircuitBreaker(settings CircuitBreakerSettings) ClientOption {
+	return func(c *Client) error {
+
+		if settings.FailureRatio < 0 || settings.FailureRatio > 1 {
+			return fmt.Errorf("%w | %d | %s", ClientCreationError, http.StatusBadRequest, "failure ratio must be between 0 and 1")
+		}
+
+		endpoints := settings.Endpoints
+
+		if len(endpoints) == 0 {
+			endpoints = []string{EndpointCreate, EndpointFetch, EndpointList, EndpointDelete, EndpointUpdate}
+		}
+
+		if c.breakers == nil {
+			c.breakers = map[string]*circuitBreaker{}
+		}
+
+		for _, endpoint := range endpoints {
+			c.breakers[endpoint] = newCircuitBreaker(endpoint, settings)
+		}
+
+		return nil
+	}
+}
+
+// CircuitState returns the state of the breaker guarding endpoint, or CircuitClosed when it is not guarded
+func (c *Client) CircuitState(endpoint string) CircuitState {
+
+	breaker := c.breakers[endpoint]
+
+	if breaker == nil {
+		return CircuitClosed
+	}
+
+	breaker.mu.Lock()
+
+	from := breaker.state
+	breaker.advance(breaker.now())
+	to := breaker.state
+
+	breaker.mu.Unlock()
+
+	breaker.notify(from, to)
+
+	return to
+}
+
+type breakerBucket struct {
+	start     time.Time
+	successes int
+	failures  int
+}
+
+type circuitBreaker struct {
+	mu             sync.Mutex
+	endpoint       string
+	settings       CircuitBreakerSettings
+	state          CircuitState
+	openedAt       time.Time
+	buckets        []breakerBucket
+	probes         int
+	probeSuccesses int
+	now            func() time.Time
+}
+
+func newCircuitBreaker(endpoint string, settings CircuitBreakerSettings) *circuitBreaker {
+
+	if settings.FailureRatio == 0 {
+		settings.FailureRatio = DefaultBreakerFailureRatio
+	}
+
+	if settings.MinRequests <= 0 {
+		settings.MinRequests = DefaultBreakerMinRequests
+	}
+
+	if settings.Window <= 0 {
+		settings.Window = DefaultBreakerWindow
+	}
+
+	if settings.Buckets <= 0 {
+		settings.Buckets = DefaultBreakerBuckets
+	}
+
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+
+	if settings.HalfOpenProbes <= 0 {
+		settings.HalfOpenProbes = 1
+	}
+
+	return &circuitBreaker{
+		endpoint: endpoint,
+		settings: settings,
+		buckets:  make([]breakerBucket, settings.Buckets),
+		now:      time.Now,
+	}
+}
+
+// allow reserves a slot for a request, failing with ErrCircuitOpen while the circuit rejects requests
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+
+	from := b.state
+	b.advance(b.now())
+	to := b.state
+
+	var err error
+
+	switch b.state {
+	case CircuitOpen:
+		err = fmt.Errorf("%w | %d | %s", ErrCircuitOpen, http.StatusServiceUnavailable, b.endpoint)
+	case CircuitHalfOpen:
+		if b.probes >= b.settings.HalfOpenProbes {
+			err = fmt.Errorf("%w | %d | %s", ErrCircuitOpen, http.StatusServiceUnavailable, b.endpoint)
+		} else {
+			b.probes++
+		}
+	}
+
+	b.mu.Unlock()
+
+	b.notify(from, to)
+
+	return err
+}
+
+// release gives back a slot reserved by a request which was never sent
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+// record stores the outcome of a request sent after allow
+func (b *circuitBreaker) record(httpResp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+
+	if errors.Is(err, context.Canceled) {
+		b.release()
+		return
+	}
+
+	failed := err != nil || httpResp.StatusCode >= http.StatusInternalServerError || httpResp.StatusCode == http.StatusTooManyRequests
+
+	b.mu.Lock()
+
+	from := b.state
+	now := b.now()
+
+	switch b.state {
+	case CircuitHalfOpen:
+		if b.probes > 0 {
+			b.probes--
+		}
+
+		if failed {
+			b.open(now)
+		} else {
+			b.probeSuccesses++
+
+			if b.probeSuccesses >= b.settings.HalfOpenProbes {
+				b.close()
+			}
+		}
+
+	case CircuitClosed:
+		bucket := b.bucket(now)
+
+		if failed {
+			bucket.failures++
+		} else {
+			bucket.successes++
+		}
+
+		if failed && b.tripped(now) {
+			b.open(now)
+		}
+	}
+
+	to := b.state
+
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// advance moves an open circuit to half-open once the open timeout elapsed
+func (b *circuitBreaker) advance(now time.Time) {
+	if b.state == CircuitOpen && now.Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.state = CircuitHalfOpen
+		b.probes = 0
+		b.probeSuccesses = 0
+	}
+}
+
+func (b *circuitBreaker) open(now time.Time) {
+	b.state = CircuitOpen
+	b.openedAt = now
+}
+
+func (b *circuitBreaker) close() {
+	b.state = CircuitClosed
+	b.buckets = make([]breakerBucket, b.settings.Buckets)
+}
+
+// bucket returns the window slot for now, resetting it when it belongs to an expired window
+func (b *circuitBreaker) bucket(now time.Time) *breakerBucket {
+
+	width := b.settings.Window / time.Duration(b.settings.Buckets)
+	start := now.Truncate(width)
+	bucket := &b.buckets[int(start.UnixNano()/int64(width))%len(b.buckets)]
+
+	if !bucket.start.Equal(start) {
+		*bucket = breakerBucket{start: start}
+	}
+
+	return bucket
+}
+
+// tripped reports whether the failures within the window reached the configured ratio
+func (b *circuitBreaker) tripped(now time.Time) bool {
+
+	requests, failures := 0, 0
+
+	for _, bucket := range b.buckets {
+		if now.Sub(bucket.start) < b.settings.Window {
+			requests += bucket.successes + bucket.failures
+			failures += bucket.failures
+		}
+	}
+
+	return requests >= b.settings.MinRequests && float64(failures)/float64(requests) >= b.settings.FailureRatio
+}
+
+func (b *circuitBreaker) notify(from CircuitState, to CircuitState) {
+	if from != to && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.endpoint, from, to)
+	}
+}
@@ -0,0 +1,216 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWithCircuitBreaker_FailureRatioReached_OpensAndFailsFast(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := newTestServer(`/v1/organisation/accounts/`, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		io.WriteString(w, `{"error_message":"service unavailable"}`)
+	})
+
+	defer ts.Close()
+
+	var transitions []CircuitState
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithCircuitBreaker(CircuitBreakerSettings{
+		FailureRatio: 0.5,
+		MinRequests:  3,
+		OnStateChange: func(endpoint string, from CircuitState, to CircuitState) {
+			transitions = append(transitions, to)
+		},
+	}))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	for i := 0; i < 3; i++ {
+		accountsClient.Fetch(ctx, accountId)
+	}
+
+	// Act
+
+	response, err := accountsClient.Fetch(ctx, accountId)
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	assertClientError(err, EndpointFetch, t, ErrCircuitOpen, http.StatusServiceUnavailable)
+
+	if requests != 3 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 3)
+	}
+
+	if accountsClient.CircuitState(EndpointFetch) != CircuitOpen {
+		t.Errorf("unexpected fetch circuit state: got %s want %s", accountsClient.CircuitState(EndpointFetch), CircuitOpen)
+	}
+
+	if accountsClient.CircuitState(EndpointDelete) != CircuitClosed {
+		t.Errorf("unexpected delete circuit state: got %s want %s", accountsClient.CircuitState(EndpointDelete), CircuitClosed)
+	}
+
+	if len(transitions) != 1 || transitions[0] != CircuitOpen {
+		t.Errorf("unexpected state change callbacks: got %v want %v", transitions, []CircuitState{CircuitOpen})
+	}
+}
+
+func TestWithCircuitBreaker_SuccessfulProbesAfterOpenTimeout_CloseTheCircuit(t *testing.T) {
+
+	// Arrange
+
+	healthy := false
+
+	ts := newTestServer(`/v1/organisation/accounts/`, func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, `{"error_message":"internal error"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithCircuitBreaker(CircuitBreakerSettings{
+		MinRequests:    1,
+		OpenTimeout:    time.Minute,
+		HalfOpenProbes: 2,
+		Endpoints:      []string{EndpointDelete},
+	}))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	now := time.Now()
+	accountsClient.breakers[EndpointDelete].now = func() time.Time { return now }
+
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	accountsClient.Delete(ctx, accountId, 0)
+
+	if err := accountsClient.Delete(ctx, accountId, 0); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("delete returned unexpected error: got %v want %v", err, ErrCircuitOpen)
+	}
+
+	healthy = true
+	now = now.Add(time.Minute)
+
+	// Act
+
+	firstProbeErr := accountsClient.Delete(ctx, accountId, 0)
+	stateAfterFirstProbe := accountsClient.CircuitState(EndpointDelete)
+	secondProbeErr := accountsClient.Delete(ctx, accountId, 0)
+
+	// Assert
+
+	if firstProbeErr != nil || secondProbeErr != nil {
+		t.Errorf("probes returned unexpected errors: got %v and %v want %v", firstProbeErr, secondProbeErr, nil)
+	}
+
+	if stateAfterFirstProbe != CircuitHalfOpen {
+		t.Errorf("unexpected circuit state after first probe: got %s want %s", stateAfterFirstProbe, CircuitHalfOpen)
+	}
+
+	if accountsClient.CircuitState(EndpointDelete) != CircuitClosed {
+		t.Errorf("unexpected circuit state after probes: got %s want %s", accountsClient.CircuitState(EndpointDelete), CircuitClosed)
+	}
+}
+
+func TestCircuitState_AfterOpenTimeout_NotifiesTheHalfOpenTransition(t *testing.T) {
+
+	// Arrange
+
+	healthy := false
+
+	ts := newTestServer(`/v1/organisation/accounts/`, func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	defer ts.Close()
+
+	var transitions []CircuitState
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithCircuitBreaker(CircuitBreakerSettings{
+		MinRequests: 1,
+		OpenTimeout: time.Minute,
+		Endpoints:   []string{EndpointDelete},
+		OnStateChange: func(endpoint string, from CircuitState, to CircuitState) {
+			transitions = append(transitions, to)
+		},
+	}))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	now := time.Now()
+	accountsClient.breakers[EndpointDelete].now = func() time.Time { return now }
+
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	accountsClient.Delete(ctx, accountId, 0)
+
+	healthy = true
+	now = now.Add(time.Minute)
+
+	// Act
+
+	state := accountsClient.CircuitState(EndpointDelete)
+	probeErr := accountsClient.Delete(ctx, accountId, 0)
+
+	// Assert
+
+	if state != CircuitHalfOpen || probeErr != nil {
+		t.Fatalf("unexpected state and probe error: got %s and %v want %s and nil", state, probeErr, CircuitHalfOpen)
+	}
+
+	expected := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}
+
+	if !reflect.DeepEqual(transitions, expected) {
+		t.Errorf("unexpected state change callbacks: got %v want %v", transitions, expected)
+	}
+}
+
+func TestWithCircuitBreaker_InvalidFailureRatio_ReturnsClientCreationError(t *testing.T) {
+
+	// Act
+
+	accountsClient, err := NewClient(WithCircuitBreaker(CircuitBreakerSettings{FailureRatio: 2}))
+
+	// Assert
+
+	if accountsClient != nil {
+		t.Errorf("Returned reponse: got %v want %v", accountsClient, nil)
+	}
+
+	assertClientError(err, "failure ratio must be between 0 and 1", t, ClientCreationError, http.StatusBadRequest)
+}
@@ -0,0 +1,281 @@
+package accounts
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cache stores fetched accounts between Fetch calls. Implementations must be safe for concurrent use,
+// which allows plugging shared stores such as Redis in place of the in-memory LRUCache
+type Cache interface {
+	Get(ctx context.Context, key string) (*CacheEntry, bool)
+	Set(ctx context.Context, key string, entry *CacheEntry)
+	Delete(ctx context.Context, key string)
+}
+
+// CacheEntry is a cached API response together with the validator used to revalidate it
+type CacheEntry struct {
+	ETag     string
+	Body     []byte
+	StoredAt time.Time
+}
+
+// CacheStats contains the hit and miss counters of the client cache
+type CacheStats struct {
+	// Hits counts the fetches served from the cache, either while fresh or after a 304 Not Modified
+	Hits uint64
+
+	// Misses counts the fetches which required a full response from the API
+	Misses uint64
+
+	// Revalidations counts the hits confirmed by the API with a 304 Not Modified
+	Revalidations uint64
+
+	// StaleHits counts the fetches served past their ttl because the API was unavailable
+	StaleHits uint64
+}
+
+type responseCache struct {
+	store         Cache
+	ttl           time.Duration
+	maxStaleness  time.Duration
+	hits          uint64
+	misses        uint64
+	revalidations uint64
+	staleHits     uint64
+}
+
+// WithCache enables caching of Fetch responses. Entries younger than ttl are served without calling the API,
+// older ones are revalidated with If-None-Match. When the API is unreachable or failing, entries younger than
+// maxStaleness are still served
+func WithCache(cache Cache, ttl time.Duration, maxStaleness time.Duration) ClientOption {
+	return func(c *Client) error {
+
+		if cache == nil {
+			return fmt.Errorf("%w | %d | %s", ClientCreationError, http.StatusBadRequest, "cache must not be nil")
+		}
+
+		if maxStaleness < ttl {
+			maxStaleness = ttl
+		}
+
+		c.cache = &responseCache{
+			store:        cache,
+			ttl:          ttl,
+			maxStaleness: maxStaleness,
+		}
+
+		return nil
+	}
+}
+
+// CacheStats returns a snapshot of the cache counters. It returns zeroed stats when caching is disabled
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+
+	return CacheStats{
+		Hits:          atomic.LoadUint64(&c.cache.hits),
+		Misses:        atomic.LoadUint64(&c.cache.misses),
+		Revalidations: atomic.LoadUint64(&c.cache.revalidations),
+		StaleHits:     atomic.LoadUint64(&c.cache.staleHits),
+	}
+}
+
+func (c *Client) invalidate(ctx context.Context, accountId uuid.UUID) {
+	if c.cache != nil {
+		c.cache.store.Delete(ctx, accountId.String())
+	}
+}
+
+func (c *Client) fetchCached(ctx context.Context, accountId uuid.UUID) (*AccountResponse, error) {
+
+	key := accountId.String()
+
+	entry, found := c.cache.store.Get(ctx, key)
+
+	if found && time.Since(entry.StoredAt) < c.cache.ttl {
+		atomic.AddUint64(&c.cache.hits, 1)
+		return decodeCacheEntry(entry)
+	}
+
+	header := http.Header{}
+
+	if found && entry.ETag != "" {
+		header.Set("If-None-Match", entry.ETag)
+	}
+
+	httpResp, err := c.send(ctx, http.MethodGet, AccountsApiDefaultUrl, AccountsApiDefaultUrl.path+"/"+key, nil, header, nil, EndpointFetch)
+
+	if err != nil {
+		if found && c.cache.servableWhenStale(entry) {
+			atomic.AddUint64(&c.cache.staleHits, 1)
+			return decodeCacheEntry(entry)
+		}
+
+		return nil, requestError(httpResp, err)
+	}
+
+	defer httpResp.Body.Close()
+
+	if found && httpResp.StatusCode == http.StatusNotModified {
+		atomic.AddUint64(&c.cache.hits, 1)
+		atomic.AddUint64(&c.cache.revalidations, 1)
+
+		c.cache.store.Set(ctx, key, &CacheEntry{ETag: entry.ETag, Body: entry.Body, StoredAt: time.Now()})
+
+		return decodeCacheEntry(entry)
+	}
+
+	if found && httpResp.StatusCode >= http.StatusInternalServerError && c.cache.servableWhenStale(entry) {
+		atomic.AddUint64(&c.cache.staleHits, 1)
+		return decodeCacheEntry(entry)
+	}
+
+	atomic.AddUint64(&c.cache.misses, 1)
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		c.cache.store.Delete(ctx, key)
+	}
+
+	accountResponse := &AccountResponse{}
+
+	body, err := decodeBody(httpResp, accountResponse, &accountResponse.apiCommonResult)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c.strict != nil {
+		if err := c.strict.check(body, accountResponse, httpResp.StatusCode, EndpointFetch); err != nil {
+			return nil, err
+		}
+	}
+
+	if accountResponse.AccountData == nil || accountResponse.Data == nil {
+		return nil, noDataError(accountResponse.Status)
+	}
+
+	if err := checkFetchedID(accountResponse, accountId); err != nil {
+		return nil, err
+	}
+
+	c.cache.store.Set(ctx, key, &CacheEntry{
+		ETag:     httpResp.Header.Get("ETag"),
+		Body:     body,
+		StoredAt: time.Now(),
+	})
+
+	return accountResponse, nil
+}
+
+func (rc *responseCache) servableWhenStale(entry *CacheEntry) bool {
+	return time.Since(entry.StoredAt) < rc.maxStaleness
+}
+
+func decodeCacheEntry(entry *CacheEntry) (*AccountResponse, error) {
+
+	accountResponse := &AccountResponse{}
+
+	if err := json.Unmarshal(entry.Body, accountResponse); err != nil {
+		return nil, fmt.Errorf("%w | %d | %s", BuildingRequestError, http.StatusOK, err)
+	}
+
+	accountResponse.Status = http.StatusOK
+
+	if accountResponse.AccountData == nil || accountResponse.Data == nil {
+		return nil, noDataError(accountResponse.Status)
+	}
+
+	return accountResponse, nil
+}
+
+// LRUCache is an in-memory Cache which evicts the least recently used entry once its capacity is reached
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewLRUCache constructs an LRUCache holding at most capacity entries
+func NewLRUCache(capacity int) *LRUCache {
+
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry stored under key and marks it as recently used
+func (l *LRUCache) Get(_ context.Context, key string) (*CacheEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	l.order.MoveToFront(element)
+
+	return element.Value.(*lruItem).entry, true
+}
+
+// Set stores entry under key, evicting the least recently used entry when full
+func (l *LRUCache) Set(_ context.Context, key string, entry *CacheEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.items[key]; ok {
+		element.Value.(*lruItem).entry = entry
+		l.order.MoveToFront(element)
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&lruItem{key: key, entry: entry})
+
+	if l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruItem).key)
+	}
+}
+
+// Delete removes the entry stored under key, if any
+func (l *LRUCache) Delete(_ context.Context, key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.items[key]; ok {
+		l.order.Remove(element)
+		delete(l.items, key)
+	}
+}
+
+// Len returns the number of entries currently cached
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.order.Len()
+}
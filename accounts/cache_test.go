@@ -0,0 +1,327 @@
+package accounts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const cachedAccountResponse = `{"data":{"attributes":{"account_classification":"Personal","alternative_names":["Alternative Names."],"bank_id":"400300","bank_id_code":"GBDSC","base_currency":"GBP","bic":"NWBKGB22","country":"GB","name":["Name of the account holder, up to four lines possible."]},"created_on":"2021-07-31T22:09:02.680Z","id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","modified_on":"2021-07-31T22:09:02.680Z","organisation_id":"eb0bd6f5-c3f5-44b2-b677-acd23cdde73c","type":"accounts","version":0},"links":{"self":"/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`
+
+func TestFetch_WithCache_ServesFreshEntryWithoutCallingTheApi(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := newTestServer(`/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc`, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v0"`)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, cachedAccountResponse)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithCache(NewLRUCache(10), time.Minute, time.Hour))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	// Act
+
+	_, err = accountsClient.Fetch(ctx, accountId)
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	response, err := accountsClient.Fetch(ctx, accountId)
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	// Assert
+
+	if requests != 1 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 1)
+	}
+
+	if response.Data.ID != accountId.String() {
+		t.Errorf("cache returned unexpected Id: got %s want %s", response.Data.ID, accountId)
+	}
+
+	stats := accountsClient.CacheStats()
+
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("unexpected cache stats: got %+v want %d hit and %d miss", stats, 1, 1)
+	}
+}
+
+func TestFetch_WithExpiredCacheEntry_RevalidatesWithIfNoneMatch(t *testing.T) {
+
+	// Arrange
+
+	receivedIfNoneMatch := ""
+
+	ts := newTestServer(`/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc`, func(w http.ResponseWriter, r *http.Request) {
+		receivedIfNoneMatch = r.Header.Get("If-None-Match")
+
+		if receivedIfNoneMatch == `"v0"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v0"`)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, cachedAccountResponse)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithCache(NewLRUCache(10), 0, time.Hour))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	// Act
+
+	_, err = accountsClient.Fetch(ctx, accountId)
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	response, err := accountsClient.Fetch(ctx, accountId)
+
+	// Assert
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if receivedIfNoneMatch != `"v0"` {
+		t.Errorf("server received unexpected If-None-Match: got %s want %s", receivedIfNoneMatch, `"v0"`)
+	}
+
+	if response.Data.Attributes.Bic != "NWBKGB22" {
+		t.Errorf("cache returned unexpected attributes Bic: got %s want %s", response.Data.Attributes.Bic, "NWBKGB22")
+	}
+
+	if stats := accountsClient.CacheStats(); stats.Revalidations != 1 {
+		t.Errorf("unexpected cache revalidations: got %d want %d", stats.Revalidations, 1)
+	}
+}
+
+func TestFetch_WithApiUnavailable_ServesStaleEntryWithinMaxStaleness(t *testing.T) {
+
+	// Arrange
+
+	apiDown := false
+
+	ts := newTestServer(`/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc`, func(w http.ResponseWriter, r *http.Request) {
+		if apiDown {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, cachedAccountResponse)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithCache(NewLRUCache(10), 0, time.Hour))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	_, err = accountsClient.Fetch(ctx, accountId)
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	apiDown = true
+
+	// Act
+
+	response, err := accountsClient.Fetch(ctx, accountId)
+
+	// Assert
+
+	if err != nil {
+		t.Errorf("fetch returned an error: got %v want %v", err, nil)
+	}
+
+	if response == nil || response.Data.ID != accountId.String() {
+		t.Errorf("stale cache returned unexpected response: got %v", response)
+	}
+
+	if stats := accountsClient.CacheStats(); stats.StaleHits != 1 {
+		t.Errorf("unexpected cache stale hits: got %d want %d", stats.StaleHits, 1)
+	}
+}
+
+func TestDelete_WithCache_InvalidatesCachedAccount(t *testing.T) {
+
+	// Arrange
+
+	cache := NewLRUCache(10)
+
+	ts := newTestServer(`/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc`, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, cachedAccountResponse)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithCache(cache, time.Minute, time.Hour))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	_, err = accountsClient.Fetch(ctx, accountId)
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	// Act
+
+	err = accountsClient.Delete(ctx, accountId, 0)
+
+	// Assert
+
+	if err != nil {
+		t.Errorf("delete returned an error: got %v want %v", err, nil)
+	}
+
+	if _, found := cache.Get(ctx, accountId.String()); found {
+		t.Errorf("cache still holds the deleted account")
+	}
+}
+
+func TestFetch_WithCache_SuccessWithoutData_ReturnsBuildingRequestError(t *testing.T) {
+
+	// Arrange
+
+	cache := NewLRUCache(10)
+
+	ts := newTestServer(`/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc`, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{}`)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithCache(cache, time.Minute, time.Hour))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	// Act
+
+	response, err := accountsClient.Fetch(ctx, accountId)
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	assertClientError(err, "response has no data", t, BuildingRequestError, http.StatusOK)
+
+	if _, found := cache.Get(ctx, accountId.String()); found {
+		t.Errorf("cache holds the response without data")
+	}
+}
+
+func TestFetch_WithCachedEntryWithoutData_ReturnsBuildingRequestError(t *testing.T) {
+
+	// Arrange
+
+	cache := NewLRUCache(10)
+	ctx := context.Background()
+	accountId := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	cache.Set(ctx, accountId.String(), &CacheEntry{Body: []byte(`{}`), StoredAt: time.Now()})
+
+	accountsClient, err := NewClient(WithCache(cache, time.Minute, time.Hour))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	response, err := accountsClient.Fetch(ctx, accountId)
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	assertClientError(err, "response has no data", t, BuildingRequestError, http.StatusOK)
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsedEntry(t *testing.T) {
+
+	// Arrange
+
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	cache.Set(ctx, "a", &CacheEntry{})
+	cache.Set(ctx, "b", &CacheEntry{})
+	cache.Get(ctx, "a")
+
+	// Act
+
+	cache.Set(ctx, "c", &CacheEntry{})
+
+	// Assert
+
+	if _, found := cache.Get(ctx, "b"); found {
+		t.Errorf("cache kept the least recently used entry")
+	}
+
+	if _, found := cache.Get(ctx, "a"); !found {
+		t.Errorf("cache evicted a recently used entry")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("unexpected cache length: got %d want %d", cache.Len(), 2)
+	}
+}
@@ -0,0 +1,247 @@
+package chaos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Distribution draws a latency
+type Distribution func(random *rand.Rand) time.Duration
+
+// Fixed always draws d
+func Fixed(d time.Duration) Distribution {
+	return func(*rand.Rand) time.Duration {
+		return d
+	}
+}
+
+// Uniform draws uniformly between min and max
+func Uniform(min time.Duration, max time.Duration) Distribution {
+	return func(random *rand.Rand) time.Duration {
+
+		if max <= min {
+			return min
+		}
+
+		return min + time.Duration(random.Int63n(int64(max-min)))
+	}
+}
+
+// Normal draws from a normal distribution, never below zero
+func Normal(mean time.Duration, stddev time.Duration) Distribution {
+	return func(random *rand.Rand) time.Duration {
+
+		d := random.NormFloat64()*float64(stddev) + float64(mean)
+
+		return time.Duration(math.Max(0, d))
+	}
+}
+
+type latency struct {
+	distribution Distribution
+	delay        time.Duration
+}
+
+// Latency delays requests by a duration drawn from distribution, or less if the request context is done first.
+// The duration is drawn from the random source of the transport, so WithSeed makes it reproducible
+func Latency(distribution Distribution) Fault {
+	return &latency{distribution: distribution}
+}
+
+func (f *latency) name() string {
+	return "latency"
+}
+
+// draw returns the fault delaying one request by a duration drawn from random
+func (f *latency) draw(random *rand.Rand) Fault {
+	return &latency{distribution: f.distribution, delay: f.distribution(random)}
+}
+
+func (f *latency) RoundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+
+	timer := time.NewTimer(f.delay)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		closeBody(req)
+		return nil, req.Context().Err()
+	case <-timer.C:
+	}
+
+	return next.RoundTrip(req)
+}
+
+type status struct {
+	code       int
+	retryAfter time.Duration
+}
+
+// Status answers requests with code, without sending them, and a Retry-After header in seconds when retryAfter
+// is positive. The body is a Form3 error message
+func Status(code int, retryAfter time.Duration) Fault {
+	return &status{code: code, retryAfter: retryAfter}
+}
+
+func (f *status) name() string {
+	return "status " + strconv.Itoa(f.code)
+}
+
+func (f *status) RoundTrip(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+
+	closeBody(req)
+
+	body := fmt.Sprintf(`{"error_message":"chaos: injected %d %s"}`, f.code, http.StatusText(f.code))
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+
+	if f.retryAfter > 0 {
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(f.retryAfter.Seconds()))))
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.code, http.StatusText(f.code)),
+		StatusCode:    f.code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+type connectionReset struct{}
+
+// ConnectionReset fails requests, without sending them, as if the server reset the connection
+func ConnectionReset() Fault {
+	return connectionReset{}
+}
+
+func (connectionReset) name() string {
+	return "connection reset"
+}
+
+func (connectionReset) RoundTrip(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+
+	closeBody(req)
+
+	return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+}
+
+// closeBody closes the body of a request which is not sent, as http.RoundTripper requires
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+type truncateBody struct {
+	fraction float64
+}
+
+// TruncateBody sends requests but cuts their response bodies to fraction of their length, e.g. leaving invalid JSON
+func TruncateBody(fraction float64) Fault {
+	return &truncateBody{fraction: fraction}
+}
+
+func (f *truncateBody) name() string {
+	return "truncated body"
+}
+
+func (f *truncateBody) RoundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+
+	res, err := next.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+
+	res.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	body = body[:int(float64(len(body))*math.Max(0, math.Min(1, f.fraction)))]
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	res.ContentLength = int64(len(body))
+	res.Header.Del("Content-Length")
+
+	return res, nil
+}
+
+type slowBody struct {
+	chunk    int
+	interval time.Duration
+}
+
+// SlowBody sends requests but drips their response bodies, chunk bytes every interval
+func SlowBody(chunk int, interval time.Duration) Fault {
+
+	if chunk < 1 {
+		chunk = 1
+	}
+
+	return &slowBody{chunk: chunk, interval: interval}
+}
+
+func (f *slowBody) name() string {
+	return "slow body"
+}
+
+func (f *slowBody) RoundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+
+	res, err := next.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = &dripReader{body: res.Body, chunk: f.chunk, interval: f.interval, ctx: req.Context()}
+
+	return res, nil
+}
+
+// dripReader reads at most chunk bytes per interval from body, failing once ctx is done
+type dripReader struct {
+	body     io.ReadCloser
+	chunk    int
+	interval time.Duration
+	ctx      context.Context
+}
+
+func (r *dripReader) Read(p []byte) (int, error) {
+
+	timer := time.NewTimer(r.interval)
+	defer timer.Stop()
+
+	select {
+	case <-r.ctx.Done():
+		return 0, r.ctx.Err()
+	case <-timer.C:
+	}
+
+	if len(p) > r.chunk {
+		p = p[:r.chunk]
+	}
+
+	return r.body.Read(p)
+}
+
+func (r *dripReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,195 @@
+// Package chaos provides an http.RoundTripper injecting faults, such as latency, error statuses, connection resets
+// and damaged bodies, to test how a client and its retry and circuit breaker settings cope with them:
+//
+//	transport := chaos.New(nil, chaos.WithSeed(42), chaos.WithRule(chaos.Rule{
+//		Method:      http.MethodGet,
+//		Path:        "/v1/organisation/accounts/*",
+//		Probability: 0.2,
+//		Fault:       chaos.Status(http.StatusServiceUnavailable, time.Second),
+//	}))
+//
+//	client, err := accounts.NewClient(accounts.WithHTTPClient(&http.Client{Transport: transport}))
+package chaos
+
+import (
+	"math/rand"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// Fault alters a request round trip. next sends the request on, through the faults of the following rules then
+// the base transport. A fault which does not call next must close the request body
+type Fault interface {
+	RoundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error)
+}
+
+// drawer is implemented by faults drawing random values, e.g. Latency. The transport calls draw from its own random
+// source when the rule fires, so that WithSeed covers them
+type drawer interface {
+	draw(random *rand.Rand) Fault
+}
+
+// Rule injects Fault into the requests it matches with the given Probability
+type Rule struct {
+
+	// Name identifies the rule in Injected. The fault type is used when empty
+	Name string
+
+	// Method matches the request method, any method when empty
+	Method string
+
+	// Path is a path.Match pattern matched against the request path, e.g. "/v1/organisation/accounts/*". Any path
+	// matches when empty
+	Path string
+
+	// Probability, between 0 and 1, that a matching request gets the fault
+	Probability float64
+
+	Fault Fault
+}
+
+func (r *Rule) matches(req *http.Request) bool {
+
+	if r.Method != "" && r.Method != req.Method {
+		return false
+	}
+
+	if r.Path == "" {
+		return true
+	}
+
+	matched, err := path.Match(r.Path, req.URL.Path)
+
+	return err == nil && matched
+}
+
+// Option is the type of constructor options for New(...)
+type Option func(*Transport)
+
+// WithRule adds rules, evaluated in order. Every rule which fires on a request applies its fault, the first one
+// wrapping the others
+func WithRule(rules ...Rule) Option {
+	return func(t *Transport) {
+		t.rules = append(t.rules, rules...)
+	}
+}
+
+// WithSeed makes the fault injection reproducible, both which rules fire and the latencies they draw
+func WithSeed(seed int64) Option {
+	return func(t *Transport) {
+		t.random = rand.New(rand.NewSource(seed))
+	}
+}
+
+// Transport is an http.RoundTripper injecting the faults of its rules before delegating to a base transport
+type Transport struct {
+	base  http.RoundTripper
+	rules []Rule
+
+	mu       sync.Mutex
+	random   *rand.Rand
+	injected map[string]int
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// New constructs a Transport delegating to base, or to http.DefaultTransport when base is nil
+func New(base http.RoundTripper, options ...Option) *Transport {
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t := &Transport{
+		base:     base,
+		random:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		injected: map[string]int{},
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	return t
+}
+
+// RoundTrip rolls every matching rule and sends the request through the faults which fired
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	var next http.RoundTripper = t.base
+
+	fired := t.fire(req)
+
+	for i := len(fired) - 1; i >= 0; i-- {
+		next = &faultStep{fault: fired[i], next: next}
+	}
+
+	return next.RoundTrip(req)
+}
+
+// Injected returns the number of times each rule fired, keyed by rule name
+func (t *Transport) Injected() map[string]int {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	injected := make(map[string]int, len(t.injected))
+
+	for name, count := range t.injected {
+		injected[name] = count
+	}
+
+	return injected
+}
+
+// fire rolls every matching rule and returns the faults of the rules which fired, with their random values drawn
+func (t *Transport) fire(req *http.Request) []Fault {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var fired []Fault
+
+	for _, rule := range t.rules {
+
+		if !rule.matches(req) || t.random.Float64() >= rule.Probability {
+			continue
+		}
+
+		fault := rule.Fault
+
+		if drawn, ok := fault.(drawer); ok {
+			fault = drawn.draw(t.random)
+		}
+
+		fired = append(fired, fault)
+		t.injected[ruleName(rule)]++
+	}
+
+	return fired
+}
+
+func ruleName(rule Rule) string {
+
+	if rule.Name != "" {
+		return rule.Name
+	}
+
+	if named, ok := rule.Fault.(interface{ name() string }); ok {
+		return named.name()
+	}
+
+	return "fault"
+}
+
+// faultStep adapts a Fault and the rest of the chain to http.RoundTripper
+type faultStep struct {
+	fault Fault
+	next  http.RoundTripper
+}
+
+func (s *faultStep) RoundTrip(req *http.Request) (*http.Response, error) {
+	return s.fault.RoundTrip(req, s.next)
+}
@@ -0,0 +1,376 @@
+package chaos_test
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/chaos"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const accountId = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+func newServer(requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": accountId, "type": "accounts", "version": 0},
+		})
+	}))
+}
+
+func get(t *testing.T, transport *chaos.Transport, url string) (*http.Response, []byte, error) {
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	res, err := transport.RoundTrip(req)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+
+	return res, body, err
+}
+
+func TestTransport_Faults(t *testing.T) {
+
+	cases := map[string]struct {
+		fault     chaos.Fault
+		status    int
+		sent      int
+		assertion func(t *testing.T, res *http.Response, body []byte, err error)
+	}{
+		"status": {
+			fault:  chaos.Status(http.StatusTooManyRequests, 1500*time.Millisecond),
+			status: http.StatusTooManyRequests,
+			assertion: func(t *testing.T, res *http.Response, body []byte, err error) {
+				if res.Header.Get("Retry-After") != "2" {
+					t.Errorf("unexpected Retry-After: got %v want 2", res.Header.Get("Retry-After"))
+				}
+			},
+		},
+		"connection reset": {
+			fault: chaos.ConnectionReset(),
+			assertion: func(t *testing.T, res *http.Response, body []byte, err error) {
+				if !errors.Is(err, syscall.ECONNRESET) {
+					t.Errorf("unexpected error: got %v want %v", err, syscall.ECONNRESET)
+				}
+			},
+		},
+		"truncated body": {
+			fault:  chaos.TruncateBody(0.5),
+			status: http.StatusOK,
+			sent:   1,
+			assertion: func(t *testing.T, res *http.Response, body []byte, err error) {
+				if json.Valid(body) || len(body) == 0 {
+					t.Errorf("unexpected body: got %s want truncated JSON", body)
+				}
+			},
+		},
+		"slow body": {
+			fault:  chaos.SlowBody(16, time.Millisecond),
+			status: http.StatusOK,
+			sent:   1,
+			assertion: func(t *testing.T, res *http.Response, body []byte, err error) {
+				if err != nil || !json.Valid(body) {
+					t.Errorf("unexpected body: got %s and %v want the whole JSON", body, err)
+				}
+			},
+		},
+		"latency": {
+			fault:  chaos.Latency(chaos.Fixed(10 * time.Millisecond)),
+			status: http.StatusOK,
+			sent:   1,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			requests := 0
+
+			ts := newServer(&requests)
+
+			defer ts.Close()
+
+			transport := chaos.New(nil, chaos.WithRule(chaos.Rule{Name: name, Probability: 1, Fault: c.fault}))
+
+			// Act
+
+			res, body, err := get(t, transport, ts.URL+"/v1/organisation/accounts/"+accountId)
+
+			// Assert
+
+			if res != nil && res.StatusCode != c.status {
+				t.Errorf("unexpected status: got %v want %v", res.StatusCode, c.status)
+			}
+
+			if requests != c.sent {
+				t.Errorf("unexpected requests sent: got %v want %v", requests, c.sent)
+			}
+
+			if transport.Injected()[name] != 1 {
+				t.Errorf("unexpected injections: got %v want 1", transport.Injected())
+			}
+
+			if c.assertion != nil {
+				c.assertion(t, res, body, err)
+			}
+		})
+	}
+}
+
+func TestTransport_RulesMatchMethodPathAndProbability(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := newServer(&requests)
+
+	defer ts.Close()
+
+	transport := chaos.New(nil, chaos.WithSeed(7), chaos.WithRule(
+		chaos.Rule{Name: "deletes", Method: http.MethodDelete, Probability: 1, Fault: chaos.ConnectionReset()},
+		chaos.Rule{Name: "health", Path: "/v1/health", Probability: 1, Fault: chaos.ConnectionReset()},
+		chaos.Rule{Name: "fetches", Path: "/v1/organisation/accounts/*", Probability: 0.5, Fault: chaos.Status(http.StatusServiceUnavailable, 0)},
+	))
+
+	// Act
+
+	failures := 0
+
+	for i := 0; i < 200; i++ {
+
+		res, _, err := get(t, transport, ts.URL+"/v1/organisation/accounts/"+accountId)
+
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		if res.StatusCode == http.StatusServiceUnavailable {
+			failures++
+		}
+	}
+
+	// Assert
+
+	injected := transport.Injected()
+
+	if injected["deletes"] != 0 || injected["health"] != 0 {
+		t.Errorf("unexpected injections: got %v want none by non matching rules", injected)
+	}
+
+	if failures != injected["fetches"] || failures < 70 || failures > 130 {
+		t.Errorf("unexpected failures: got %v want about 100, as counted %v", failures, injected["fetches"])
+	}
+
+	if requests != 200-failures {
+		t.Errorf("unexpected requests sent: got %v want %v", requests, 200-failures)
+	}
+}
+
+func TestTransport_LatencyRespectsTheContext(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := newServer(&requests)
+
+	defer ts.Close()
+
+	transport := chaos.New(nil, chaos.WithRule(chaos.Rule{Probability: 1, Fault: chaos.Latency(chaos.Fixed(time.Minute))}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+
+	// Act
+
+	_, err := transport.RoundTrip(req)
+
+	// Assert
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("unexpected error: got %v want %v", err, context.DeadlineExceeded)
+	}
+
+	if requests != 0 {
+		t.Errorf("unexpected requests sent: got %v want 0", requests)
+	}
+}
+
+func TestTransport_InjectedErrorsOpenTheClientCircuitBreaker(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := newServer(&requests)
+
+	defer ts.Close()
+
+	transport := chaos.New(nil, chaos.WithRule(chaos.Rule{
+		Method:      http.MethodGet,
+		Probability: 1,
+		Fault:       chaos.Status(http.StatusServiceUnavailable, time.Second),
+	}))
+
+	client, err := accounts.NewClient(
+		accounts.WithBaseURL(ts.URL),
+		accounts.WithHTTPClient(&http.Client{Transport: transport}),
+		accounts.WithCircuitBreaker(accounts.CircuitBreakerSettings{FailureRatio: 0.5, MinRequests: 3}),
+	)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		client.Fetch(ctx, uuid.MustParse(accountId))
+	}
+
+	// Act
+
+	_, err = client.Fetch(ctx, uuid.MustParse(accountId))
+
+	// Assert
+
+	if !errors.Is(err, accounts.ErrCircuitOpen) {
+		t.Errorf("unexpected error: got %v want %v", err, accounts.ErrCircuitOpen)
+	}
+
+	if client.CircuitState(accounts.EndpointFetch) != accounts.CircuitOpen {
+		t.Errorf("unexpected circuit state: got %s want %s", client.CircuitState(accounts.EndpointFetch), accounts.CircuitOpen)
+	}
+
+	if injected := transport.Injected()["status 503"]; injected != 3 || requests != 0 {
+		t.Errorf("unexpected traffic: got %v injections and %v requests want 3 and 0", injected, requests)
+	}
+}
+
+func TestTransport_WithSeed_DrawsTheSameLatencies(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := newServer(&requests)
+	defer ts.Close()
+
+	draws := func() []time.Duration {
+
+		var drawn []time.Duration
+
+		transport := chaos.New(nil, chaos.WithSeed(42), chaos.WithRule(chaos.Rule{
+			Probability: 1,
+			Fault: chaos.Latency(func(random *rand.Rand) time.Duration {
+				delay := time.Duration(random.Int63n(int64(time.Millisecond)))
+				drawn = append(drawn, delay)
+				return delay
+			}),
+		}))
+
+		for i := 0; i < 3; i++ {
+			if _, _, err := get(t, transport, ts.URL+"/v1/organisation/accounts/"+accountId); err != nil {
+				t.Fatalf(err.Error())
+			}
+		}
+
+		return drawn
+	}
+
+	// Act
+
+	first := draws()
+	second := draws()
+
+	// Assert
+
+	if len(first) != 3 || !reflect.DeepEqual(first, second) {
+		t.Errorf("unexpected latencies: got %v and %v want the same 3 draws", first, second)
+	}
+}
+
+// trackedBody records whether it was closed
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestTransport_FaultsNotSendingTheRequest_CloseItsBody(t *testing.T) {
+
+	cases := map[string]struct {
+		fault chaos.Fault
+	}{
+		"status":           {fault: chaos.Status(http.StatusServiceUnavailable, 0)},
+		"connection reset": {fault: chaos.ConnectionReset()},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			requests := 0
+
+			ts := newServer(&requests)
+			defer ts.Close()
+
+			transport := chaos.New(nil, chaos.WithRule(chaos.Rule{Probability: 1, Fault: c.fault}))
+			body := &trackedBody{Reader: strings.NewReader(`{"data":{}}`)}
+
+			req, err := http.NewRequest(http.MethodPost, ts.URL+"/v1/organisation/accounts", body)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			// Act
+
+			res, _ := transport.RoundTrip(req)
+
+			if res != nil {
+				res.Body.Close()
+			}
+
+			// Assert
+
+			if !body.closed || requests != 0 {
+				t.Errorf("unexpected request handling: got body closed %v and %d requests sent want true and 0", body.closed, requests)
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package accounts
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type apiConfig struct {
@@ -27,6 +31,16 @@ type Client struct {
 	httpClient interface {
 		Do(req *http.Request) (*http.Response, error)
 	}
+	organisationID string
+	cache          *responseCache
+	limiter        *rateLimiter
+	breakers       map[string]*circuitBreaker
+	validators     []AccountValidator
+	auditSink      AuditSink
+	dryRun         *dryRunLog
+	strict         *strictDecoding
+	requestHooks   []RequestHook
+	responseHooks  []ResponseHook
 }
 
 // NewClient constructs a new Client which can make requests to the Form3 API
@@ -74,3 +88,118 @@ func WithBaseURL(baseURL string) ClientOption {
 		return nil
 	}
 }
+
+// WithHTTPClient sends the requests through httpClient, e.g. one with a custom Transport such as chaos.Transport
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+
+		if httpClient == nil {
+			return fmt.Errorf("%w | %d | %s", ClientCreationError, http.StatusBadRequest, "http client is required")
+		}
+
+		c.httpClient = httpClient
+
+		return nil
+	}
+}
+
+// WithOrganisationID scopes the client to an organisation. Accounts created without an organisation_id are
+// created under it
+func WithOrganisationID(organisationID string) ClientOption {
+	return func(c *Client) error {
+
+		if _, err := uuid.Parse(organisationID); err != nil {
+			return fmt.Errorf("%w | %d | %s", ClientCreationError, http.StatusBadRequest, "organisation id must be a uuid")
+		}
+
+		c.organisationID = organisationID
+
+		return nil
+	}
+}
+
+// OrganisationID returns the organisation the client is scoped to, or an empty string when it is not scoped
+func (c *Client) OrganisationID() string {
+	return c.organisationID
+}
+
+// WithRateLimit caps the number of requests sent per second across all the client operations.
+// burst is the number of requests which may be sent at once before the limit kicks in
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) error {
+
+		if requestsPerSecond <= 0 {
+			return fmt.Errorf("%w | %d | %s", ClientCreationError, http.StatusBadRequest, "requests per second must be positive")
+		}
+
+		c.limiter = newRateLimiter(requestsPerSecond, burst)
+
+		return nil
+	}
+}
+
+// newRequest builds a request against the given path, resolved from the client base url, or from the api
+// default host when no base url was configured. The client base url itself is never modified
+func (c *Client) newRequest(ctx context.Context, method string, config *apiConfig, path string, query url.Values, body io.Reader) (*http.Request, error) {
+
+	base := c.baseURL
+
+	if base.Host == "" {
+		defaultHost, err := url.Parse(config.host)
+
+		if err != nil {
+			return nil, err
+		}
+
+		base = defaultHost
+	}
+
+	requestURL, err := base.Parse(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if query != nil {
+		requestURL.RawQuery = query.Encode()
+	}
+
+	customReq, err := http.NewRequestWithContext(ctx, method, requestURL.String(), body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	addHeaders(customReq)
+
+	return customReq, nil
+}
+
+// do sends the request once the circuit breaker of the endpoint and the rate limiter allow it
+func (c *Client) do(req *http.Request, endpoint string) (*http.Response, error) {
+
+	breaker := c.breakers[endpoint]
+
+	if breaker != nil {
+		if err := breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.wait(req.Context()); err != nil {
+			breaker.release()
+			return nil, err
+		}
+	}
+
+	httpResp, err := c.httpClient.Do(req)
+
+	breaker.record(httpResp, err)
+
+	if err != nil {
+		return httpResp, &transportFailure{err: err}
+	}
+
+	return httpResp, nil
+}
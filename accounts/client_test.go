@@ -131,6 +131,36 @@ func TestClientErrorCases(t *testing.T) {
 
 }
 
+func TestWithOrganisationID_InvalidId_ReturnsClientCreationError(t *testing.T) {
+
+	// Act
+
+	accountClient, err := NewClient(WithOrganisationID("not-a-uuid"))
+
+	// Assert
+
+	if accountClient != nil {
+		t.Errorf("Returned reponse: got %v want %v", accountClient, nil)
+	}
+
+	assertClientError(err, "organisation id must be a uuid", t, ClientCreationError, http.StatusBadRequest)
+}
+
+func TestWithHTTPClient_Nil_ReturnsClientCreationError(t *testing.T) {
+
+	// Act
+
+	accountClient, err := NewClient(WithHTTPClient(nil))
+
+	// Assert
+
+	if accountClient != nil {
+		t.Errorf("Returned reponse: got %v want %v", accountClient, nil)
+	}
+
+	assertClientError(err, "http client is required", t, ClientCreationError, http.StatusBadRequest)
+}
+
 // newTestServer creates a multiplex server to handle API endpoints
 func newTestServer(path string, h func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
 	mux := http.NewServeMux()
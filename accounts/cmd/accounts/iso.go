@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/iso20022"
+	"ei09010/form3-api-client/accounts/lookup"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+// runImport applies an acmt document: acmt.007 creates the account, acmt.019 deletes it, acmt.023 is answered with
+// an acmt.024 report written to --out and acmt.024 results are printed. Unmapped fields are reported on stderr
+func runImport(ctx context.Context, env *environment, args []string) error {
+
+	fs, cf := newFlagSet("import", env)
+	out := fs.String("out", "", "file the acmt.024 answering an acmt.023 is written to, stdout by default")
+	xsdDir := xsdDirFlag(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := newCodec(*xsdDir)
+
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("import expects one FILE argument, or - for stdin")
+	}
+
+	data, err := input(env, fs.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	document, _, err := c.unmarshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	client, err := cf.newClient()
+
+	if err != nil {
+		return err
+	}
+
+	defer printDryRun(env, client)
+
+	var report *iso20022.Report
+
+	switch d := document.(type) {
+	case *iso20022.AccountOpeningDocument:
+		report, err = importOpening(ctx, env, client, d)
+	case *iso20022.AccountClosingDocument:
+		report, err = importClosing(ctx, env, client, d)
+	case *iso20022.VerificationRequestDocument:
+		report, err = importVerification(ctx, env, c, client, d, *out)
+	case *iso20022.VerificationReportDocument:
+		report, err = importVerificationReport(env, d)
+	}
+
+	printReport(env, report)
+
+	return err
+}
+
+func importOpening(ctx context.Context, env *environment, client *accounts.Client, document *iso20022.AccountOpeningDocument) (*iso20022.Report, error) {
+
+	accountData, report, err := iso20022.FromAccountOpening(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uuid.Parse(accountData.Data.ID); err != nil {
+		accountData.Data.ID = uuid.New().String()
+	}
+
+	created, err := client.Create(ctx, accountData)
+
+	if err != nil {
+		return report, err
+	}
+
+	fmt.Fprintf(env.stdout, "%s account %s\n", outcome(client, "created"), created.Data.ID)
+
+	return report, nil
+}
+
+// importClosing deletes the account identified by the process id, or by its identification when the process id
+// is not an account id, at the version currently stored
+func importClosing(ctx context.Context, env *environment, client *accounts.Client, document *iso20022.AccountClosingDocument) (*iso20022.Report, error) {
+
+	accountData, report, err := iso20022.FromAccountClosing(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accountId, err := uuid.Parse(accountData.Data.ID)
+
+	if err != nil {
+
+		found, err := lookup.NewResolver(client).Resolve(ctx, identifier(accountData.Data.Attributes))
+
+		if err != nil {
+			return report, err
+		}
+
+		accountId, err = uuid.Parse(found.Account.ID)
+
+		if err != nil {
+			return report, fmt.Errorf("resolved account id %q is not a uuid: %w", found.Account.ID, err)
+		}
+	}
+
+	current, err := client.Fetch(ctx, accountId)
+
+	if err != nil {
+		return report, err
+	}
+
+	if err := client.Delete(ctx, accountId, current.Data.Version); err != nil {
+		return report, err
+	}
+
+	fmt.Fprintf(env.stdout, "%s account %s\n", outcome(client, "deleted"), accountId)
+
+	return report, nil
+}
+
+func importVerification(ctx context.Context, env *environment, c *codec, client *accounts.Client, document *iso20022.VerificationRequestDocument, out string) (*iso20022.Report, error) {
+
+	resolver := lookup.NewResolver(client)
+
+	answer, report, err := iso20022.VerifyRequest(document, func(identification *accounts.AccountAttributes) (*accounts.Data, error) {
+
+		found, err := resolver.Resolve(ctx, identifier(identification))
+
+		if errors.Is(err, lookup.ErrNotFound) || errors.Is(err, accounts.ValidationError) {
+			return nil, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return found.Account, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return report, write(env, c, out, answer)
+}
+
+func importVerificationReport(env *environment, document *iso20022.VerificationReportDocument) (*iso20022.Report, error) {
+
+	for _, result := range iso20022.FromVerificationReport(document) {
+
+		if result.Verified {
+			fmt.Fprintf(env.stdout, "%s verified\n", result.VerificationID)
+			continue
+		}
+
+		fmt.Fprintf(env.stdout, "%s not verified (%s)\n", result.VerificationID, result.Reason)
+	}
+
+	return nil, nil
+}
+
+// runExport fetches an account and writes it as the acmt document chosen with --type. Unmapped fields are reported
+// on stderr
+func runExport(ctx context.Context, env *environment, args []string) error {
+
+	fs, cf := newFlagSet("export", env)
+	messageType := fs.String("type", string(iso20022.MessageAcmt007), "acmt.007, acmt.019 or acmt.023")
+	id := fs.String("id", "", "id of the account to export")
+	out := fs.String("out", "", "file the document is written to, stdout by default")
+	xsdDir := xsdDirFlag(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := newCodec(*xsdDir)
+
+	if err != nil {
+		return err
+	}
+
+	accountId, err := uuid.Parse(*id)
+
+	if err != nil {
+		return fmt.Errorf("--id must be an account uuid")
+	}
+
+	convert, ok := exporters[iso20022.MessageType(*messageType)]
+
+	if !ok {
+		return fmt.Errorf("unsupported --type %q", *messageType)
+	}
+
+	client, err := cf.newClient()
+
+	if err != nil {
+		return err
+	}
+
+	account, err := client.Fetch(ctx, accountId)
+
+	if err != nil {
+		return err
+	}
+
+	document, report, err := convert(account.AccountData)
+
+	if err != nil {
+		return err
+	}
+
+	printReport(env, report)
+
+	return write(env, c, *out, document)
+}
+
+var exporters = map[iso20022.MessageType]func(*accounts.AccountData) (interface{}, *iso20022.Report, error){
+	iso20022.MessageAcmt007: func(a *accounts.AccountData) (interface{}, *iso20022.Report, error) {
+		return iso20022.ToAccountOpening(a)
+	},
+	iso20022.MessageAcmt019: func(a *accounts.AccountData) (interface{}, *iso20022.Report, error) {
+		return iso20022.ToAccountClosing(a)
+	},
+	iso20022.MessageAcmt023: func(a *accounts.AccountData) (interface{}, *iso20022.Report, error) {
+		return iso20022.ToVerificationRequest(a)
+	},
+}
+
+// codec encodes and decodes acmt documents, validating them against the XSDs of --xsd-dir when it is set
+type codec struct {
+	schemas *iso20022.SchemaSet
+}
+
+func xsdDirFlag(fs *flag.FlagSet) *string {
+	return fs.String("xsd-dir", os.Getenv(envXsdDir), "directory of the acmt .xsd files documents are validated against")
+}
+
+func newCodec(xsdDir string) (*codec, error) {
+
+	if xsdDir == "" {
+		return &codec{}, nil
+	}
+
+	schemas, err := iso20022.LoadSchemaDir(xsdDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &codec{schemas: schemas}, nil
+}
+
+func (c *codec) marshal(document interface{}) ([]byte, error) {
+
+	if c.schemas != nil {
+		return c.schemas.Marshal(document)
+	}
+
+	return iso20022.Marshal(document)
+}
+
+func (c *codec) unmarshal(data []byte) (interface{}, iso20022.MessageType, error) {
+
+	if c.schemas != nil {
+		return c.schemas.Unmarshal(data)
+	}
+
+	return iso20022.Unmarshal(data)
+}
+
+func write(env *environment, c *codec, path string, document interface{}) error {
+
+	encoded, err := c.marshal(document)
+
+	if err != nil {
+		return err
+	}
+
+	w, closeFn, err := output(env, path)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(encoded); err != nil {
+		closeFn()
+		return err
+	}
+
+	return closeFn()
+}
+
+func printReport(env *environment, report *iso20022.Report) {
+
+	if report == nil || report.Empty() {
+		return
+	}
+
+	fmt.Fprint(env.stderr, "unmapped fields:\n"+report.String())
+}
+
+func identifier(attributes *accounts.AccountAttributes) lookup.Identifier {
+
+	if attributes.Iban != "" {
+		return lookup.ByIBAN(attributes.Iban)
+	}
+
+	return lookup.ByBankAccount(attributes.BankIDCode, attributes.BankID, attributes.AccountNumber)
+}
+
+// outcome phrases the action performed on an account, conditionally in dry-run mode
+func outcome(client *accounts.Client, action string) string {
+
+	if client.DryRun() {
+		return "would have " + action
+	}
+
+	return action
+}
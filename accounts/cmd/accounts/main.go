@@ -0,0 +1,183 @@
+// Command accounts manages Form3 accounts from the command line.
+//
+//	accounts import [flags] FILE   create, close or verify accounts from an ISO 20022 acmt document
+//	accounts export [flags]        write an account as an ISO 20022 acmt document
+//	accounts snapshot [flags]      dump the accounts of an organisation to a JSONL snapshot
+//	accounts diff BEFORE [AFTER]   compare two snapshots, or a snapshot with the live accounts
+//	accounts plan MANIFEST         show the changes converging the accounts to a manifest
+//	accounts apply MANIFEST        make those changes
+//
+// The API is reached at --base-url, which defaults to the FORM3_API_URL environment variable. import and export
+// validate documents against the acmt XSDs of --xsd-dir, which defaults to FORM3_XSD_DIR, when it is set
+package main
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+)
+
+// Environment variables providing the flag defaults
+const (
+	envBaseURL        = "FORM3_API_URL"
+	envOrganisationID = "FORM3_ORGANISATION_ID"
+	envXsdDir         = "FORM3_XSD_DIR"
+)
+
+// command is a subcommand run with its own flag set
+type command struct {
+	usage string
+	run   func(ctx context.Context, env *environment, args []string) error
+}
+
+var commands = map[string]command{
+	"import":   {usage: "import [flags] FILE", run: runImport},
+	"export":   {usage: "export --type TYPE --id ID [flags]", run: runExport},
+	"snapshot": {usage: "snapshot --organisation-id ID [flags]", run: runSnapshot},
+	"diff":     {usage: "diff [flags] BEFORE [AFTER]", run: runDiff},
+	"plan":     {usage: "plan [flags] MANIFEST", run: runPlan},
+	"apply":    {usage: "apply [--lock FILE] [flags] MANIFEST", run: runApply},
+}
+
+// environment holds the streams a command reads from and writes to
+type environment struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, &environment{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}, os.Args[1:]))
+}
+
+// run dispatches args to their command and returns the process exit code
+func run(ctx context.Context, env *environment, args []string) int {
+
+	if len(args) == 0 {
+		usage(env.stderr)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+
+	if !ok {
+		fmt.Fprintf(env.stderr, "unknown command %q\n", args[0])
+		usage(env.stderr)
+		return 2
+	}
+
+	if err := cmd.run(ctx, env, args[1:]); err != nil {
+
+		if err != flag.ErrHelp {
+			fmt.Fprintln(env.stderr, err)
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+func usage(w io.Writer) {
+
+	names := make([]string, 0, len(commands))
+
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage:")
+
+	for _, name := range names {
+		fmt.Fprintln(w, "  accounts "+commands[name].usage)
+	}
+}
+
+// clientFlags holds the flags configuring the API client
+type clientFlags struct {
+	baseURL        string
+	organisationID string
+	dryRun         bool
+}
+
+// newFlagSet returns the flag set of a command, with the client flags registered
+func newFlagSet(name string, env *environment) (*flag.FlagSet, *clientFlags) {
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+
+	cf := &clientFlags{}
+	fs.StringVar(&cf.baseURL, "base-url", os.Getenv(envBaseURL), "Form3 API base url")
+	fs.StringVar(&cf.organisationID, "organisation-id", os.Getenv(envOrganisationID), "organisation the client is scoped to")
+	fs.BoolVar(&cf.dryRun, "dry-run", false, "print the mutations instead of sending them")
+
+	return fs, cf
+}
+
+func (cf *clientFlags) newClient() (*accounts.Client, error) {
+
+	options := []accounts.ClientOption{}
+
+	if cf.baseURL != "" {
+		options = append(options, accounts.WithBaseURL(cf.baseURL))
+	}
+
+	if cf.organisationID != "" {
+		options = append(options, accounts.WithOrganisationID(cf.organisationID))
+	}
+
+	if cf.dryRun {
+		options = append(options, accounts.WithDryRun())
+	}
+
+	return accounts.NewClient(options...)
+}
+
+// printDryRun writes the mutations recorded by a dry-run client to stderr
+func printDryRun(env *environment, client *accounts.Client) {
+	for _, request := range client.DryRunRequests() {
+
+		fmt.Fprintf(env.stderr, "dry run: %s %s\n", request.Method, request.URL)
+
+		if len(request.Body) > 0 {
+			fmt.Fprintf(env.stderr, "%s\n", request.Body)
+		}
+	}
+}
+
+// output opens path for writing, standing for stdout when empty or "-"
+func output(env *environment, path string) (io.Writer, func() error, error) {
+
+	if path == "" || path == "-" {
+		return env.stdout, func() error { return nil }, nil
+	}
+
+	file, err := os.Create(path)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, file.Close, nil
+}
+
+// input reads path, standing for stdin when "-"
+func input(env *environment, path string) ([]byte, error) {
+
+	if path == "-" {
+		return io.ReadAll(env.stdin)
+	}
+
+	return os.ReadFile(path)
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testAccountId = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+// fakeAPI stores accounts in memory and serves create, fetch, update, delete and list requests, optionally filtered by
+// iban or organisation_id
+type fakeAPI struct {
+	mu       sync.Mutex
+	accounts map[string]*accounts.Data
+}
+
+func newFakeAPI(t *testing.T, stored ...*accounts.Data) (*fakeAPI, *httptest.Server) {
+
+	api := &fakeAPI{accounts: map[string]*accounts.Data{}}
+
+	for _, data := range stored {
+		api.accounts[data.ID] = data
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(ts.Close)
+
+	return api, ts
+}
+
+func (f *fakeAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/v1/organisation/accounts"), "/")
+
+	switch {
+	case r.Method == http.MethodPost:
+		body := &accounts.AccountData{}
+		json.NewDecoder(r.Body).Decode(body)
+		f.accounts[body.Data.ID] = body.Data
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(body)
+
+	case r.Method == http.MethodPatch && f.accounts[id] != nil:
+		body := &accounts.AccountData{}
+		json.NewDecoder(r.Body).Decode(body)
+		body.Data.Version++
+		f.accounts[id] = body.Data
+		json.NewEncoder(w).Encode(body)
+
+	case r.Method == http.MethodDelete && f.accounts[id] != nil:
+		delete(f.accounts, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodGet && id == "":
+		found := []*accounts.Data{}
+		for _, data := range f.accounts {
+			iban, organisationId := r.URL.Query().Get("filter[iban]"), r.URL.Query().Get("filter[organisation_id]")
+			if (iban == "" || iban == data.Attributes.Iban) && (organisationId == "" || organisationId == data.OrganisationID) {
+				found = append(found, data)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": found})
+
+	case r.Method == http.MethodGet && f.accounts[id] != nil:
+		json.NewEncoder(w).Encode(&accounts.AccountData{Data: f.accounts[id]})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error_message": "not found"})
+	}
+}
+
+func testAccount() *accounts.Data {
+	return &accounts.Data{
+		ID:   testAccountId,
+		Type: "accounts",
+		Attributes: &accounts.AccountAttributes{
+			AlternativeNames: []string{"Sam Holder"},
+			BankID:           "400300",
+			BankIDCode:       "GBDSC",
+			BaseCurrency:     "GBP",
+			Bic:              "NWBKGB22",
+			Country:          "GB",
+			Iban:             "GB11NWBK40030041426819",
+			Name:             []string{"Samantha Holder"},
+		},
+	}
+}
+
+// runCommandOutput runs a command against ts and returns its stdout, stderr and exit code
+func runCommandOutput(t *testing.T, ts *httptest.Server, args ...string) (string, string, int) {
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	env := &environment{stdin: strings.NewReader(""), stdout: stdout, stderr: stderr}
+
+	args = append([]string{args[0], "--base-url", ts.URL}, args[1:]...)
+	code := run(context.Background(), env, args)
+
+	return stdout.String(), stderr.String(), code
+}
+
+func TestExportThenImportOpening(t *testing.T) {
+
+	// Arrange
+
+	api, ts := newFakeAPI(t, testAccount())
+	file := filepath.Join(t.TempDir(), "acmt007.xml")
+
+	// Act
+
+	exportStdout, exportStderr, exportCode := runCommandOutput(t, ts, "export", "--type", "acmt.007", "--id", testAccountId, "--out", file)
+
+	delete(api.accounts, testAccountId)
+
+	importStdout, _, importCode := runCommandOutput(t, ts, "import", file)
+
+	// Assert
+
+	if exportCode != 0 || importCode != 0 {
+		t.Fatalf("unexpected exit codes: got %v and %v want 0, stderr %v", exportCode, importCode, exportStderr)
+	}
+
+	if exportStdout != "" {
+		t.Errorf("unexpected stdout: got %v want the document in %v", exportStdout, file)
+	}
+
+	if !strings.Contains(exportStderr, "attributes.alternative_names") {
+		t.Errorf("unexpected report: got %v want alternative_names reported", exportStderr)
+	}
+
+	if !strings.Contains(importStdout, "created account "+testAccountId) {
+		t.Errorf("unexpected stdout: got %v", importStdout)
+	}
+
+	if created := api.accounts[testAccountId]; created == nil || created.Attributes.Iban != testAccount().Attributes.Iban {
+		t.Errorf("unexpected created account: got %+v", created)
+	}
+}
+
+func TestImportClosingDeletesTheAccount(t *testing.T) {
+
+	// Arrange
+
+	api, ts := newFakeAPI(t, testAccount())
+	file := filepath.Join(t.TempDir(), "acmt019.xml")
+
+	if _, stderr, code := runCommandOutput(t, ts, "export", "--type", "acmt.019", "--id", testAccountId, "--out", file); code != 0 {
+		t.Fatalf("unexpected export failure: %v", stderr)
+	}
+
+	// Act
+
+	_, stderr, code := runCommandOutput(t, ts, "import", file)
+
+	// Assert
+
+	if code != 0 {
+		t.Fatalf("unexpected exit code: got %v want 0, stderr %v", code, stderr)
+	}
+
+	if api.accounts[testAccountId] != nil {
+		t.Errorf("unexpected account: got it stored want it deleted")
+	}
+}
+
+func TestImportClosingResolvedToAnInvalidId_Fails(t *testing.T) {
+
+	// Arrange
+
+	api, ts := newFakeAPI(t, testAccount())
+	file := filepath.Join(t.TempDir(), "acmt019.xml")
+
+	if _, stderr, code := runCommandOutput(t, ts, "export", "--type", "acmt.019", "--id", testAccountId, "--out", file); code != 0 {
+		t.Fatalf("unexpected export failure: %v", stderr)
+	}
+
+	exported, err := os.ReadFile(file)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if err := os.WriteFile(file, bytes.ReplaceAll(exported, []byte(strings.ReplaceAll(testAccountId, "-", "")), []byte("closing-process")), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	stored := api.accounts[testAccountId]
+	stored.ID = "not-a-uuid"
+
+	// Act
+
+	_, stderr, code := runCommandOutput(t, ts, "import", file)
+
+	// Assert
+
+	if code == 0 || !strings.Contains(stderr, `resolved account id "not-a-uuid" is not a uuid`) {
+		t.Errorf("unexpected outcome: got exit code %v and stderr %q", code, stderr)
+	}
+
+	if api.accounts[testAccountId] == nil {
+		t.Errorf("unexpected deletion: got the account deleted want it stored")
+	}
+}
+
+func TestImportVerificationAnswersWithReport(t *testing.T) {
+
+	// Arrange
+
+	_, ts := newFakeAPI(t, testAccount())
+	request := filepath.Join(t.TempDir(), "acmt023.xml")
+
+	if _, stderr, code := runCommandOutput(t, ts, "export", "--type", "acmt.023", "--id", testAccountId, "--out", request); code != 0 {
+		t.Fatalf("unexpected export failure: %v", stderr)
+	}
+
+	// Act
+
+	stdout, stderr, code := runCommandOutput(t, ts, "import", request)
+
+	// Assert
+
+	if code != 0 {
+		t.Fatalf("unexpected exit code: got %v want 0, stderr %v", code, stderr)
+	}
+
+	if !strings.Contains(stdout, "<IdVrfctnRpt>") || !strings.Contains(stdout, "<Vrfctn>true</Vrfctn>") {
+		t.Errorf("unexpected report: got %v want a verified acmt.024", stdout)
+	}
+}
+
+func TestImportDryRunSendsNothing(t *testing.T) {
+
+	// Arrange
+
+	api, ts := newFakeAPI(t, testAccount())
+	file := filepath.Join(t.TempDir(), "acmt007.xml")
+
+	if _, stderr, code := runCommandOutput(t, ts, "export", "--type", "acmt.007", "--id", testAccountId, "--out", file); code != 0 {
+		t.Fatalf("unexpected export failure: %v", stderr)
+	}
+
+	delete(api.accounts, testAccountId)
+
+	// Act
+
+	stdout, stderr, code := runCommandOutput(t, ts, "import", "--dry-run", file)
+
+	// Assert
+
+	if code != 0 {
+		t.Fatalf("unexpected exit code: got %v want 0, stderr %v", code, stderr)
+	}
+
+	if api.accounts[testAccountId] != nil {
+		t.Errorf("unexpected account: got it created want nothing sent")
+	}
+
+	if !strings.Contains(stdout, "would have created account "+testAccountId) {
+		t.Errorf("unexpected stdout: got %v", stdout)
+	}
+
+	if !strings.Contains(stderr, "dry run: POST "+ts.URL+"/v1/organisation/accounts") {
+		t.Errorf("unexpected stderr: got %v want the recorded request", stderr)
+	}
+}
+
+func TestRunRejectsUnknownInput(t *testing.T) {
+
+	cases := map[string]struct {
+		args []string
+	}{
+		"unknown command": {args: []string{"frobnicate"}},
+		"missing id":      {args: []string{"export", "--type", "acmt.007"}},
+		"unknown type":    {args: []string{"export", "--type", "pacs.008", "--id", testAccountId}},
+		"missing file":    {args: []string{"import", filepath.Join(os.TempDir(), "missing.xml")}},
+		"missing xsd dir": {args: []string{"export", "--id", testAccountId, "--xsd-dir", filepath.Join(os.TempDir(), "missing-xsd")}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			_, ts := newFakeAPI(t)
+
+			// Act
+
+			_, _, code := runCommandOutput(t, ts, c.args...)
+
+			// Assert
+
+			if code == 0 {
+				t.Errorf("unexpected exit code: got 0 want a failure")
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts/reconcile"
+	"fmt"
+	"os"
+)
+
+// runPlan prints the actions converging the live accounts to a manifest
+func runPlan(ctx context.Context, env *environment, args []string) error {
+
+	fs, cf := newFlagSet("plan", env)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("plan expects one MANIFEST argument")
+	}
+
+	manifest, err := reconcile.LoadManifest(fs.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	client, err := cf.newClient()
+
+	if err != nil {
+		return err
+	}
+
+	plan, err := reconcile.NewEngine(client).Plan(ctx, manifest)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(env.stdout, plan.String())
+
+	return nil
+}
+
+// runApply plans then applies a manifest while holding its lock file
+func runApply(ctx context.Context, env *environment, args []string) error {
+
+	fs, cf := newFlagSet("apply", env)
+	lockPath := fs.String("lock", "", "lock file preventing concurrent applies, MANIFEST.lock by default")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("apply expects one MANIFEST argument")
+	}
+
+	manifest, err := reconcile.LoadManifest(fs.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	if *lockPath == "" {
+		*lockPath = fs.Arg(0) + ".lock"
+	}
+
+	lock, err := reconcile.AcquireLock(*lockPath, lockHolder())
+
+	if err != nil {
+		return err
+	}
+
+	defer lock.Release()
+
+	client, err := cf.newClient()
+
+	if err != nil {
+		return err
+	}
+
+	defer printDryRun(env, client)
+
+	engine := reconcile.NewEngine(client)
+
+	plan, err := engine.Plan(ctx, manifest)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(env.stdout, plan.String())
+
+	results, err := engine.Apply(ctx, plan)
+
+	for _, result := range results {
+
+		if result.Err != nil {
+			fmt.Fprintf(env.stdout, "%s %s failed: %s\n", result.Action.Kind, result.Action.AccountID, result.Err)
+			continue
+		}
+
+		fmt.Fprintf(env.stdout, "%s %s\n", outcome(client, string(result.Action.Kind)+"d"), result.Action.AccountID)
+	}
+
+	return err
+}
+
+// lockHolder identifies the current user and host in lock files
+func lockHolder() string {
+
+	host, _ := os.Hostname()
+
+	return os.Getenv("USER") + "@" + host
+}
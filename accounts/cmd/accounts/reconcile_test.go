@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T) string {
+
+	path := filepath.Join(t.TempDir(), "accounts.yaml")
+
+	manifest := `version: 1
+organisation_id: ` + testOrganisationId + `
+prune: true
+accounts:
+  - id: ` + testAccountId + `
+    attributes: {country: GB, bank_id: "400301", bank_id_code: GBDSC, iban: GB11NWBK40030041426819, name: [Samantha Holder]}
+`
+
+	if err := os.WriteFile(path, []byte(manifest), 0600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return path
+}
+
+func TestApplyConvergesToTheManifest(t *testing.T) {
+
+	// Arrange
+
+	account := testAccount()
+	account.OrganisationID = testOrganisationId
+
+	api, ts := newFakeAPI(t, account)
+	manifest := writeManifest(t)
+
+	// Act
+
+	planStdout, _, planCode := runCommandOutput(t, ts, "plan", manifest)
+	applyStdout, applyStderr, applyCode := runCommandOutput(t, ts, "apply", manifest)
+	replanStdout, _, _ := runCommandOutput(t, ts, "plan", manifest)
+
+	// Assert
+
+	if planCode != 0 || applyCode != 0 {
+		t.Fatalf("unexpected exit codes: got %v and %v want 0, stderr %v", planCode, applyCode, applyStderr)
+	}
+
+	if !strings.Contains(planStdout, "~ update "+testAccountId) || !strings.Contains(planStdout, `attributes.bank_id: "400300" -> "400301"`) {
+		t.Errorf("unexpected plan: got %v", planStdout)
+	}
+
+	if !strings.Contains(applyStdout, "updated "+testAccountId) {
+		t.Errorf("unexpected apply output: got %v", applyStdout)
+	}
+
+	if api.accounts[testAccountId].Attributes.BankID != "400301" {
+		t.Errorf("unexpected bank id: got %v want 400301", api.accounts[testAccountId].Attributes.BankID)
+	}
+
+	if !strings.Contains(replanStdout, "Plan: 0 to create, 0 to update, 0 to delete") {
+		t.Errorf("unexpected plan after apply: got %v", replanStdout)
+	}
+
+	if _, err := os.Stat(manifest + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("unexpected lock file: got %v want it released", err)
+	}
+}
+
+func TestApplyRefusesWhileLocked(t *testing.T) {
+
+	// Arrange
+
+	_, ts := newFakeAPI(t)
+	manifest := writeManifest(t)
+
+	if err := os.WriteFile(manifest+".lock", []byte(`{"holder":"alice@ci"}`), 0600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	_, stderr, code := runCommandOutput(t, ts, "apply", manifest)
+
+	// Assert
+
+	if code == 0 || !strings.Contains(stderr, "alice@ci") {
+		t.Errorf("unexpected outcome: got %v %v want a failure naming the lock holder", code, stderr)
+	}
+}
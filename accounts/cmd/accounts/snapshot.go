@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts/snapshot"
+	"fmt"
+)
+
+// runSnapshot writes the accounts of --organisation-id to a snapshot file
+func runSnapshot(ctx context.Context, env *environment, args []string) error {
+
+	fs, cf := newFlagSet("snapshot", env)
+	out := fs.String("out", "", "file the snapshot is written to, stdout by default")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cf.organisationID == "" {
+		return fmt.Errorf("snapshot requires --organisation-id")
+	}
+
+	client, err := cf.newClient()
+
+	if err != nil {
+		return err
+	}
+
+	taken, err := snapshot.Take(ctx, client, cf.organisationID)
+
+	if err != nil {
+		return err
+	}
+
+	w, closeFn, err := output(env, *out)
+
+	if err != nil {
+		return err
+	}
+
+	if err := taken.Write(w); err != nil {
+		closeFn()
+		return err
+	}
+
+	fmt.Fprintf(env.stderr, "%d accounts of organisation %s\n", taken.Header.Count, cf.organisationID)
+
+	return closeFn()
+}
+
+// runDiff compares two snapshots, or a snapshot with the live accounts of its organisation when a single file is
+// given, and prints the changes
+func runDiff(ctx context.Context, env *environment, args []string) error {
+
+	fs, cf := newFlagSet("diff", env)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return fmt.Errorf("diff expects BEFORE [AFTER] snapshot files")
+	}
+
+	before, err := snapshot.ReadFile(fs.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	var after *snapshot.Snapshot
+
+	if fs.NArg() == 2 {
+		after, err = snapshot.ReadFile(fs.Arg(1))
+	} else {
+		after, err = liveSnapshot(ctx, cf, before.Header.OrganisationID)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(env.stdout, snapshot.Compare(before, after).String())
+
+	return nil
+}
+
+func liveSnapshot(ctx context.Context, cf *clientFlags, organisationID string) (*snapshot.Snapshot, error) {
+
+	client, err := cf.newClient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Take(ctx, client, organisationID)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testOrganisationId = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+
+func TestSnapshotThenDiffWithLiveAccounts(t *testing.T) {
+
+	// Arrange
+
+	account := testAccount()
+	account.OrganisationID = testOrganisationId
+
+	other := testAccount()
+	other.ID = "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+	other.OrganisationID = "ba61483c-d5c5-4f50-ae81-6b8c039bea43"
+
+	api, ts := newFakeAPI(t, account, other)
+	file := filepath.Join(t.TempDir(), "before.jsonl")
+
+	if _, stderr, code := runCommandOutput(t, ts, "snapshot", "--organisation-id", testOrganisationId, "--out", file); code != 0 {
+		t.Fatalf("unexpected snapshot failure: %v", stderr)
+	}
+
+	if snapshot, err := os.ReadFile(file); err != nil || strings.Contains(string(snapshot), other.ID) {
+		t.Fatalf("unexpected snapshot: got %s, %v want the accounts of %s only", snapshot, err, testOrganisationId)
+	}
+
+	api.accounts[testAccountId].Attributes.BankID = "400301"
+
+	// Act
+
+	stdout, stderr, code := runCommandOutput(t, ts, "diff", file)
+
+	// Assert
+
+	if code != 0 {
+		t.Fatalf("unexpected exit code: got %v want 0, stderr %v", code, stderr)
+	}
+
+	want := "~ " + testAccountId + "\n    attributes.bank_id: \"400300\" -> \"400301\"\n0 added, 0 removed, 1 modified\n"
+
+	if stdout != want {
+		t.Errorf("unexpected diff: got %v want %v", stdout, want)
+	}
+}
+
+func TestSnapshotRequiresAnOrganisation(t *testing.T) {
+
+	// Arrange
+
+	_, ts := newFakeAPI(t)
+
+	// Act
+
+	_, stderr, code := runCommandOutput(t, ts, "snapshot", "--organisation-id", "")
+
+	// Assert
+
+	if code == 0 || !strings.Contains(stderr, "--organisation-id") {
+		t.Errorf("unexpected outcome: got %v %v want a failure naming --organisation-id", code, stderr)
+	}
+}
@@ -0,0 +1,105 @@
+// Package cop verifies payee names against UK accounts with Confirmation of Payee, built on the accounts client core
+package cop
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Default values used by the Confirmation of Payee client
+const (
+	NameVerificationsPath = "/v1/confirmation-of-payee/name-verifications"
+	BankIDCodeGBDSC       = "GBDSC"
+	nameVerificationType  = "name_verifications"
+)
+
+var (
+	sortCodePattern      = regexp.MustCompile(`^[0-9]{6}$`)
+	accountNumberPattern = regexp.MustCompile(`^[0-9]{8}$`)
+)
+
+// Client may be used to verify payee names
+type Client struct {
+	verifications *accounts.Resource[NameVerification]
+}
+
+// NewClient constructs a Confirmation of Payee Client sending its requests through core. Circuit breaker endpoints
+// are prefixed with "cop."
+func NewClient(core *accounts.Client) *Client {
+	return &Client{
+		verifications: accounts.NewResource[NameVerification](core, "cop", NameVerificationsPath),
+	}
+}
+
+// Verify checks request.Name against the holder of the account identified by its sort code and account number. The
+// verification only reads, so a dry-run client sends it
+func (c *Client) Verify(ctx context.Context, request *Request) (*Result, error) {
+
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := c.verifications.Create(accounts.WithReadOnly(ctx), &accounts.Document[NameVerification]{Data: &NameVerification{
+		ID:             uuid.New().String(),
+		OrganisationID: request.OrganisationID,
+		Type:           nameVerificationType,
+		Attributes: &NameVerificationAttributes{
+			AccountNumber: request.AccountNumber,
+			AccountType:   request.AccountType,
+			BankID:        request.SortCode,
+			BankIDCode:    BankIDCodeGBDSC,
+			Name:          request.Name,
+		},
+	}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Data == nil || response.Data.Attributes == nil || response.Data.Attributes.Result == "" {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ApiHttpErrorType, response.Status, "name verification returned no result")
+	}
+
+	attributes := response.Data.Attributes
+
+	return &Result{
+		Match:         attributes.Result,
+		SuggestedName: attributes.SuggestedName,
+		ReasonCode:    attributes.ReasonCode,
+	}, nil
+}
+
+func (r *Request) validate() error {
+
+	if r == nil {
+		return validationError("request is required")
+	}
+
+	if !sortCodePattern.MatchString(r.SortCode) {
+		return validationError(fmt.Sprintf("sort code must have 6 digits: %q", r.SortCode))
+	}
+
+	if !accountNumberPattern.MatchString(r.AccountNumber) {
+		return validationError(fmt.Sprintf("account number must have 8 digits: %q", r.AccountNumber))
+	}
+
+	if strings.TrimSpace(r.Name) == "" {
+		return validationError("name is required")
+	}
+
+	if r.AccountType != AccountTypePersonal && r.AccountType != AccountTypeBusiness {
+		return validationError(fmt.Sprintf("account type must be %s or %s: %q", AccountTypePersonal, AccountTypeBusiness, r.AccountType))
+	}
+
+	return nil
+}
+
+func validationError(msg string) error {
+	return fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, msg)
+}
@@ -0,0 +1,203 @@
+package cop
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, responder http.Handler, options ...accounts.ClientOption) (*Client, *httptest.Server) {
+
+	mux := http.NewServeMux()
+	mux.Handle(NameVerificationsPath, responder)
+	ts := httptest.NewServer(mux)
+
+	core, err := accounts.NewClient(append([]accounts.ClientOption{accounts.WithBaseURL(ts.URL)}, options...)...)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return NewClient(core), ts
+}
+
+func gbAccount(accountNumber string, classification string, optOut bool, names ...string) *accounts.Data {
+	return &accounts.Data{Attributes: &accounts.AccountAttributes{
+		AccountClassification: classification,
+		AccountMatchingOptOut: optOut,
+		AccountNumber:         accountNumber,
+		AlternativeNames:      []string{"Sam Holder"},
+		BankID:                "400300",
+		BankIDCode:            BankIDCodeGBDSC,
+		Country:               "GB",
+		Name:                  names,
+	}}
+}
+
+func TestVerify(t *testing.T) {
+
+	// Arrange
+
+	responder, err := NewResponder(
+		gbAccount("41426819", "Personal", false, "Samantha", "Holder"),
+		gbAccount("41426820", "Personal", true, "Opted Out"),
+		gbAccount("41426821", "Business", false, "Holder Trading Ltd"),
+		gbAccount("41426822", "Personal", false, "Tom"),
+	)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	client, ts := newTestClient(t, responder)
+	defer ts.Close()
+
+	cases := map[string]struct {
+		request       *Request
+		expectedMatch MatchResult
+		expectedName  string
+	}{
+		"Full match on name": {
+			request:       &Request{SortCode: "400300", AccountNumber: "41426819", Name: "MRS SAMANTHA HOLDER", AccountType: AccountTypePersonal},
+			expectedMatch: FullMatch,
+		},
+		"Full match on alternative name": {
+			request:       &Request{SortCode: "400300", AccountNumber: "41426819", Name: "Sam Holder", AccountType: AccountTypePersonal},
+			expectedMatch: FullMatch,
+		},
+		"Close match suggests the account name": {
+			request:       &Request{SortCode: "400300", AccountNumber: "41426819", Name: "Samanta Holdr", AccountType: AccountTypePersonal},
+			expectedMatch: CloseMatch,
+			expectedName:  "Samantha Holder",
+		},
+		"No match": {
+			request:       &Request{SortCode: "400300", AccountNumber: "41426819", Name: "John Smith", AccountType: AccountTypePersonal},
+			expectedMatch: NoMatch,
+		},
+		"No match on a short name two edits away": {
+			request:       &Request{SortCode: "400300", AccountNumber: "41426822", Name: "Bob", AccountType: AccountTypePersonal},
+			expectedMatch: NoMatch,
+		},
+		"Unknown account": {
+			request:       &Request{SortCode: "400300", AccountNumber: "00000000", Name: "Samantha Holder", AccountType: AccountTypePersonal},
+			expectedMatch: NoMatch,
+		},
+		"Opted out": {
+			request:       &Request{SortCode: "400300", AccountNumber: "41426820", Name: "Opted Out", AccountType: AccountTypePersonal},
+			expectedMatch: OptedOut,
+		},
+		"Account type mismatch": {
+			request:       &Request{SortCode: "400300", AccountNumber: "41426821", Name: "Holder Trading Ltd", AccountType: AccountTypePersonal},
+			expectedMatch: AccountTypeMismatch,
+		},
+	}
+
+	for name, testCase := range cases {
+
+		// Act
+
+		result, err := client.Verify(context.Background(), testCase.request)
+
+		// Assert
+
+		if err != nil {
+			t.Errorf("%s: verify returned an error: got %v want %v", name, err, nil)
+			continue
+		}
+
+		if result.Match != testCase.expectedMatch {
+			t.Errorf("%s: unexpected match: got %s want %s", name, result.Match, testCase.expectedMatch)
+		}
+
+		if result.SuggestedName != testCase.expectedName {
+			t.Errorf("%s: unexpected suggested name: got %q want %q", name, result.SuggestedName, testCase.expectedName)
+		}
+	}
+}
+
+func TestVerify_DryRunClient_SendsTheVerification(t *testing.T) {
+
+	// Arrange
+
+	responder, err := NewResponder(gbAccount("41426819", "Personal", false, "Samantha", "Holder"))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	client, ts := newTestClient(t, responder, accounts.WithDryRun())
+	defer ts.Close()
+
+	// Act
+
+	result, err := client.Verify(context.Background(), &Request{SortCode: "400300", AccountNumber: "41426819", Name: "Samantha Holder", AccountType: AccountTypePersonal})
+
+	// Assert
+
+	if err != nil || result.Match != FullMatch {
+		t.Errorf("verify returned unexpected result: got %+v, %v want %s", result, err, FullMatch)
+	}
+}
+
+func TestVerify_invalidRequest_ReturnsValidationErrorWithoutCallingTheAPI(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	client, ts := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+
+	defer ts.Close()
+
+	// Act
+
+	result, err := client.Verify(context.Background(), &Request{SortCode: "40-03-00", AccountNumber: "41426819", Name: "Samantha Holder", AccountType: AccountTypePersonal})
+
+	// Assert
+
+	if result != nil {
+		t.Errorf("Returned result: got %v want %v", result, nil)
+	}
+
+	if !errors.Is(err, accounts.ValidationError) {
+		t.Errorf("verify returned unexpected error: got %v want %v", err, accounts.ValidationError)
+	}
+
+	if requests != 0 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 0)
+	}
+}
+
+func TestNewResponder_AccountWithoutAttributes_ReturnsValidationError(t *testing.T) {
+
+	cases := map[string]struct {
+		account *accounts.Data
+	}{
+		"nil account":    {account: nil},
+		"nil attributes": {account: &accounts.Data{}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+
+			responder, err := NewResponder(c.account)
+
+			// Assert
+
+			if responder != nil {
+				t.Errorf("Returned responder: got %v want %v", responder, nil)
+			}
+
+			if !errors.Is(err, accounts.ValidationError) {
+				t.Errorf("unexpected error: got %v want %v", err, accounts.ValidationError)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package cop
+
+import "time"
+
+// AccountType is the kind of account a name is checked against
+type AccountType string
+
+// Account types, matching the account_classification of GB accounts
+const (
+	AccountTypePersonal AccountType = "Personal"
+	AccountTypeBusiness AccountType = "Business"
+)
+
+// MatchResult is the outcome of a name verification
+type MatchResult string
+
+// Name verification outcomes
+const (
+	// FullMatch means the name matches the account holder
+	FullMatch MatchResult = "full_match"
+
+	// CloseMatch means the name is close to the account holder, returned in Result.SuggestedName
+	CloseMatch MatchResult = "close_match"
+
+	// NoMatch means the name does not match the account holder, or the account could not be found
+	NoMatch MatchResult = "no_match"
+
+	// OptedOut means the account holder opted out of account matching
+	OptedOut MatchResult = "opted_out"
+
+	// AccountTypeMismatch means the name matches but the account is of the other AccountType
+	AccountTypeMismatch MatchResult = "account_type_mismatch"
+)
+
+// NameVerification is the resource submitted to, and returned by, the name verification endpoint
+type NameVerification struct {
+	Attributes     *NameVerificationAttributes `json:"attributes"`
+	CreatedOn      time.Time                   `json:"created_on,omitempty"`
+	ID             string                      `json:"id"`
+	OrganisationID string                      `json:"organisation_id"`
+	Type           string                      `json:"type"`
+	Version        int                         `json:"version"`
+}
+
+// NameVerificationAttributes holds the account checked and, once answered, the outcome of the check
+type NameVerificationAttributes struct {
+	AccountNumber string      `json:"account_number"`
+	AccountType   AccountType `json:"account_type"`
+	BankID        string      `json:"bank_id"`
+	BankIDCode    string      `json:"bank_id_code"`
+	Name          string      `json:"name"`
+	Result        MatchResult `json:"result,omitempty"`
+	SuggestedName string      `json:"suggested_name,omitempty"`
+	ReasonCode    string      `json:"reason_code,omitempty"`
+}
+
+// Request describes the account and name to verify
+type Request struct {
+	OrganisationID string
+	SortCode       string
+	AccountNumber  string
+	Name           string
+	AccountType    AccountType
+}
+
+// Result is the typed outcome of Verify
+type Result struct {
+	Match MatchResult
+
+	// SuggestedName holds the account holder name on a CloseMatch
+	SuggestedName string
+
+	// ReasonCode holds the scheme reason code, when provided
+	ReasonCode string
+}
+
+// Matched reports whether the name can be trusted as is
+func (r *Result) Matched() bool {
+	return r.Match == FullMatch
+}
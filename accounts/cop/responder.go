@@ -0,0 +1,216 @@
+package cop
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// closeMatchDistance is the largest number of edits for which a name is a close match
+const closeMatchDistance = 2
+
+// closeMatchRunesPerEdit is the name length which allows one edit, so that short names need to be closer to match,
+// e.g. "Bob" is no close match for "Tom"
+const closeMatchRunesPerEdit = 5
+
+// Responder is a local stand-in for the name verification endpoint. It answers from the accounts added to it,
+// matching names against their name and alternative_names, and honours account_matching_opt_out and
+// account_classification. Serve it at NameVerificationsPath, e.g. from an httptest server
+type Responder struct {
+	mu       sync.RWMutex
+	accounts map[string]*accounts.Data
+}
+
+// NewResponder constructs a Responder answering for the given accounts
+func NewResponder(accountsData ...*accounts.Data) (*Responder, error) {
+
+	r := &Responder{accounts: map[string]*accounts.Data{}}
+
+	for _, account := range accountsData {
+		if err := r.Add(account); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Add registers an account, keyed by its bank id and account number. It fails with ValidationError when the
+// account has no attributes
+func (r *Responder) Add(account *accounts.Data) error {
+
+	if account == nil || account.Attributes == nil {
+		return validationError("account attributes are required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.accounts[account.Attributes.BankID+"/"+account.Attributes.AccountNumber] = account
+
+	return nil
+}
+
+// ServeHTTP answers a name verification request
+func (r *Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	document := &accounts.Document[NameVerification]{}
+
+	if err := json.NewDecoder(req.Body).Decode(document); err != nil || document.Data == nil || document.Data.Attributes == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error_message":"invalid name verification request"}`)
+		return
+	}
+
+	attributes := document.Data.Attributes
+
+	r.mu.RLock()
+	account := r.accounts[attributes.BankID+"/"+attributes.AccountNumber]
+	r.mu.RUnlock()
+
+	attributes.Result, attributes.SuggestedName = match(account, attributes.Name, attributes.AccountType)
+	document.Data.CreatedOn = time.Now().UTC()
+
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(document)
+}
+
+// match compares name with the holder of account, returning the outcome and the suggested name on a close match
+func match(account *accounts.Data, name string, accountType AccountType) (MatchResult, string) {
+
+	if account == nil || account.Attributes == nil {
+		return NoMatch, ""
+	}
+
+	attributes := account.Attributes
+
+	if attributes.AccountMatchingOptOut {
+		return OptedOut, ""
+	}
+
+	holder := strings.Join(attributes.Name, " ")
+	candidates := append([]string{holder}, attributes.AlternativeNames...)
+
+	result := NoMatch
+	wanted := normaliseName(name)
+
+	for _, candidate := range candidates {
+
+		normalised := normaliseName(candidate)
+
+		if normalised == wanted {
+			result = FullMatch
+			break
+		}
+
+		if levenshtein(normalised, wanted) <= closeMatchThreshold(normalised, wanted) {
+			result = CloseMatch
+		}
+	}
+
+	if result == NoMatch {
+		return NoMatch, ""
+	}
+
+	if attributes.AccountClassification != "" && !strings.EqualFold(attributes.AccountClassification, string(accountType)) {
+		return AccountTypeMismatch, ""
+	}
+
+	if result == CloseMatch {
+		return CloseMatch, holder
+	}
+
+	return FullMatch, ""
+}
+
+// normaliseName lower cases name, drops punctuation and titles and collapses whitespace
+func normaliseName(name string) string {
+
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+
+	var words []string
+
+	for _, word := range strings.Fields(cleaned) {
+		switch word {
+		case "mr", "mrs", "ms", "miss", "dr":
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// closeMatchThreshold is the number of edits allowed between two names, growing with the longer name up to
+// closeMatchDistance
+func closeMatchThreshold(a string, b string) int {
+
+	length := utf8.RuneCountInString(a)
+
+	if other := utf8.RuneCountInString(b); other > length {
+		length = other
+	}
+
+	return min(closeMatchDistance, length/closeMatchRunesPerEdit)
+}
+
+func levenshtein(a string, b string) int {
+
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func min(values ...int) int {
+
+	smallest := values[0]
+
+	for _, value := range values[1:] {
+		if value < smallest {
+			smallest = value
+		}
+	}
+
+	return smallest
+}
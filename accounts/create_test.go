@@ -261,6 +261,52 @@ func TestCreate_InvalidAccountData_returns400BadRequest(t *testing.T) {
 
 }
 
+func TestCreate_WithOrganisationID_ScopesTheSentAccountOnly(t *testing.T) {
+
+	// Arrange
+
+	scopedOrganisationId := "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+
+	var received AccountData
+
+	ts := newTestServer(`/v1/organisation/accounts`, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(bodyBytes, &received)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(bodyBytes)
+	})
+
+	defer ts.Close()
+
+	accountClient, err := NewClient(WithBaseURL(ts.URL), WithOrganisationID(scopedOrganisationId))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	accountData := generateValidGenericAccountData()
+	accountData.Data.OrganisationID = ""
+
+	// Act
+
+	_, err = accountClient.Create(context.Background(), accountData)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("create returned an error: got %v want %v", err, nil)
+	}
+
+	if received.Data.OrganisationID != scopedOrganisationId {
+		t.Errorf("server received unexpected organisationId: got %s want %s", received.Data.OrganisationID, scopedOrganisationId)
+	}
+
+	if accountData.Data.OrganisationID != "" {
+		t.Errorf("create changed the caller's organisationId: got %s want it empty", accountData.Data.OrganisationID)
+	}
+}
+
 func TestCreateErrorCases(t *testing.T) {
 
 	// Arrange
@@ -305,9 +351,9 @@ func TestCreateErrorCases(t *testing.T) {
 			accountId:            "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
 			messageResponse:      "",
 			requestPath:          `/v1/organisation/accounts`,
-			expectedErrorMessage: "EOF",
+			expectedErrorMessage: "",
 			expectedHttpStatus:   http.StatusInternalServerError,
-			expectedErrorType:    BuildingRequestError,
+			expectedErrorType:    ApiHttpErrorType,
 			accountPayload:       generateValidGenericAccountData(),
 		},
 		"Handler timeout causes the request to fail": {
@@ -177,14 +177,14 @@ func TestDeleteErrorCases(t *testing.T) {
 				URL: "handler url",
 			},
 		},
-		"Unmarshalling Error reading error response message": {
+		"Error response which is not JSON": {
 			accountId:            "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
 			version:              0,
 			expectedHttpStatus:   http.StatusBadRequest,
 			requestPath:          `/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc`,
 			messageResponse:      `rror":"invalid version number"}`,
-			expectedErrorMessage: "invalid character 'r' looking for beginning of value",
-			expectedErrorType:    BuildingRequestError,
+			expectedErrorMessage: `unexpected response: rror":"invalid version number"}`,
+			expectedErrorType:    ApiHttpErrorType,
 		},
 		"Nil http response": {
 			accountId:            "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
@@ -0,0 +1,115 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DryRunHeader is set on the synthetic responses returned in dry-run mode
+const DryRunHeader = "X-Dry-Run"
+
+// DryRunRequest is a request the client would have sent in dry-run mode
+type DryRunRequest struct {
+	Time   time.Time
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// dryRunLog holds the requests recorded in dry-run mode
+type dryRunLog struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+// WithDryRun stops the client from sending requests which may change anything: every request but GET and HEAD is
+// held back, whichever resource it targets, unless its context is marked with WithReadOnly. Create, Update and
+// Delete, like the methods of the other resource packages, still validate their input and build the exact request,
+// which is recorded, then return a synthetic successful response echoing the request body. Reads such as Fetch and
+// List go through, as do POSTs marked read-only, e.g. cop name verifications. The recorded headers are the ones set
+// once the request hooks ran, so a signature added by a RequestHook is captured; one added by the http client's
+// transport is not. Held back mutations are not audited since nothing is sent
+func WithDryRun() ClientOption {
+	return func(c *Client) error {
+		c.dryRun = &dryRunLog{}
+		return nil
+	}
+}
+
+type readOnlyKey struct{}
+
+// WithReadOnly marks the requests sent with ctx as reads although their method is not GET, e.g. the POST of a name
+// verification, so a dry-run client sends them rather than holding them back
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// isReadOnly reports whether ctx was marked by WithReadOnly
+func isReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// holdsBack reports whether the client records req in dry-run mode instead of sending it
+func (c *Client) holdsBack(ctx context.Context, req *http.Request) bool {
+	return c.dryRun != nil && req.Method != http.MethodGet && req.Method != http.MethodHead && !isReadOnly(ctx)
+}
+
+// DryRun reports whether the client was created with WithDryRun
+func (c *Client) DryRun() bool {
+	return c.dryRun != nil
+}
+
+// DryRunRequests returns the requests recorded in dry-run mode, in the order they were built
+func (c *Client) DryRunRequests() []DryRunRequest {
+
+	if c.dryRun == nil {
+		return nil
+	}
+
+	c.dryRun.mu.Lock()
+	defer c.dryRun.mu.Unlock()
+
+	return append([]DryRunRequest(nil), c.dryRun.requests...)
+}
+
+// record stores the request and returns the synthetic response answering it: the request body with 201 Created
+// for a POST, 200 OK for a PATCH or PUT, and 204 No Content for a DELETE
+func (l *dryRunLog) record(req *http.Request, body []byte) *http.Response {
+
+	l.mu.Lock()
+	l.requests = append(l.requests, DryRunRequest{
+		Time:   time.Now().UTC(),
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+	l.mu.Unlock()
+
+	httpResp := &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{DryRunHeader: []string{"true"}, "Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}
+
+	switch req.Method {
+	case http.MethodPost:
+		httpResp.Status, httpResp.StatusCode = "201 Created", http.StatusCreated
+	case http.MethodDelete:
+		httpResp.Status, httpResp.StatusCode = "204 No Content", http.StatusNoContent
+		httpResp.Body = http.NoBody
+	}
+
+	return httpResp
+}
@@ -0,0 +1,228 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestWithDryRun_RecordsMutationsWithoutSendingThem(t *testing.T) {
+
+	// Arrange
+
+	accountId := "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	mutationsSent := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method != http.MethodGet {
+			mutationsSent++
+		}
+
+		w.Write([]byte(`{"data":{"id":"` + accountId + `","version":1}}`))
+	}))
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithDryRun())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx := context.Background()
+	account := &AccountData{Data: &Data{ID: accountId, Type: "accounts", Attributes: &AccountAttributes{Country: "GB"}}}
+
+	// Act
+
+	created, createErr := accountsClient.Create(ctx, account)
+	_, updateErr := accountsClient.Update(ctx, account)
+	deleteErr := accountsClient.Delete(ctx, uuid.MustParse(accountId), 1)
+	fetched, fetchErr := accountsClient.Fetch(ctx, uuid.MustParse(accountId))
+
+	// Assert
+
+	if createErr != nil || updateErr != nil || deleteErr != nil || fetchErr != nil {
+		t.Fatalf("unexpected errors: got %v %v %v %v", createErr, updateErr, deleteErr, fetchErr)
+	}
+
+	if mutationsSent != 0 {
+		t.Errorf("unexpected mutations sent: got %v want 0", mutationsSent)
+	}
+
+	if created.Status != http.StatusCreated || created.Data.ID != accountId {
+		t.Errorf("unexpected synthetic response: got %v %v want %v %v", created.Status, created.Data.ID, http.StatusCreated, accountId)
+	}
+
+	if fetched.Data.Version != 1 {
+		t.Errorf("unexpected fetched version: got %v want 1 from the server", fetched.Data.Version)
+	}
+
+	requests := accountsClient.DryRunRequests()
+
+	if len(requests) != 3 {
+		t.Fatalf("unexpected number of recorded requests: got %v want 3", len(requests))
+	}
+
+	cases := map[string]struct {
+		got    DryRunRequest
+		method string
+		url    string
+	}{
+		"create": {got: requests[0], method: http.MethodPost, url: ts.URL + "/v1/organisation/accounts"},
+		"update": {got: requests[1], method: http.MethodPatch, url: ts.URL + "/v1/organisation/accounts/" + accountId},
+		"delete": {got: requests[2], method: http.MethodDelete, url: ts.URL + "/v1/organisation/accounts/" + accountId + "?version=1"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			if c.got.Method != c.method || c.got.URL != c.url {
+				t.Errorf("unexpected request: got %v %v want %v %v", c.got.Method, c.got.URL, c.method, c.url)
+			}
+
+			if c.got.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("unexpected headers: got %v", c.got.Header)
+			}
+		})
+	}
+
+	if !strings.Contains(string(requests[0].Body), `"id":"`+accountId+`"`) {
+		t.Errorf("unexpected create body: got %s", requests[0].Body)
+	}
+}
+
+func TestWithDryRun_StillValidates(t *testing.T) {
+
+	// Arrange
+
+	rejectAll := AccountValidatorFunc(func(ctx context.Context, account *Data) error {
+		return ValidationError
+	})
+
+	accountsClient, err := NewClient(WithDryRun(), WithAccountValidator(rejectAll))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	_, createErr := accountsClient.Create(context.Background(), &AccountData{Data: &Data{ID: uuid.NewString()}})
+	_, updateErr := accountsClient.Update(context.Background(), &AccountData{Data: &Data{ID: "not-a-uuid"}})
+
+	// Assert
+
+	if !errors.Is(createErr, ValidationError) {
+		t.Errorf("unexpected create error: got %v want %v", createErr, ValidationError)
+	}
+
+	if !errors.Is(updateErr, BuildingRequestError) {
+		t.Errorf("unexpected update error: got %v want %v", updateErr, BuildingRequestError)
+	}
+
+	if len(accountsClient.DryRunRequests()) != 0 {
+		t.Errorf("unexpected recorded requests: got %v want none", accountsClient.DryRunRequests())
+	}
+}
+
+func TestWithDryRun_SendsReadsAndRecordsHookHeaders(t *testing.T) {
+
+	// Arrange
+
+	accountId := "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	var sent []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"id":"` + accountId + `","version":0}}`))
+	}))
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithDryRun(), WithRequestHook(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Signature", `keyId="operator",signature="c2lnbmVk"`)
+		return nil
+	}))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	verifications := NewResource[Data](accountsClient, "cop", "/v1/validations/name-verifications")
+	ctx := context.Background()
+
+	// Act
+
+	verified, verifyErr := verifications.Create(WithReadOnly(ctx), &Document[Data]{Data: &Data{ID: accountId}})
+	deleteErr := accountsClient.Delete(ctx, uuid.MustParse(accountId), 0)
+
+	// Assert
+
+	if verifyErr != nil || deleteErr != nil {
+		t.Fatalf("unexpected errors: got %v %v", verifyErr, deleteErr)
+	}
+
+	if len(sent) != 1 || sent[0] != "POST /v1/validations/name-verifications" || verified.Header.Get(DryRunHeader) != "" {
+		t.Errorf("unexpected requests sent: got %v want the verification only", sent)
+	}
+
+	requests := accountsClient.DryRunRequests()
+
+	if len(requests) != 1 || requests[0].Method != http.MethodDelete || requests[0].Header.Get("Signature") != `keyId="operator",signature="c2lnbmVk"` {
+		t.Errorf("unexpected recorded requests: got %+v want the signed delete", requests)
+	}
+}
+
+func TestWithDryRun_HoldsBackEveryResourceMutation(t *testing.T) {
+
+	// Arrange
+
+	sent := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+	}))
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithDryRun())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	payments := NewResource[Data](accountsClient, "payments", "/v1/transaction/payments")
+	paymentId := "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	ctx := context.Background()
+
+	// Act
+
+	created, createErr := payments.Create(ctx, &Document[Data]{Data: &Data{ID: paymentId}})
+	_, patchErr := payments.Patch(ctx, paymentId, &Document[Data]{Data: &Data{ID: paymentId}})
+	deleteErr := payments.Delete(ctx, paymentId, 0)
+
+	// Assert
+
+	if createErr != nil || patchErr != nil || deleteErr != nil {
+		t.Fatalf("unexpected errors: got %v %v %v", createErr, patchErr, deleteErr)
+	}
+
+	if sent != 0 {
+		t.Errorf("unexpected requests sent: got %v want 0", sent)
+	}
+
+	if created.Header.Get(DryRunHeader) != "true" || created.Data.ID != paymentId {
+		t.Errorf("unexpected synthetic response: got %v %+v", created.Header, created.Data)
+	}
+
+	if len(accountsClient.DryRunRequests()) != 3 {
+		t.Errorf("unexpected recorded requests: got %v want 3", len(accountsClient.DryRunRequests()))
+	}
+}
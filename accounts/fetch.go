@@ -2,9 +2,8 @@ package accounts
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -12,64 +11,31 @@ import (
 // Fetch retrieves account related information using an accountId
 func (c *Client) Fetch(ctx context.Context, accountId uuid.UUID) (*AccountResponse, error) {
 
-	accountResponse := &AccountResponse{}
-
-	if err := c.getJSON(ctx, accountId, AccountsApiDefaultUrl, accountResponse); err != nil {
-		return nil, err
+	if c.cache != nil {
+		return c.fetchCached(ctx, accountId)
 	}
 
-	if err := accountResponse.Error(); err != nil {
-		return nil, err
-	}
-
-	return accountResponse, nil
-}
-
-func (c *Client) getJSON(ctx context.Context, accountId uuid.UUID, config *apiConfig, resp *AccountResponse) error {
-
-	httpResp, err := c.get(ctx, accountId, config)
+	response, err := c.accountsResource().Fetch(ctx, accountId.String())
 
 	if err != nil {
-		if httpResp != nil {
-			return fmt.Errorf("%w | %d | %s", BuildingRequestError, httpResp.StatusCode, err)
-		} else {
-			return fmt.Errorf("%w | %d | %s", BuildingRequestError, http.StatusBadRequest, err)
-		}
+		return nil, err
 	}
 
-	resp.Status = httpResp.StatusCode
-
-	defer httpResp.Body.Close()
+	accountResponse := newAccountResponse(response)
 
-	err = json.NewDecoder(httpResp.Body).Decode(resp)
-
-	if err != nil {
-		return fmt.Errorf("%w | %d | %s", BuildingRequestError, httpResp.StatusCode, err)
+	if err := checkFetchedID(accountResponse, accountId); err != nil {
+		return nil, err
 	}
 
-	return nil
-
+	return accountResponse, nil
 }
 
-func (c *Client) get(ctx context.Context, accountId uuid.UUID, config *apiConfig) (*http.Response, error) {
-
-	var err error
-
-	c.baseURL, err = c.baseURL.Parse(config.path + "/" + accountId.String())
+// checkFetchedID fails with ValidationError when the API answered with another account than the requested one
+func checkFetchedID(response *AccountResponse, accountId uuid.UUID) error {
 
-	if err != nil {
-		return nil, err
+	if response.AccountData == nil || response.Data == nil || strings.EqualFold(response.Data.ID, accountId.String()) {
+		return nil
 	}
-	customReq, err := http.NewRequest(http.MethodGet, c.baseURL.String(), nil)
-
-	if err != nil {
-		return nil, err
-	}
-
-	customReq.WithContext(ctx)
-
-	addHeaders(customReq)
-
-	return c.httpClient.Do(customReq)
 
+	return fmt.Errorf("%w | %d | %s", ValidationError, response.Status, fmt.Sprintf("fetched account %s instead of %s", response.Data.ID, accountId))
 }
@@ -248,9 +248,9 @@ func TestFetchErrorCases(t *testing.T) {
 			accountId:            "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
 			messageResponse:      "",
 			requestPath:          "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4df",
-			expectedErrorMessage: "EOF",
+			expectedErrorMessage: "",
 			expectedHttpStatus:   http.StatusInternalServerError,
-			expectedErrorType:    BuildingRequestError,
+			expectedErrorType:    ApiHttpErrorType,
 		},
 		"Invalid json response in successful request": {
 			accountId:            "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
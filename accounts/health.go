@@ -0,0 +1,184 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Default values used by the health checks
+const (
+	HealthPath           = "/v1/health"
+	EndpointHealth       = "health"
+	HealthStatusUp       = "up"
+	DefaultReadyInterval = 500 * time.Millisecond
+	DefaultReadyMaxDelay = 5 * time.Second
+)
+
+var healthApiDefaultUrl = &apiConfig{
+	host: AccountsApiDefaultUrl.host,
+	path: HealthPath,
+}
+
+// Health is the outcome of a Ping
+type Health struct {
+	Status string `json:"status"`
+
+	// Version is the API version, when the health endpoint reports it
+	Version string `json:"version,omitempty"`
+
+	// Latency is the round trip time of the health request
+	Latency time.Duration `json:"-"`
+}
+
+// NotReadyError is returned by WaitUntilReady when ctx is done before the API reports itself up. It matches
+// ApiHttpErrorType and the error of the context with errors.Is, and unwraps to the error of the last attempt, e.g.
+// an *ApiError or ErrCircuitOpen
+type NotReadyError struct {
+	Attempts int
+
+	// Err is the error of the last attempt
+	Err error
+
+	// ContextErr is the error of the context, context.DeadlineExceeded or context.Canceled
+	ContextErr error
+}
+
+func (e *NotReadyError) Error() string {
+	return fmt.Sprintf("%s | %d | %s", ApiHttpErrorType, http.StatusServiceUnavailable, fmt.Sprintf("api not ready after %d attempts: %s", e.Attempts, e.Err))
+}
+
+// Is makes the error match ApiHttpErrorType and the error of the context
+func (e *NotReadyError) Is(target error) bool {
+	return target == ApiHttpErrorType || (e.ContextErr != nil && target == e.ContextErr)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *NotReadyError) Unwrap() error {
+	return e.Err
+}
+
+// Backoff returns the delay before the given retry attempt, starting at 1
+type Backoff func(attempt int) time.Duration
+
+// ExponentialBackoff doubles the delay from initial on every attempt, up to max
+func ExponentialBackoff(initial time.Duration, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+
+		delay := initial
+
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+
+		if delay > max {
+			return max
+		}
+
+		return delay
+	}
+}
+
+// Ping calls the API health endpoint, failing with ApiHttpErrorType unless the API reports itself up
+func (c *Client) Ping(ctx context.Context) (*Health, error) {
+
+	start := time.Now()
+
+	httpResp, err := c.send(ctx, http.MethodGet, healthApiDefaultUrl, HealthPath, nil, nil, nil, EndpointHealth)
+
+	if err != nil {
+		return nil, requestError(httpResp, err)
+	}
+
+	defer httpResp.Body.Close()
+
+	health := &Health{Latency: time.Since(start)}
+
+	body, err := readBody(httpResp)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w | %d | %s", BuildingRequestError, httpResp.StatusCode, err)
+	}
+
+	if err := json.Unmarshal(body, health); err != nil {
+		if !isHttpCodeOK(httpResp.StatusCode) {
+			return nil, newApiError(httpResp, body)
+		}
+		return nil, fmt.Errorf("%w | %d | %s", BuildingRequestError, httpResp.StatusCode, err)
+	}
+
+	if !isHttpCodeOK(httpResp.StatusCode) {
+		apiErr := newApiError(httpResp, body)
+		apiErr.Message = "api status is " + health.Status
+		return health, apiErr
+	}
+
+	if health.Status != HealthStatusUp {
+		return health, fmt.Errorf("%w | %d | %s", ApiHttpErrorType, httpResp.StatusCode, "api status is "+health.Status)
+	}
+
+	return health, nil
+}
+
+// WaitUntilReady pings the API until it reports itself up, waiting backoff(attempt) between attempts, and fails
+// with a *NotReadyError wrapping the last error once ctx is done. A nil backoff uses ExponentialBackoff(DefaultReadyInterval, DefaultReadyMaxDelay)
+func (c *Client) WaitUntilReady(ctx context.Context, backoff Backoff) (*Health, error) {
+
+	if backoff == nil {
+		backoff = ExponentialBackoff(DefaultReadyInterval, DefaultReadyMaxDelay)
+	}
+
+	for attempt := 1; ; attempt++ {
+
+		health, err := c.Ping(ctx)
+
+		if err == nil {
+			return health, nil
+		}
+
+		timer := time.NewTimer(backoff(attempt))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &NotReadyError{Attempts: attempt, Err: err, ContextErr: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+// ReadinessHandler returns an http.Handler answering 200 while the API is up and 503 otherwise, with the outcome of
+// the ping as a json body. Mount it as a dependency readiness check
+func (c *Client) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		health, err := c.Ping(r.Context())
+
+		body := struct {
+			Status    string `json:"status"`
+			Version   string `json:"version,omitempty"`
+			LatencyMs int64  `json:"latency_ms"`
+			Error     string `json:"error,omitempty"`
+		}{Status: "down"}
+
+		if health != nil {
+			body.Version = health.Version
+			body.LatencyMs = health.Latency.Milliseconds()
+		}
+
+		status := http.StatusOK
+
+		if err != nil {
+			status = http.StatusServiceUnavailable
+			body.Error = err.Error()
+		} else {
+			body.Status = health.Status
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	})
+}
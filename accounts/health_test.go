@@ -0,0 +1,159 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitUntilReady_ApiComingUp_ReturnsHealth(t *testing.T) {
+
+	// Arrange
+
+	pings := 0
+
+	ts := newTestServer(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		pings++
+		if pings < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, `{"status":"down"}`)
+			return
+		}
+		io.WriteString(w, `{"status":"up","version":"v1.0.0"}`)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Act
+
+	health, err := accountsClient.WaitUntilReady(ctx, ExponentialBackoff(time.Millisecond, 4*time.Millisecond))
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("wait returned an error: got %v want %v", err, nil)
+	}
+
+	if health.Status != HealthStatusUp || health.Version != "v1.0.0" || health.Latency <= 0 {
+		t.Errorf("wait returned unexpected health: got %+v", health)
+	}
+
+	if pings != 3 {
+		t.Errorf("server received unexpected number of pings: got %d want %d", pings, 3)
+	}
+}
+
+func TestWaitUntilReady_ApiNeverUp_ReturnsErrorOnceContextIsDone(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		io.WriteString(w, `{"status":"down"}`)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+
+	health, err := accountsClient.WaitUntilReady(ctx, ExponentialBackoff(time.Millisecond, 5*time.Millisecond))
+
+	// Assert
+
+	if health != nil {
+		t.Errorf("Returned health: got %v want %v", health, nil)
+	}
+
+	if !errors.Is(err, ApiHttpErrorType) || !strings.Contains(err.Error(), "| 503 | api not ready after") {
+		t.Errorf("wait returned unexpected error: got %v", err)
+	}
+
+	var apiErr *ApiError
+
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &apiErr) || apiErr.Status != http.StatusServiceUnavailable {
+		t.Errorf("wait returned an error not wrapping the context and last attempt errors: got %v", err)
+	}
+}
+
+func TestReadinessHandlerCases(t *testing.T) {
+
+	// Arrange
+
+	cases := map[string]struct {
+		apiStatus      int
+		apiBody        string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"Api up": {
+			apiStatus:      http.StatusOK,
+			apiBody:        `{"status":"up"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   HealthStatusUp,
+		},
+		"Api down": {
+			apiStatus:      http.StatusServiceUnavailable,
+			apiBody:        `{"status":"down"}`,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "down",
+		},
+	}
+
+	for name, testCase := range cases {
+
+		ts := newTestServer(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(testCase.apiStatus)
+			io.WriteString(w, testCase.apiBody)
+		})
+
+		accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		recorder := httptest.NewRecorder()
+
+		// Act
+
+		accountsClient.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		// Assert
+
+		var body struct {
+			Status string `json:"status"`
+		}
+
+		json.NewDecoder(recorder.Body).Decode(&body)
+
+		if recorder.Code != testCase.expectedStatus || body.Status != testCase.expectedBody {
+			t.Errorf("%s: handler returned unexpected answer: got %d %s want %d %s", name, recorder.Code, body.Status, testCase.expectedStatus, testCase.expectedBody)
+		}
+
+		ts.Close()
+	}
+}
@@ -0,0 +1,71 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// RequestIDHeader is the response header carrying the id Form3 gives to each request
+const RequestIDHeader = "X-Request-Id"
+
+// RequestHook can change a request, e.g. add headers taken from the context, before it is sent. An error aborts the
+// request, which then fails with BuildingRequestError
+type RequestHook func(ctx context.Context, req *http.Request) error
+
+// ResponseHook observes every response received from the API, before its body is read
+type ResponseHook func(ctx context.Context, resp *http.Response)
+
+// WithRequestHook calls hook on every request built by the client, after the default headers are set. Hooks run
+// in the order they were added
+func WithRequestHook(hook RequestHook) ClientOption {
+	return func(c *Client) error {
+
+		if hook == nil {
+			return fmt.Errorf("%w | %d | %s", ClientCreationError, http.StatusBadRequest, "request hook must not be nil")
+		}
+
+		c.requestHooks = append(c.requestHooks, hook)
+
+		return nil
+	}
+}
+
+// WithResponseHook calls hook on every response received from the API, successful or not. Hooks run in the order
+// they were added. The synthetic responses of dry-run mode are not received from the API and skip the hooks
+func WithResponseHook(hook ResponseHook) ClientOption {
+	return func(c *Client) error {
+
+		if hook == nil {
+			return fmt.Errorf("%w | %d | %s", ClientCreationError, http.StatusBadRequest, "response hook must not be nil")
+		}
+
+		c.responseHooks = append(c.responseHooks, hook)
+
+		return nil
+	}
+}
+
+// runRequestHooks applies the request hooks in order, stopping at the first error
+func (c *Client) runRequestHooks(ctx context.Context, req *http.Request) error {
+
+	for _, hook := range c.requestHooks {
+		if err := hook(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runResponseHooks passes the response to every response hook
+func (c *Client) runResponseHooks(ctx context.Context, resp *http.Response) {
+
+	if resp == nil {
+		return
+	}
+
+	for _, hook := range c.responseHooks {
+		hook(ctx, resp)
+	}
+}
@@ -0,0 +1,171 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type tenantKey struct{}
+
+const hooksAccountId = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+func TestHooks_AddHeadersAndCaptureTheRequestId(t *testing.T) {
+
+	// Arrange
+
+	var received http.Header
+
+	ts := newTestServer("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set(RequestIDHeader, "form3-request-1")
+		io.WriteString(w, `{"data":{"id":"`+hooksAccountId+`","type":"accounts","version":0}}`)
+	})
+
+	defer ts.Close()
+
+	var captured []string
+
+	accountsClient, err := NewClient(
+		WithBaseURL(ts.URL),
+		WithRequestHook(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-Tenant", ctx.Value(tenantKey{}).(string))
+			return nil
+		}),
+		WithRequestHook(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-Request-ID", "correlation-"+req.Header.Get("X-Tenant"))
+			return nil
+		}),
+		WithResponseHook(func(ctx context.Context, resp *http.Response) {
+			captured = append(captured, resp.Header.Get(RequestIDHeader))
+		}),
+	)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	// Act
+
+	response, err := accountsClient.Fetch(ctx, uuid.MustParse(hooksAccountId))
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if received.Get("X-Tenant") != "acme" || received.Get("X-Request-ID") != "correlation-acme" {
+		t.Errorf("unexpected request headers: got %v want the tenant and correlation id", received)
+	}
+
+	if received.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected content type: got %v want %v", received.Get("Content-Type"), "application/json")
+	}
+
+	if len(captured) != 1 || captured[0] != "form3-request-1" {
+		t.Errorf("unexpected captured request ids: got %v want %v", captured, []string{"form3-request-1"})
+	}
+
+	if response.RequestID != "form3-request-1" || response.Header.Get(RequestIDHeader) != "form3-request-1" {
+		t.Errorf("unexpected response request id: got %v and %v want %v", response.RequestID, response.Header, "form3-request-1")
+	}
+}
+
+func TestWithRequestHook_Error_AbortsTheRequest(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := newTestServer("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithRequestHook(func(ctx context.Context, req *http.Request) error {
+		return errors.New("no tenant in context")
+	}))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	err = accountsClient.Delete(context.Background(), uuid.MustParse(hooksAccountId), 0)
+
+	// Assert
+
+	assertClientError(err, "no tenant in context", t, BuildingRequestError, http.StatusBadRequest)
+
+	if requests != 0 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 0)
+	}
+}
+
+func TestApiError_HoldsTheRequestId(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, "form3-request-2")
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error_message":"record does not exist"}`)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	err = accountsClient.Delete(context.Background(), uuid.MustParse(hooksAccountId), 0)
+
+	// Assert
+
+	var apiErr *ApiError
+
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "form3-request-2" {
+		t.Errorf("unexpected error: got %v want an ApiError with request id %v", err, "form3-request-2")
+	}
+}
+
+func TestHooks_Nil_ReturnsClientCreationError(t *testing.T) {
+
+	cases := map[string]struct {
+		option          ClientOption
+		expectedMessage string
+	}{
+		"request hook":  {option: WithRequestHook(nil), expectedMessage: "request hook must not be nil"},
+		"response hook": {option: WithResponseHook(nil), expectedMessage: "response hook must not be nil"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+
+			accountClient, err := NewClient(c.option)
+
+			// Assert
+
+			if accountClient != nil {
+				t.Errorf("Returned reponse: got %v want %v", accountClient, nil)
+			}
+
+			assertClientError(err, c.expectedMessage, t, ClientCreationError, http.StatusBadRequest)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/audit"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Audit
+func (s *e2eTestSuite) TestAudit_PostgresSinkChainsMutations() {
+
+	// Arrange
+
+	sink := audit.NewPostgresSink(s.dbConn)
+
+	ctx := accounts.WithOperator(context.Background(), "integration")
+
+	s.Require().NoError(sink.Migrate(ctx))
+
+	s.Require().NoError(s.dbConn.Exec("TRUNCATE audit_records").Error)
+
+	accountsClient, err := accounts.NewClient(accounts.WithBaseURL(envVar.ApplicationUrl), accounts.WithAuditSink(sink))
+
+	s.Require().NoError(err)
+
+	accountId := uuid.New()
+
+	// Act
+
+	_, err = accountsClient.Create(ctx, generatedExpectedAccountToBeReturnedByAPI(accountId))
+
+	s.Require().NoError(err)
+
+	s.Require().NoError(accountsClient.Delete(ctx, accountId, 0))
+
+	records, err := sink.Records(ctx)
+
+	// Assert
+
+	s.Require().NoError(err)
+
+	s.Require().Len(records, 2, "Create and Delete should both be recorded")
+
+	assert.Equal(s.T(), accountId.String(), records[1].AccountID, "AccountID from the delete record, should match the deleted account")
+
+	assert.Equal(s.T(), "integration", records[0].Operator, "Operator from the record, should match the context operator")
+
+	assert.NoError(s.T(), sink.Verify(ctx), "Untouched chain should verify")
+
+	assert.Error(s.T(), s.dbConn.Exec("UPDATE audit_records SET operator = 'mallory'").Error, "Updates should be rejected")
+
+	s.Require().NoError(s.dbConn.Exec("ALTER TABLE audit_records DISABLE TRIGGER audit_records_append_only").Error)
+	s.Require().NoError(s.dbConn.Exec("UPDATE audit_records SET operator = 'mallory' WHERE sequence = 1").Error)
+	s.Require().NoError(s.dbConn.Exec("ALTER TABLE audit_records ENABLE TRIGGER audit_records_append_only").Error)
+
+	assert.True(s.T(), errors.Is(sink.Verify(ctx), audit.ErrTampered), "Altered chain should not verify")
+}
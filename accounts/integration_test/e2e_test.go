@@ -5,6 +5,7 @@ import (
 	"ei09010/form3-api-client/accounts"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/lib/pq"
@@ -32,6 +33,19 @@ func (s *e2eTestSuite) SetupSuite() {
 
 	envVar.InitEnvVariables()
 
+	accountsClient, err := accounts.NewClient(accounts.WithBaseURL(envVar.ApplicationUrl))
+
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	health, err := accountsClient.WaitUntilReady(ctx, nil)
+
+	s.Require().NoError(err, "Account API should be ready")
+
+	fmt.Printf(" > Account API is ready, answered health check in %s\n", health.Latency)
+
 	dbUri := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s",
 		envVar.DatabaseHostUrl, envVar.DatabasePort, envVar.DatabaseUser, envVar.DatabaseName, envVar.DatabasePwd)
 
@@ -0,0 +1,108 @@
+package integration
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/mirror"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Mirror
+func (s *e2eTestSuite) TestMirror_SyncsAccountsAndDetectsDeletions() {
+
+	// Arrange
+
+	accountsClient, err := accounts.NewClient(accounts.WithBaseURL(envVar.ApplicationUrl))
+
+	s.Require().NoError(err)
+
+	store := mirror.NewGormStore(s.dbConn)
+
+	ctx := context.Background()
+
+	s.Require().NoError(store.Migrate(ctx))
+
+	s.dbConn.Delete(&mirror.Record{})
+	s.dbConn.Delete(&mirror.SyncState{})
+
+	keptId, err := uuid.NewUUID()
+
+	s.Require().NoError(err)
+
+	deletedId, err := uuid.NewUUID()
+
+	s.Require().NoError(err)
+
+	s.NoError(s.dbConn.Create(generateAccountDataToStore(keptId)).Error)
+	s.NoError(s.dbConn.Create(generateAccountDataToStore(deletedId)).Error)
+
+	syncer := mirror.NewSyncer(accountsClient, store, mirror.WithPageSize(1))
+
+	_, err = syncer.Sync(ctx)
+
+	s.Require().NoError(err)
+
+	s.Require().NoError(accountsClient.Delete(ctx, deletedId, 0))
+
+	// Act
+
+	result, err := syncer.Sync(ctx)
+
+	s.Require().NoError(err)
+
+	// Assert
+
+	assert.Equal(s.T(), 1, result.Removed, "Deleted account should be marked as removed")
+
+	kept, err := store.Get(ctx, keptId.String())
+
+	s.Require().NoError(err)
+
+	assert.Nil(s.T(), kept.RemovedAt, "Kept account should not be marked as removed")
+
+	assert.Equal(s.T(), "400300", kept.Attributes.BankID, "BankId from the mirrored account, should match the stored account")
+
+	deleted, err := store.Get(ctx, deletedId.String())
+
+	s.Require().NoError(err)
+
+	assert.NotNil(s.T(), deleted.RemovedAt, "Deleted account should be marked as removed")
+}
+
+func (s *e2eTestSuite) TestMirror_FindAccounts_MatchesAttributesOfLiveAccounts() {
+
+	// Arrange
+
+	store := mirror.NewGormStore(s.dbConn)
+
+	ctx := context.Background()
+
+	s.Require().NoError(store.Migrate(ctx))
+
+	s.dbConn.Delete(&mirror.Record{})
+
+	liveId := uuid.New().String()
+	removedId := uuid.New().String()
+
+	s.Require().NoError(store.Upsert(ctx, []*mirror.Record{
+		{ID: liveId, Version: 0, Attributes: mirror.Attributes{BankID: "400300", AccountNumber: "41426819"}},
+		{ID: removedId, Version: 0, Attributes: mirror.Attributes{BankID: "400300", AccountNumber: "41426819"}},
+	}))
+
+	s.Require().NoError(store.MarkRemoved(ctx, []string{removedId}, time.Now()))
+
+	// Act
+
+	found, err := store.FindAccounts(ctx, map[string]string{"bank_id": "400300", "account_number": "41426819"})
+
+	// Assert
+
+	s.Require().NoError(err)
+
+	s.Require().Len(found, 1, "Only the live account should match")
+
+	assert.Equal(s.T(), liveId, found[0].ID, "Id from the found account, should match the live account")
+}
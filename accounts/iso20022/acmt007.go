@@ -0,0 +1,214 @@
+package iso20022
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+// AccountOpeningDocument is an acmt.007 account opening request
+type AccountOpeningDocument struct {
+	XMLName xml.Name              `xml:"Document"`
+	Xmlns   string                `xml:"xmlns,attr"`
+	Request AccountOpeningRequest `xml:"AcctOpngReq"`
+}
+
+// AccountOpeningRequest is the body of an acmt.007 message
+type AccountOpeningRequest struct {
+	References      References           `xml:"Refs"`
+	Account         CashAccount          `xml:"Acct"`
+	AccountServicer FinancialInstitution `xml:"AcctSvcrId"`
+	Organisation    *Organisation        `xml:"Org,omitempty"`
+	Unsupported     []AnyElement         `xml:",any"`
+}
+
+// ToAccountOpening converts an account to an acmt.007 request. The process id carries the account id
+func ToAccountOpening(accountData *accounts.AccountData) (*AccountOpeningDocument, *Report, error) {
+
+	data, err := accountOf(accountData)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &Report{}
+	now := time.Now()
+
+	document := &AccountOpeningDocument{
+		Xmlns: accountOpeningNamespace,
+		Request: AccountOpeningRequest{
+			References: References{
+				MessageID: newMessageIdentification(data.ID, now),
+				ProcessID: newMessageIdentification(data.ID, now),
+			},
+			Account:         cashAccount(data.Attributes, report),
+			AccountServicer: servicer(data.Attributes),
+			Organisation:    organisation(data),
+		},
+	}
+
+	reportUnmappedAttributes(data.Attributes, report)
+
+	return document, report, nil
+}
+
+// FromAccountOpening converts an acmt.007 request to an account, ready to be created
+func FromAccountOpening(document *AccountOpeningDocument) (*accounts.AccountData, *Report, error) {
+
+	request := &document.Request
+	report := &Report{}
+
+	report.addUnsupported("AcctOpngReq", request.Unsupported)
+	report.addUnsupported("AcctOpngReq/Acct", request.Account.Unsupported)
+	report.addUnsupported("AcctOpngReq/AcctSvcrId/FinInstnId", request.AccountServicer.FinInstnID.Unsupported)
+
+	attributes := &accounts.AccountAttributes{
+		BaseCurrency: request.Account.Currency,
+	}
+
+	applyAccountIdentification(attributes, request.Account.ID)
+	applyServicer(attributes, request.AccountServicer)
+
+	if request.Account.Name != "" {
+		attributes.Name = []string{request.Account.Name}
+	}
+
+	if request.Account.Type != nil {
+		attributes.AccountClassification = request.Account.Type.Proprietary
+	}
+
+	data := &accounts.Data{
+		ID:         expandID(request.References.ProcessID.ID),
+		Type:       "accounts",
+		Attributes: attributes,
+	}
+
+	if request.Organisation != nil {
+
+		if request.Organisation.OrgID != nil {
+			data.OrganisationID = expandID(request.Organisation.OrgID.Other.ID)
+		}
+
+		if request.Organisation.FullLegalName != "" && request.Organisation.FullLegalName != request.Account.Name {
+			report.add("AcctOpngReq/Org/FullLglNm", "organisation names are not stored on accounts")
+		}
+	}
+
+	return &accounts.AccountData{Data: data}, report, nil
+}
+
+// cashAccount maps the identification, name, classification and currency of an account
+func cashAccount(attributes *accounts.AccountAttributes, report *Report) CashAccount {
+
+	account := CashAccount{
+		ID:       accountIdentification(attributes, report),
+		Currency: attributes.BaseCurrency,
+	}
+
+	if len(attributes.Name) > 0 {
+		account.Name = strings.Join(attributes.Name, " ")
+	}
+
+	if attributes.AccountClassification != "" {
+		account.Type = &AccountType{Proprietary: attributes.AccountClassification}
+	}
+
+	return account
+}
+
+// accountIdentification prefers the IBAN, reporting the account number when both are set
+func accountIdentification(attributes *accounts.AccountAttributes, report *Report) AccountIdentification {
+
+	if attributes.Iban != "" {
+
+		if attributes.AccountNumber != "" && report != nil {
+			report.add("attributes.account_number", "the IBAN identifies the account, the account number is dropped")
+		}
+
+		return AccountIdentification{IBAN: attributes.Iban}
+	}
+
+	if attributes.AccountNumber != "" {
+		return AccountIdentification{Other: &GenericAccountIdentification{ID: attributes.AccountNumber}}
+	}
+
+	return AccountIdentification{}
+}
+
+func applyAccountIdentification(attributes *accounts.AccountAttributes, identification AccountIdentification) {
+
+	attributes.Iban = identification.IBAN
+
+	if identification.Other != nil {
+		attributes.AccountNumber = identification.Other.ID
+	}
+}
+
+// servicer maps the bic, bank id and country of an account to its servicing institution
+func servicer(attributes *accounts.AccountAttributes) FinancialInstitution {
+
+	identification := FinancialInstitutionIdentification{BICFI: attributes.Bic}
+
+	if attributes.BankID != "" {
+		identification.ClrSysMmbID = &ClearingSystemMemberID{
+			ClrSysID: ClearingSystemID{Code: attributes.BankIDCode},
+			MemberID: attributes.BankID,
+		}
+	}
+
+	if attributes.Country != "" {
+		identification.PostalAddress = &PostalAddress{Country: attributes.Country}
+	}
+
+	return FinancialInstitution{FinInstnID: identification}
+}
+
+func applyServicer(attributes *accounts.AccountAttributes, institution FinancialInstitution) {
+
+	identification := institution.FinInstnID
+
+	attributes.Bic = identification.BICFI
+
+	if identification.ClrSysMmbID != nil {
+		attributes.BankID = identification.ClrSysMmbID.MemberID
+		attributes.BankIDCode = identification.ClrSysMmbID.ClrSysID.Code
+	}
+
+	if identification.PostalAddress != nil {
+		attributes.Country = identification.PostalAddress.Country
+	}
+}
+
+func organisation(data *accounts.Data) *Organisation {
+
+	if data.OrganisationID == "" {
+		return nil
+	}
+
+	return &Organisation{
+		FullLegalName: strings.Join(data.Attributes.Name, " "),
+		OrgID:         &OrganisationIdentification{Other: GenericAccountIdentification{ID: compactID(data.OrganisationID)}},
+	}
+}
+
+// reportUnmappedAttributes lists the account attributes acmt messages have no element for
+func reportUnmappedAttributes(attributes *accounts.AccountAttributes, report *Report) {
+
+	if len(attributes.AlternativeNames) > 0 {
+		report.add("attributes.alternative_names", "acmt messages carry a single account name")
+	}
+
+	if attributes.AccountMatchingOptOut {
+		report.add("attributes.account_matching_opt_out", "no acmt element for Confirmation of Payee preferences")
+	}
+}
+
+func accountOf(accountData *accounts.AccountData) (*accounts.Data, error) {
+
+	if accountData == nil || accountData.Data == nil || accountData.Data.Attributes == nil {
+		return nil, validationError("account data and attributes are required")
+	}
+
+	return accountData.Data, nil
+}
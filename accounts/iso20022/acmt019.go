@@ -0,0 +1,80 @@
+package iso20022
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"encoding/xml"
+	"time"
+)
+
+// AccountClosingDocument is an acmt.019 account closing request
+type AccountClosingDocument struct {
+	XMLName xml.Name              `xml:"Document"`
+	Xmlns   string                `xml:"xmlns,attr"`
+	Request AccountClosingRequest `xml:"AcctClsgReq"`
+}
+
+// AccountClosingRequest is the body of an acmt.019 message
+type AccountClosingRequest struct {
+	References      References            `xml:"Refs"`
+	AccountID       AccountIdentification `xml:"AcctId"`
+	AccountServicer FinancialInstitution  `xml:"AcctSvcrId"`
+	Organisation    *Organisation         `xml:"Org,omitempty"`
+	Unsupported     []AnyElement          `xml:",any"`
+}
+
+// ToAccountClosing converts an account to an acmt.019 request. The process id carries the account id
+func ToAccountClosing(accountData *accounts.AccountData) (*AccountClosingDocument, *Report, error) {
+
+	data, err := accountOf(accountData)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &Report{}
+	now := time.Now()
+
+	if data.Version != 0 {
+		report.add("version", "acmt.019 has no element for the account version, deletion uses the version fetched on import")
+	}
+
+	return &AccountClosingDocument{
+		Xmlns: accountClosingNamespace,
+		Request: AccountClosingRequest{
+			References: References{
+				MessageID: newMessageIdentification(data.ID, now),
+				ProcessID: newMessageIdentification(data.ID, now),
+			},
+			AccountID:       accountIdentification(data.Attributes, report),
+			AccountServicer: servicer(data.Attributes),
+			Organisation:    organisation(data),
+		},
+	}, report, nil
+}
+
+// FromAccountClosing converts an acmt.019 request to the identifiers of the account to close
+func FromAccountClosing(document *AccountClosingDocument) (*accounts.AccountData, *Report, error) {
+
+	request := &document.Request
+	report := &Report{}
+
+	report.addUnsupported("AcctClsgReq", request.Unsupported)
+	report.addUnsupported("AcctClsgReq/AcctSvcrId/FinInstnId", request.AccountServicer.FinInstnID.Unsupported)
+
+	attributes := &accounts.AccountAttributes{}
+
+	applyAccountIdentification(attributes, request.AccountID)
+	applyServicer(attributes, request.AccountServicer)
+
+	data := &accounts.Data{
+		ID:         expandID(request.References.ProcessID.ID),
+		Type:       "accounts",
+		Attributes: attributes,
+	}
+
+	if request.Organisation != nil && request.Organisation.OrgID != nil {
+		data.OrganisationID = expandID(request.Organisation.OrgID.Other.ID)
+	}
+
+	return &accounts.AccountData{Data: data}, report, nil
+}
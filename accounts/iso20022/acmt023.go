@@ -0,0 +1,244 @@
+package iso20022
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Verification reason codes reported in acmt.024 when an identification is not verified
+const (
+	ReasonIncorrectAccountNumber = "AC01"
+	ReasonInconsistentName       = "BE01"
+)
+
+// VerificationRequestDocument is an acmt.023 identification verification request
+type VerificationRequestDocument struct {
+	XMLName xml.Name            `xml:"Document"`
+	Xmlns   string              `xml:"xmlns,attr"`
+	Request VerificationRequest `xml:"IdVrfctnReq"`
+}
+
+// VerificationRequest is the body of an acmt.023 message
+type VerificationRequest struct {
+	Assignment    Assignment     `xml:"Assgnmt"`
+	Verifications []Verification `xml:"Vrfctn"`
+	Unsupported   []AnyElement   `xml:",any"`
+}
+
+// Assignment identifies a verification message
+type Assignment struct {
+	MessageID      string `xml:"MsgId"`
+	CreationDtTime string `xml:"CreDtTm"`
+}
+
+// Verification is an identification to verify
+type Verification struct {
+	ID              string          `xml:"Id"`
+	PartyAndAccount PartyAndAccount `xml:"PtyAndAcctId"`
+}
+
+// PartyAndAccount is the identification of an account and of its holder
+type PartyAndAccount struct {
+	Party   *Party                `xml:"Pty,omitempty"`
+	Account AccountIdentification `xml:"Acct"`
+	Agent   *FinancialInstitution `xml:"Agt,omitempty"`
+}
+
+// Party holds the name of an account holder
+type Party struct {
+	Name string `xml:"Nm"`
+}
+
+// VerificationReportDocument is an acmt.024 identification verification report
+type VerificationReportDocument struct {
+	XMLName xml.Name           `xml:"Document"`
+	Xmlns   string             `xml:"xmlns,attr"`
+	Report  VerificationReport `xml:"IdVrfctnRpt"`
+}
+
+// VerificationReport is the body of an acmt.024 message
+type VerificationReport struct {
+	Assignment         Assignment                `xml:"Assgnmt"`
+	OriginalAssignment Assignment                `xml:"OrgnlAssgnmt"`
+	Reports            []VerificationReportEntry `xml:"Rpt"`
+}
+
+// VerificationReportEntry is the outcome of a Verification
+type VerificationReportEntry struct {
+	OriginalID    string           `xml:"OrgnlId"`
+	Verified      bool             `xml:"Vrfctn"`
+	Reason        *Reason          `xml:"Rsn,omitempty"`
+	OriginalParty PartyAndAccount  `xml:"OrgnlPtyAndAcctId"`
+	UpdatedParty  *PartyAndAccount `xml:"UpdtdPtyAndAcctId,omitempty"`
+}
+
+// Reason holds the code explaining a failed verification
+type Reason struct {
+	Code string `xml:"Cd"`
+}
+
+// VerificationResult is a decoded acmt.024 report entry
+type VerificationResult struct {
+	VerificationID string
+	Verified       bool
+	Reason         string
+
+	// Account holds the identification of the account as known by the reporting party, when it reported one
+	Account *accounts.AccountAttributes
+}
+
+// Resolver finds the account matching an identification, returning nil when none does
+type Resolver func(identification *accounts.AccountAttributes) (*accounts.Data, error)
+
+// ToVerificationRequest converts an account to an acmt.023 request verifying its holder name and identification
+func ToVerificationRequest(accountData *accounts.AccountData) (*VerificationRequestDocument, *Report, error) {
+
+	data, err := accountOf(accountData)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &Report{}
+
+	document := &VerificationRequestDocument{
+		Xmlns: verificationRequestNamespace,
+		Request: VerificationRequest{
+			Assignment: newAssignment(),
+			Verifications: []Verification{{
+				ID:              compactID(uuid.New().String()),
+				PartyAndAccount: partyAndAccount(data.Attributes, report),
+			}},
+		},
+	}
+
+	reportUnmappedAttributes(data.Attributes, report)
+
+	return document, report, nil
+}
+
+// VerifyRequest answers an acmt.023 request with an acmt.024 report, resolving every identification with resolve.
+// An identification is verified when an account matches it and, when a name is given, the holder name matches too
+func VerifyRequest(document *VerificationRequestDocument, resolve Resolver) (*VerificationReportDocument, *Report, error) {
+
+	request := &document.Request
+	report := &Report{}
+
+	report.addUnsupported("IdVrfctnReq", request.Unsupported)
+
+	answer := &VerificationReportDocument{
+		Xmlns: verificationReportNamespace,
+		Report: VerificationReport{
+			Assignment:         newAssignment(),
+			OriginalAssignment: request.Assignment,
+		},
+	}
+
+	for _, verification := range request.Verifications {
+
+		identification := &accounts.AccountAttributes{}
+		applyAccountIdentification(identification, verification.PartyAndAccount.Account)
+
+		if verification.PartyAndAccount.Agent != nil {
+			applyServicer(identification, *verification.PartyAndAccount.Agent)
+		}
+
+		account, err := resolve(identification)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry := VerificationReportEntry{
+			OriginalID:    verification.ID,
+			OriginalParty: verification.PartyAndAccount,
+		}
+
+		switch {
+		case account == nil || account.Attributes == nil:
+			entry.Reason = &Reason{Code: ReasonIncorrectAccountNumber}
+
+		case verification.PartyAndAccount.Party != nil && !sameName(verification.PartyAndAccount.Party.Name, account.Attributes):
+			entry.Reason = &Reason{Code: ReasonInconsistentName}
+			updated := partyAndAccount(account.Attributes, nil)
+			entry.UpdatedParty = &updated
+
+		default:
+			entry.Verified = true
+		}
+
+		answer.Report.Reports = append(answer.Report.Reports, entry)
+	}
+
+	return answer, report, nil
+}
+
+// FromVerificationReport decodes the entries of an acmt.024 report
+func FromVerificationReport(document *VerificationReportDocument) []VerificationResult {
+
+	results := make([]VerificationResult, 0, len(document.Report.Reports))
+
+	for _, entry := range document.Report.Reports {
+
+		result := VerificationResult{VerificationID: entry.OriginalID, Verified: entry.Verified}
+
+		if entry.Reason != nil {
+			result.Reason = entry.Reason.Code
+		}
+
+		if entry.UpdatedParty != nil {
+
+			result.Account = &accounts.AccountAttributes{}
+			applyAccountIdentification(result.Account, entry.UpdatedParty.Account)
+
+			if entry.UpdatedParty.Party != nil {
+				result.Account.Name = []string{entry.UpdatedParty.Party.Name}
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func partyAndAccount(attributes *accounts.AccountAttributes, report *Report) PartyAndAccount {
+
+	agent := servicer(attributes)
+
+	party := PartyAndAccount{
+		Account: accountIdentification(attributes, report),
+		Agent:   &agent,
+	}
+
+	if len(attributes.Name) > 0 {
+		party.Party = &Party{Name: strings.Join(attributes.Name, " ")}
+	}
+
+	return party
+}
+
+// sameName compares name with the holder and alternative names of an account, ignoring case and spacing
+func sameName(name string, attributes *accounts.AccountAttributes) bool {
+
+	wanted := strings.Join(strings.Fields(name), " ")
+
+	for _, candidate := range append([]string{strings.Join(attributes.Name, " ")}, attributes.AlternativeNames...) {
+		if strings.EqualFold(wanted, strings.Join(strings.Fields(candidate), " ")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newAssignment() Assignment {
+	return Assignment{
+		MessageID:      compactID(uuid.New().String()),
+		CreationDtTime: time.Now().UTC().Format(isoDateTimeLayout),
+	}
+}
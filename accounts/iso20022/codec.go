@@ -0,0 +1,105 @@
+// Package iso20022 converts accounts to and from the ISO 20022 account management messages spoken by core banking
+// platforms: acmt.007 account opening, acmt.019 account closing and acmt.023/024 identification verification.
+// Every conversion returns a Report listing the fields which have no equivalent on the other side. Marshal and
+// Unmarshal check documents structurally; a SchemaSet loaded with the published XSDs also validates them against
+// their schema
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+var messageTypes = map[string]MessageType{
+	accountOpeningNamespace:      MessageAcmt007,
+	accountClosingNamespace:      MessageAcmt019,
+	verificationRequestNamespace: MessageAcmt023,
+	verificationReportNamespace:  MessageAcmt024,
+}
+
+// Marshal checks the structure of a document, see ValidateStructure, and encodes it as indented XML with an XML
+// declaration
+func Marshal(document interface{}) ([]byte, error) {
+
+	if err := ValidateStructure(document); err != nil {
+		return nil, err
+	}
+
+	body, err := xml.MarshalIndent(document, "", "  ")
+
+	if err != nil {
+		return nil, validationError(err.Error())
+	}
+
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// Detect returns the message type of a document from the namespace of its root element
+func Detect(data []byte) (MessageType, error) {
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			return "", validationError("no root element")
+		}
+
+		if err != nil {
+			return "", validationError(err.Error())
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+
+			if start.Name.Local != "Document" {
+				return "", validationError(fmt.Sprintf("root element %s, expected Document", start.Name.Local))
+			}
+
+			messageType, ok := messageTypes[start.Name.Space]
+
+			if !ok {
+				return "", validationError(fmt.Sprintf("unsupported namespace %q", start.Name.Space))
+			}
+
+			return messageType, nil
+		}
+	}
+}
+
+// Unmarshal detects the type of a document, decodes it and checks its structure, see ValidateStructure. The
+// returned document is one of *AccountOpeningDocument, *AccountClosingDocument, *VerificationRequestDocument or
+// *VerificationReportDocument
+func Unmarshal(data []byte) (interface{}, MessageType, error) {
+
+	messageType, err := Detect(data)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	var document interface{}
+
+	switch messageType {
+	case MessageAcmt007:
+		document = &AccountOpeningDocument{}
+	case MessageAcmt019:
+		document = &AccountClosingDocument{}
+	case MessageAcmt023:
+		document = &VerificationRequestDocument{}
+	case MessageAcmt024:
+		document = &VerificationReportDocument{}
+	}
+
+	if err := xml.Unmarshal(data, document); err != nil {
+		return nil, messageType, validationError(err.Error())
+	}
+
+	if err := ValidateStructure(document); err != nil {
+		return nil, messageType, err
+	}
+
+	return document, messageType, nil
+}
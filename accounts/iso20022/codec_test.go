@@ -0,0 +1,152 @@
+package iso20022
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateStructure(t *testing.T) {
+
+	cases := map[string]struct {
+		mutate    func(d *AccountOpeningDocument)
+		violation string
+	}{
+		"valid": {
+			mutate: func(d *AccountOpeningDocument) {},
+		},
+		"wrong namespace": {
+			mutate:    func(d *AccountOpeningDocument) { d.Xmlns = accountClosingNamespace },
+			violation: "Document: namespace",
+		},
+		"missing message id": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.References.MessageID.ID = "" },
+			violation: "AcctOpngReq/Refs/MsgId/Id: is required",
+		},
+		"message id too long": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.References.MessageID.ID = strings.Repeat("a", 36) },
+			violation: "AcctOpngReq/Refs/MsgId/Id: is 36 characters long",
+		},
+		"invalid creation date time": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.References.ProcessID.CreationDtTime = "01/06/2022" },
+			violation: "AcctOpngReq/Refs/PrcId/CreDtTm",
+		},
+		"invalid iban": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.Account.ID.IBAN = "GB-NWBK" },
+			violation: "AcctOpngReq/Acct/Id/IBAN",
+		},
+		"missing account identification": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.Account.ID = AccountIdentification{} },
+			violation: "AcctOpngReq/Acct/Id: IBAN or Othr is required",
+		},
+		"invalid currency": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.Account.Currency = "gbp" },
+			violation: "AcctOpngReq/Acct/Ccy",
+		},
+		"invalid bic": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.AccountServicer.FinInstnID.BICFI = "NWBK" },
+			violation: "AcctOpngReq/AcctSvcrId/FinInstnId/BICFI",
+		},
+		"invalid country": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.AccountServicer.FinInstnID.PostalAddress.Country = "GBR" },
+			violation: "AcctOpngReq/AcctSvcrId/FinInstnId/PstlAdr/Ctry",
+		},
+		"name too long": {
+			mutate:    func(d *AccountOpeningDocument) { d.Request.Account.Name = strings.Repeat("a", 71) },
+			violation: "AcctOpngReq/Acct/Nm",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			document, _, err := ToAccountOpening(testAccount())
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			c.mutate(document)
+
+			// Act
+
+			err = ValidateStructure(document)
+
+			// Assert
+
+			if c.violation == "" {
+				if err != nil {
+					t.Errorf("unexpected error: got %v want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, accounts.ValidationError) || !strings.Contains(err.Error(), c.violation) {
+				t.Errorf("unexpected error: got %v want %v", err, c.violation)
+			}
+		})
+	}
+}
+
+func TestMarshalRejectsInvalidDocuments(t *testing.T) {
+
+	// Arrange
+
+	document, _, err := ToAccountClosing(testAccount())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	document.Request.AccountID = AccountIdentification{}
+
+	// Act
+
+	_, err = Marshal(document)
+
+	// Assert
+
+	if !errors.Is(err, accounts.ValidationError) {
+		t.Errorf("unexpected error: got %v want %v", err, accounts.ValidationError)
+	}
+}
+
+func TestDetect(t *testing.T) {
+
+	cases := map[string]struct {
+		document string
+		want     MessageType
+		fails    bool
+	}{
+		"acmt.007":        {document: `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:acmt.007.001.03"/>`, want: MessageAcmt007},
+		"acmt.024":        {document: `<?xml version="1.0"?><Document xmlns="urn:iso:std:iso:20022:tech:xsd:acmt.024.001.03"/>`, want: MessageAcmt024},
+		"other namespace": {document: `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"/>`, fails: true},
+		"other root":      {document: `<AcctOpngReq xmlns="urn:iso:std:iso:20022:tech:xsd:acmt.007.001.03"/>`, fails: true},
+		"not xml":         {document: `{"data": {}}`, fails: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+
+			got, err := Detect([]byte(c.document))
+
+			// Assert
+
+			if c.fails {
+				if !errors.Is(err, accounts.ValidationError) {
+					t.Errorf("unexpected error: got %v want %v", err, accounts.ValidationError)
+				}
+				return
+			}
+
+			if err != nil || got != c.want {
+				t.Errorf("unexpected message type: got %v %v want %v", got, err, c.want)
+			}
+		})
+	}
+}
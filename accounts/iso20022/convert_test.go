@@ -0,0 +1,312 @@
+package iso20022
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testAccountId      = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	testOrganisationId = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+)
+
+func testAccount() *accounts.AccountData {
+	return &accounts.AccountData{Data: &accounts.Data{
+		ID:             testAccountId,
+		OrganisationID: testOrganisationId,
+		Type:           "accounts",
+		Attributes: &accounts.AccountAttributes{
+			AccountClassification: "Personal",
+			BankID:                "400300",
+			BankIDCode:            "GBDSC",
+			BaseCurrency:          "GBP",
+			Bic:                   "NWBKGB22",
+			Country:               "GB",
+			Iban:                  "GB11NWBK40030041426819",
+			Name:                  []string{"Samantha", "Holder"},
+		},
+	}}
+}
+
+func TestAccountOpeningRoundTrip(t *testing.T) {
+
+	// Arrange
+
+	account := testAccount()
+
+	// Act
+
+	document, exportReport, err := ToAccountOpening(account)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	encoded, err := Marshal(document)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	decoded, messageType, err := Unmarshal(encoded)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	imported, importReport, err := FromAccountOpening(decoded.(*AccountOpeningDocument))
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if messageType != MessageAcmt007 {
+		t.Errorf("unexpected message type: got %v want %v", messageType, MessageAcmt007)
+	}
+
+	if !exportReport.Empty() || !importReport.Empty() {
+		t.Errorf("unexpected unmapped fields: got %v and %v", exportReport, importReport)
+	}
+
+	want := account.Data.Attributes
+	want.Name = []string{"Samantha Holder"}
+
+	if !reflect.DeepEqual(imported.Data.Attributes, want) {
+		t.Errorf("unexpected attributes: got %+v want %+v", imported.Data.Attributes, want)
+	}
+
+	if imported.Data.ID != testAccountId || imported.Data.OrganisationID != testOrganisationId {
+		t.Errorf("unexpected identifiers: got %v and %v", imported.Data.ID, imported.Data.OrganisationID)
+	}
+}
+
+func TestAccountOpeningReport(t *testing.T) {
+
+	// Arrange
+
+	account := testAccount()
+	account.Data.Attributes.AccountNumber = "41426819"
+	account.Data.Attributes.AlternativeNames = []string{"Sam Holder"}
+	account.Data.Attributes.AccountMatchingOptOut = true
+
+	// Act
+
+	_, report, err := ToAccountOpening(account)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	want := []string{"attributes.account_number", "attributes.alternative_names", "attributes.account_matching_opt_out"}
+	got := []string{}
+
+	for _, field := range report.Unmapped {
+		got = append(got, field.Field)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected unmapped fields: got %v want %v", got, want)
+	}
+}
+
+func TestFromAccountOpeningReportsUnsupportedElements(t *testing.T) {
+
+	// Arrange
+
+	encoded := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:acmt.007.001.03">
+  <AcctOpngReq>
+    <Refs>
+      <MsgId><Id>MSG1</Id><CreDtTm>2022-06-01T10:00:00</CreDtTm></MsgId>
+      <PrcId><Id>ad27e26596054b4ba0e53003ea9cc4dc</Id><CreDtTm>2022-06-01T10:00:00</CreDtTm></PrcId>
+    </Refs>
+    <Acct>
+      <Id><Othr><Id>41426819</Id></Othr></Id>
+      <Nm>Samantha Holder</Nm>
+      <Ccy>GBP</Ccy>
+      <MnthlyPmtVal>100</MnthlyPmtVal>
+    </Acct>
+    <AcctSvcrId><FinInstnId><BICFI>NWBKGB22</BICFI></FinInstnId></AcctSvcrId>
+    <RltdPties><Nm>Other Holder</Nm></RltdPties>
+  </AcctOpngReq>
+</Document>`
+
+	// Act
+
+	decoded, _, err := Unmarshal([]byte(encoded))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	imported, report, err := FromAccountOpening(decoded.(*AccountOpeningDocument))
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if imported.Data.ID != testAccountId || imported.Data.Attributes.AccountNumber != "41426819" {
+		t.Errorf("unexpected account: got %v and %v", imported.Data.ID, imported.Data.Attributes.AccountNumber)
+	}
+
+	for _, field := range []string{"AcctOpngReq/RltdPties", "AcctOpngReq/Acct/MnthlyPmtVal"} {
+		if !strings.Contains(report.String(), field) {
+			t.Errorf("unexpected report: got %v want it to contain %v", report, field)
+		}
+	}
+}
+
+func TestAccountClosingRoundTrip(t *testing.T) {
+
+	// Arrange
+
+	account := testAccount()
+	account.Data.Version = 2
+
+	// Act
+
+	document, exportReport, err := ToAccountClosing(account)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	encoded, err := Marshal(document)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	decoded, _, err := Unmarshal(encoded)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	imported, _, err := FromAccountClosing(decoded.(*AccountClosingDocument))
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(exportReport.Unmapped) != 1 || exportReport.Unmapped[0].Field != "version" {
+		t.Errorf("unexpected unmapped fields: got %v want version", exportReport)
+	}
+
+	if imported.Data.ID != testAccountId || imported.Data.Attributes.Iban != account.Data.Attributes.Iban {
+		t.Errorf("unexpected account: got %v and %v", imported.Data.ID, imported.Data.Attributes.Iban)
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+
+	cases := map[string]struct {
+		name     string
+		found    bool
+		verified bool
+		reason   string
+	}{
+		"matching name":        {name: "Samantha Holder", found: true, verified: true},
+		"name case and spaces": {name: " samantha  HOLDER ", found: true, verified: true},
+		"other name":           {name: "Sam Other", found: true, reason: ReasonInconsistentName},
+		"unknown account":      {name: "Samantha Holder", reason: ReasonIncorrectAccountNumber},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			account := testAccount()
+
+			request, _, err := ToVerificationRequest(account)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			request.Request.Verifications[0].PartyAndAccount.Party.Name = c.name
+
+			var resolved *accounts.AccountAttributes
+
+			resolve := func(identification *accounts.AccountAttributes) (*accounts.Data, error) {
+
+				resolved = identification
+
+				if !c.found {
+					return nil, nil
+				}
+
+				return account.Data, nil
+			}
+
+			// Act
+
+			answer, _, err := VerifyRequest(request, resolve)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			encoded, err := Marshal(answer)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			decoded, _, err := Unmarshal(encoded)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			results := FromVerificationReport(decoded.(*VerificationReportDocument))
+
+			// Assert
+
+			if resolved.Iban != account.Data.Attributes.Iban || resolved.BankID != account.Data.Attributes.BankID {
+				t.Errorf("unexpected identification: got %+v", resolved)
+			}
+
+			if len(results) != 1 {
+				t.Fatalf("unexpected results: got %v want 1", len(results))
+			}
+
+			if results[0].VerificationID != request.Request.Verifications[0].ID {
+				t.Errorf("unexpected verification id: got %v want %v", results[0].VerificationID, request.Request.Verifications[0].ID)
+			}
+
+			if results[0].Verified != c.verified || results[0].Reason != c.reason {
+				t.Errorf("unexpected result: got %v %v want %v %v", results[0].Verified, results[0].Reason, c.verified, c.reason)
+			}
+
+			if c.reason == ReasonInconsistentName && !reflect.DeepEqual(results[0].Account.Name, []string{"Samantha Holder"}) {
+				t.Errorf("unexpected updated name: got %v want Samantha Holder", results[0].Account.Name)
+			}
+		})
+	}
+}
+
+func TestConvertersRequireAttributes(t *testing.T) {
+
+	// Act
+
+	_, _, err := ToAccountOpening(&accounts.AccountData{Data: &accounts.Data{ID: testAccountId}})
+
+	// Assert
+
+	if !errors.Is(err, accounts.ValidationError) {
+		t.Errorf("unexpected error: got %v want %v", err, accounts.ValidationError)
+	}
+}
@@ -0,0 +1,62 @@
+package iso20022
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// UnmappedField is a field which has no equivalent on the other side of a conversion
+type UnmappedField struct {
+	Field  string
+	Reason string
+}
+
+// Report lists the fields dropped by a conversion
+type Report struct {
+	Unmapped []UnmappedField
+}
+
+// Empty reports whether every field was mapped
+func (r *Report) Empty() bool {
+	return len(r.Unmapped) == 0
+}
+
+// String lists the unmapped fields, one per line
+func (r *Report) String() string {
+
+	var b strings.Builder
+
+	for _, field := range r.Unmapped {
+		fmt.Fprintf(&b, "%s: %s\n", field.Field, field.Reason)
+	}
+
+	return b.String()
+}
+
+func (r *Report) add(field string, reason string) {
+	r.Unmapped = append(r.Unmapped, UnmappedField{Field: field, Reason: reason})
+}
+
+// addUnsupported reports the elements captured under path because the converters do not know them
+func (r *Report) addUnsupported(path string, elements []AnyElement) {
+	for _, element := range elements {
+		r.add(path+"/"+element.XMLName.Local, "element not supported")
+	}
+}
+
+// compactID drops the dashes of a uuid so it fits the Max35Text identifiers
+func compactID(id string) string {
+	return strings.ReplaceAll(id, "-", "")
+}
+
+// expandID restores a uuid compacted by compactID, leaving other identifiers untouched
+func expandID(id string) string {
+
+	if parsed, err := uuid.Parse(id); err == nil {
+		return parsed.String()
+	}
+
+	return id
+}
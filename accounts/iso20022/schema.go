@@ -0,0 +1,1371 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidSchema is returned when an XSD cannot be loaded
+var ErrInvalidSchema = errors.New("Invalid XML schema")
+
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+
+// unbounded is the maxOccurs of a particle which may repeat without limit
+const unbounded = -1
+
+// Schema is an XSD loaded with LoadSchema. It supports the subset of XML Schema the ISO 20022 message schemas are
+// written in: named and anonymous types, sequences and choices with occurrence bounds, wildcards, simple content
+// with attributes, and the string, numeric, boolean, date and binary built-in types with their facets. A schema
+// using another construct, e.g. xs:include or xs:union, fails to load rather than being partially checked
+type Schema struct {
+	// Namespace is the target namespace of the schema, e.g. "urn:iso:std:iso:20022:tech:xsd:acmt.007.001.03"
+	Namespace string
+
+	qualified bool
+	elements  map[string]*xsdElement
+
+	raw       *rawSchema
+	prefixes  map[string]string
+	simple    map[string]*xsdSimpleType
+	complex   map[string]*xsdComplexType
+	resolving map[string]bool
+}
+
+// LoadSchema reads an XSD, such as one of the acmt schemas published on iso20022.org, failing with
+// ErrInvalidSchema when it is malformed or uses constructs Schema does not support
+func LoadSchema(r io.Reader) (*Schema, error) {
+
+	raw := &rawSchema{}
+
+	if err := xml.NewDecoder(r).Decode(raw); err != nil {
+		return nil, schemaError("%s", err)
+	}
+
+	if raw.XMLName.Space != xsdNamespace || raw.XMLName.Local != "schema" {
+		return nil, schemaError("root element %s, expected xs:schema", raw.XMLName.Local)
+	}
+
+	if len(raw.Includes) > 0 || len(raw.Imports) > 0 || len(raw.Groups) > 0 {
+		return nil, schemaError("xs:include, xs:import and xs:group are not supported")
+	}
+
+	s := &Schema{
+		Namespace: raw.TargetNamespace,
+		qualified: raw.ElementFormDefault == "qualified",
+		elements:  map[string]*xsdElement{},
+		raw:       raw,
+		prefixes:  map[string]string{},
+		simple:    map[string]*xsdSimpleType{},
+		complex:   map[string]*xsdComplexType{},
+		resolving: map[string]bool{},
+	}
+
+	for _, attr := range raw.Attrs {
+		switch {
+		case attr.Name.Space == "xmlns":
+			s.prefixes[attr.Name.Local] = attr.Value
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			s.prefixes[""] = attr.Value
+		}
+	}
+
+	for i := range raw.Elements {
+
+		element, err := s.element(&raw.Elements[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.elements[element.name] = element
+	}
+
+	for _, complexType := range raw.ComplexTypes {
+		if _, err := s.namedType(complexType.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, simpleType := range raw.SimpleTypes {
+		if _, err := s.namedType(simpleType.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	s.raw, s.resolving = nil, nil
+
+	return s, nil
+}
+
+// Validate checks the XML of a document against the schema. Every violation is listed in the returned
+// ValidationError
+func (s *Schema) Validate(data []byte) error {
+
+	root, err := parseNode(data)
+
+	if err != nil {
+		return validationError(err.Error())
+	}
+
+	element, ok := s.elements[root.name.Local]
+
+	if !ok || root.name.Space != s.Namespace {
+		return validationError(fmt.Sprintf("root element {%s}%s is not declared by the schema", root.name.Space, root.name.Local))
+	}
+
+	v := &validator{}
+	s.validateElement(v, root.name.Local, root, element)
+
+	return v.err()
+}
+
+// SchemaSet validates acmt documents against the XSD of their namespace. Marshal and Unmarshal check documents
+// structurally only, see ValidateStructure; the methods of a SchemaSet also validate the XML against the schemas
+type SchemaSet struct {
+	schemas map[string]*Schema
+}
+
+// NewSchemaSet constructs a SchemaSet of the given schemas, keyed by their namespace
+func NewSchemaSet(schemas ...*Schema) *SchemaSet {
+
+	set := &SchemaSet{schemas: map[string]*Schema{}}
+
+	for _, schema := range schemas {
+		set.schemas[schema.Namespace] = schema
+	}
+
+	return set
+}
+
+// LoadSchemaDir loads every .xsd file of dir, e.g. the acmt.007.001.03, acmt.019.001.03, acmt.023.001.03 and
+// acmt.024.001.03 schemas downloaded from iso20022.org
+func LoadSchemaDir(dir string) (*SchemaSet, error) {
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.xsd"))
+
+	if err != nil {
+		return nil, schemaError("%s", err)
+	}
+
+	if len(paths) == 0 {
+		return nil, schemaError("no .xsd file in %s", dir)
+	}
+
+	schemas := make([]*Schema, 0, len(paths))
+
+	for _, path := range paths {
+
+		schema, err := loadSchemaFile(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	return NewSchemaSet(schemas...), nil
+}
+
+func loadSchemaFile(path string) (*Schema, error) {
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, schemaError("%s", err)
+	}
+
+	defer file.Close()
+
+	schema, err := LoadSchema(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w (%s)", err, filepath.Base(path))
+	}
+
+	return schema, nil
+}
+
+// Validate checks the XML of a document against the schema of its namespace, failing with a ValidationError when
+// no schema of the set has that namespace
+func (s *SchemaSet) Validate(data []byte) error {
+
+	root, err := parseNode(data)
+
+	if err != nil {
+		return validationError(err.Error())
+	}
+
+	schema, ok := s.schemas[root.name.Space]
+
+	if !ok {
+		return validationError(fmt.Sprintf("no schema loaded for namespace %q", root.name.Space))
+	}
+
+	return schema.Validate(data)
+}
+
+// Marshal encodes a document like Marshal, then validates the XML against its schema
+func (s *SchemaSet) Marshal(document interface{}) ([]byte, error) {
+
+	encoded, err := Marshal(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate(encoded); err != nil {
+		return nil, err
+	}
+
+	return encoded, nil
+}
+
+// Unmarshal validates the XML of a document against its schema, then decodes it like Unmarshal
+func (s *SchemaSet) Unmarshal(data []byte) (interface{}, MessageType, error) {
+
+	if err := s.Validate(data); err != nil {
+		messageType, _ := Detect(data)
+		return nil, messageType, err
+	}
+
+	return Unmarshal(data)
+}
+
+func schemaError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSchema, fmt.Sprintf(format, args...))
+}
+
+// rawSchema and the raw types below are the XSD as decoded, before its type references are resolved
+type rawSchema struct {
+	XMLName            xml.Name
+	Attrs              []xml.Attr       `xml:",any,attr"`
+	TargetNamespace    string           `xml:"targetNamespace,attr"`
+	ElementFormDefault string           `xml:"elementFormDefault,attr"`
+	Elements           []rawParticle    `xml:"element"`
+	ComplexTypes       []rawComplexType `xml:"complexType"`
+	SimpleTypes        []rawSimpleType  `xml:"simpleType"`
+	Includes           []xml.Name       `xml:"include"`
+	Imports            []xml.Name       `xml:"import"`
+	Groups             []xml.Name       `xml:"group"`
+}
+
+// rawParticle is an xs:element, xs:sequence, xs:choice or xs:any. Its Items keep the document order of a group
+type rawParticle struct {
+	XMLName     xml.Name
+	Name        string          `xml:"name,attr"`
+	Type        string          `xml:"type,attr"`
+	Ref         string          `xml:"ref,attr"`
+	MinOccurs   string          `xml:"minOccurs,attr"`
+	MaxOccurs   string          `xml:"maxOccurs,attr"`
+	Namespace   string          `xml:"namespace,attr"`
+	ComplexType *rawComplexType `xml:"complexType"`
+	SimpleType  *rawSimpleType  `xml:"simpleType"`
+	Items       []rawParticle   `xml:",any"`
+}
+
+type rawComplexType struct {
+	Name          string          `xml:"name,attr"`
+	Mixed         string          `xml:"mixed,attr"`
+	Sequence      *rawParticle    `xml:"sequence"`
+	Choice        *rawParticle    `xml:"choice"`
+	All           *rawParticle    `xml:"all"`
+	ComplexCont   *rawParticle    `xml:"complexContent"`
+	SimpleContent *rawContent     `xml:"simpleContent"`
+	Attributes    []rawAttribute  `xml:"attribute"`
+	AttributeRefs []rawAttributes `xml:"attributeGroup"`
+}
+
+type rawContent struct {
+	Extension   *rawExtension `xml:"extension"`
+	Restriction *rawExtension `xml:"restriction"`
+}
+
+type rawExtension struct {
+	Base       string         `xml:"base,attr"`
+	Attributes []rawAttribute `xml:"attribute"`
+}
+
+type rawAttributes struct {
+	Ref string `xml:"ref,attr"`
+}
+
+type rawAttribute struct {
+	Name       string         `xml:"name,attr"`
+	Type       string         `xml:"type,attr"`
+	Use        string         `xml:"use,attr"`
+	SimpleType *rawSimpleType `xml:"simpleType"`
+}
+
+type rawSimpleType struct {
+	Name        string          `xml:"name,attr"`
+	Restriction *rawRestriction `xml:"restriction"`
+	List        *xml.Name       `xml:"list"`
+	Union       *xml.Name       `xml:"union"`
+}
+
+type rawRestriction struct {
+	Base       string         `xml:"base,attr"`
+	SimpleType *rawSimpleType `xml:"simpleType"`
+	Facets     []rawFacet     `xml:",any"`
+}
+
+type rawFacet struct {
+	XMLName xml.Name
+	Value   string `xml:"value,attr"`
+}
+
+// xsdElement is a resolved element declaration, of either a simple or a complex type
+type xsdElement struct {
+	name        string
+	simpleType  *xsdSimpleType
+	complexType *xsdComplexType
+}
+
+type xsdComplexType struct {
+	content    *xsdParticle
+	simple     *xsdSimpleType
+	attributes []xsdAttribute
+}
+
+type xsdAttribute struct {
+	name       string
+	required   bool
+	simpleType *xsdSimpleType
+}
+
+// Kinds of particles
+const (
+	particleElement  = "element"
+	particleSequence = "sequence"
+	particleChoice   = "choice"
+	particleAny      = "any"
+)
+
+type xsdParticle struct {
+	kind      string
+	min       int
+	max       int
+	element   *xsdElement
+	namespace string
+	items     []*xsdParticle
+}
+
+// xsdSimpleType is a built-in type narrowed by facets. Each step of a derivation adds its patterns, of which one
+// must match, while the other facets of the most derived step apply
+type xsdSimpleType struct {
+	builtin        string
+	patterns       [][]*regexp.Regexp
+	enumeration    []string
+	length         *int
+	minLength      *int
+	maxLength      *int
+	totalDigits    *int
+	fractionDigits *int
+	minInclusive   *big.Rat
+	maxInclusive   *big.Rat
+	minExclusive   *big.Rat
+	maxExclusive   *big.Rat
+}
+
+// builtinChecks holds the lexical checks of the supported built-in types
+var builtinChecks = map[string]func(string) bool{
+	"anySimpleType":      func(string) bool { return true },
+	"string":             func(string) bool { return true },
+	"normalizedString":   func(string) bool { return true },
+	"token":              func(string) bool { return true },
+	"anyURI":             func(string) bool { return true },
+	"ID":                 func(string) bool { return true },
+	"IDREF":              func(string) bool { return true },
+	"boolean":            func(v string) bool { return v == "true" || v == "false" || v == "1" || v == "0" },
+	"decimal":            regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`).MatchString,
+	"integer":            regexp.MustCompile(`^[+-]?[0-9]+$`).MatchString,
+	"int":                regexp.MustCompile(`^[+-]?[0-9]+$`).MatchString,
+	"long":               regexp.MustCompile(`^[+-]?[0-9]+$`).MatchString,
+	"nonNegativeInteger": regexp.MustCompile(`^\+?[0-9]+$`).MatchString,
+	"positiveInteger":    regexp.MustCompile(`^\+?0*[1-9][0-9]*$`).MatchString,
+	"base64Binary":       func(v string) bool { _, err := decodeBase64(v); return err == nil },
+	"date":               timeCheck("2006-01-02", "2006-01-02Z07:00"),
+	"dateTime":           timeCheck("2006-01-02T15:04:05.999999999", "2006-01-02T15:04:05.999999999Z07:00"),
+	"time":               timeCheck("15:04:05.999999999", "15:04:05.999999999Z07:00"),
+	"gYear":              regexp.MustCompile(`^-?[0-9]{4,}(Z|[+-][0-9]{2}:[0-9]{2})?$`).MatchString,
+	"gYearMonth":         regexp.MustCompile(`^-?[0-9]{4,}-(0[1-9]|1[0-2])(Z|[+-][0-9]{2}:[0-9]{2})?$`).MatchString,
+	"gMonthDay":          regexp.MustCompile(`^--(0[1-9]|1[0-2])-(0[1-9]|[12][0-9]|3[01])(Z|[+-][0-9]{2}:[0-9]{2})?$`).MatchString,
+}
+
+// numericBuiltins are the built-in types accepting the digits and bounds facets
+var numericBuiltins = map[string]bool{
+	"decimal": true, "integer": true, "int": true, "long": true, "nonNegativeInteger": true, "positiveInteger": true,
+}
+
+func timeCheck(layouts ...string) func(string) bool {
+	return func(value string) bool {
+		for _, layout := range layouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func decodeBase64(value string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+}
+
+// resolve splits a QName into the namespace bound to its prefix and its local name
+func (s *Schema) resolve(qname string) (string, string) {
+
+	prefix, local := "", qname
+
+	if i := strings.IndexByte(qname, ':'); i >= 0 {
+		prefix, local = qname[:i], qname[i+1:]
+	}
+
+	return s.prefixes[prefix], local
+}
+
+func (s *Schema) element(raw *rawParticle) (*xsdElement, error) {
+
+	if raw.Ref != "" {
+		return nil, schemaError("element references are not supported: %s", raw.Ref)
+	}
+
+	element := &xsdElement{name: raw.Name}
+
+	switch {
+	case raw.ComplexType != nil:
+		complexType, err := s.complexType(raw.ComplexType)
+		if err != nil {
+			return nil, err
+		}
+		element.complexType = complexType
+
+	case raw.SimpleType != nil:
+		simpleType, err := s.simpleType(raw.SimpleType)
+		if err != nil {
+			return nil, err
+		}
+		element.simpleType = simpleType
+
+	case raw.Type != "":
+		resolved, err := s.typeRef(raw.Type)
+		if err != nil {
+			return nil, err
+		}
+		element.simpleType, element.complexType = resolved.simpleType, resolved.complexType
+
+	default:
+		return nil, schemaError("element %s has no type", raw.Name)
+	}
+
+	return element, nil
+}
+
+// typeRef resolves a type reference into a built-in or named type of the schema
+func (s *Schema) typeRef(qname string) (*xsdElement, error) {
+
+	namespace, local := s.resolve(qname)
+
+	if namespace == xsdNamespace {
+
+		if _, ok := builtinChecks[local]; !ok {
+			return nil, schemaError("unsupported built-in type xs:%s", local)
+		}
+
+		return &xsdElement{simpleType: &xsdSimpleType{builtin: local}}, nil
+	}
+
+	if namespace != s.raw.TargetNamespace {
+		return nil, schemaError("type %s is outside the target namespace", qname)
+	}
+
+	return s.namedType(local)
+}
+
+// namedType resolves the named type of the schema, compiling it once. Complex types may be recursive
+func (s *Schema) namedType(name string) (*xsdElement, error) {
+
+	if simpleType, ok := s.simple[name]; ok {
+		return &xsdElement{simpleType: simpleType}, nil
+	}
+
+	if complexType, ok := s.complex[name]; ok {
+		return &xsdElement{complexType: complexType}, nil
+	}
+
+	for i := range s.raw.ComplexTypes {
+
+		raw := &s.raw.ComplexTypes[i]
+
+		if raw.Name != name {
+			continue
+		}
+
+		complexType := &xsdComplexType{}
+		s.complex[name] = complexType
+
+		compiled, err := s.complexType(raw)
+
+		if err != nil {
+			return nil, err
+		}
+
+		*complexType = *compiled
+
+		return &xsdElement{complexType: complexType}, nil
+	}
+
+	for i := range s.raw.SimpleTypes {
+
+		raw := &s.raw.SimpleTypes[i]
+
+		if raw.Name != name {
+			continue
+		}
+
+		if s.resolving[name] {
+			return nil, schemaError("simple type %s derives from itself", name)
+		}
+
+		s.resolving[name] = true
+
+		simpleType, err := s.simpleType(raw)
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.simple[name] = simpleType
+
+		return &xsdElement{simpleType: simpleType}, nil
+	}
+
+	return nil, schemaError("type %s is not declared", name)
+}
+
+func (s *Schema) complexType(raw *rawComplexType) (*xsdComplexType, error) {
+
+	if raw.All != nil || raw.ComplexCont != nil || len(raw.AttributeRefs) > 0 || raw.Mixed == "true" {
+		return nil, schemaError("complex type %s uses unsupported content", raw.Name)
+	}
+
+	complexType := &xsdComplexType{}
+
+	attributes := raw.Attributes
+
+	switch {
+	case raw.SimpleContent != nil:
+
+		extension := raw.SimpleContent.Extension
+
+		if extension == nil {
+			return nil, schemaError("complex type %s restricts simple content, which is not supported", raw.Name)
+		}
+
+		base, err := s.typeRef(extension.Base)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if base.simpleType == nil {
+			return nil, schemaError("complex type %s extends complex type %s", raw.Name, extension.Base)
+		}
+
+		complexType.simple = base.simpleType
+		attributes = append(append([]rawAttribute(nil), attributes...), extension.Attributes...)
+
+	case raw.Sequence != nil && raw.Choice != nil:
+		return nil, schemaError("complex type %s has both a sequence and a choice", raw.Name)
+
+	case raw.Sequence != nil:
+		content, err := s.particle(raw.Sequence)
+		if err != nil {
+			return nil, err
+		}
+		complexType.content = content
+
+	case raw.Choice != nil:
+		content, err := s.particle(raw.Choice)
+		if err != nil {
+			return nil, err
+		}
+		complexType.content = content
+	}
+
+	for i := range attributes {
+
+		attribute, err := s.attribute(&attributes[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		complexType.attributes = append(complexType.attributes, attribute)
+	}
+
+	return complexType, nil
+}
+
+func (s *Schema) attribute(raw *rawAttribute) (xsdAttribute, error) {
+
+	attribute := xsdAttribute{name: raw.Name, required: raw.Use == "required"}
+
+	switch {
+	case raw.SimpleType != nil:
+		simpleType, err := s.simpleType(raw.SimpleType)
+		if err != nil {
+			return attribute, err
+		}
+		attribute.simpleType = simpleType
+
+	case raw.Type != "":
+		resolved, err := s.typeRef(raw.Type)
+		if err != nil {
+			return attribute, err
+		}
+		if resolved.simpleType == nil {
+			return attribute, schemaError("attribute %s has complex type %s", raw.Name, raw.Type)
+		}
+		attribute.simpleType = resolved.simpleType
+
+	default:
+		attribute.simpleType = &xsdSimpleType{builtin: "anySimpleType"}
+	}
+
+	return attribute, nil
+}
+
+func (s *Schema) particle(raw *rawParticle) (*xsdParticle, error) {
+
+	min, max, err := occurrences(raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	particle := &xsdParticle{kind: raw.XMLName.Local, min: min, max: max}
+
+	if raw.XMLName.Space != xsdNamespace {
+		return nil, schemaError("unexpected element %s in a model group", raw.XMLName.Local)
+	}
+
+	switch particle.kind {
+	case particleElement:
+		element, err := s.element(raw)
+		if err != nil {
+			return nil, err
+		}
+		particle.element = element
+
+	case particleAny:
+		particle.namespace = raw.Namespace
+
+		if particle.namespace == "" {
+			particle.namespace = "##any"
+		}
+
+	case particleSequence, particleChoice:
+		for i := range raw.Items {
+
+			if raw.Items[i].XMLName.Local == "annotation" {
+				continue
+			}
+
+			item, err := s.particle(&raw.Items[i])
+
+			if err != nil {
+				return nil, err
+			}
+
+			particle.items = append(particle.items, item)
+		}
+
+	default:
+		return nil, schemaError("xs:%s is not supported in a model group", particle.kind)
+	}
+
+	return particle, nil
+}
+
+func occurrences(raw *rawParticle) (int, int, error) {
+
+	min, max := 1, 1
+
+	if raw.MinOccurs != "" {
+
+		value, err := strconv.Atoi(raw.MinOccurs)
+
+		if err != nil || value < 0 {
+			return 0, 0, schemaError("invalid minOccurs %q", raw.MinOccurs)
+		}
+
+		min = value
+	}
+
+	switch raw.MaxOccurs {
+	case "":
+	case "unbounded":
+		max = unbounded
+	default:
+
+		value, err := strconv.Atoi(raw.MaxOccurs)
+
+		if err != nil || value < min {
+			return 0, 0, schemaError("invalid maxOccurs %q", raw.MaxOccurs)
+		}
+
+		max = value
+	}
+
+	return min, max, nil
+}
+
+func (s *Schema) simpleType(raw *rawSimpleType) (*xsdSimpleType, error) {
+
+	if raw.List != nil || raw.Union != nil || raw.Restriction == nil {
+		return nil, schemaError("simple type %s is not a restriction, which is the only derivation supported", raw.Name)
+	}
+
+	restriction := raw.Restriction
+
+	var base *xsdSimpleType
+
+	switch {
+	case restriction.SimpleType != nil:
+		anonymous, err := s.simpleType(restriction.SimpleType)
+		if err != nil {
+			return nil, err
+		}
+		base = anonymous
+
+	default:
+		resolved, err := s.typeRef(restriction.Base)
+		if err != nil {
+			return nil, err
+		}
+		if resolved.simpleType == nil {
+			return nil, schemaError("simple type %s restricts complex type %s", raw.Name, restriction.Base)
+		}
+		base = resolved.simpleType
+	}
+
+	simpleType := *base
+	simpleType.patterns = append([][]*regexp.Regexp(nil), base.patterns...)
+
+	var patterns []*regexp.Regexp
+	var enumeration []string
+
+	for _, facet := range restriction.Facets {
+
+		if err := simpleType.facet(facet, &patterns, &enumeration); err != nil {
+			return nil, schemaError("simple type %s: %s", raw.Name, err)
+		}
+	}
+
+	if len(patterns) > 0 {
+		simpleType.patterns = append(simpleType.patterns, patterns)
+	}
+
+	if enumeration != nil {
+		simpleType.enumeration = enumeration
+	}
+
+	return &simpleType, nil
+}
+
+// facet applies a restriction facet, collecting the patterns and enumerated values of the step
+func (t *xsdSimpleType) facet(facet rawFacet, patterns *[]*regexp.Regexp, enumeration *[]string) error {
+
+	value := facet.Value
+
+	switch facet.XMLName.Local {
+	case "annotation", "whiteSpace":
+		return nil
+
+	case "pattern":
+		pattern, err := regexp.Compile(`^(?:` + value + `)$`)
+		if err != nil {
+			return fmt.Errorf("unsupported pattern %q: %s", value, err)
+		}
+		*patterns = append(*patterns, pattern)
+		return nil
+
+	case "enumeration":
+		*enumeration = append(*enumeration, value)
+		return nil
+
+	case "length":
+		return intFacet(value, &t.length)
+	case "minLength":
+		return intFacet(value, &t.minLength)
+	case "maxLength":
+		return intFacet(value, &t.maxLength)
+	}
+
+	if !numericBuiltins[t.builtin] {
+		return fmt.Errorf("facet %s on non numeric type %s is not supported", facet.XMLName.Local, t.builtin)
+	}
+
+	switch facet.XMLName.Local {
+	case "totalDigits":
+		return intFacet(value, &t.totalDigits)
+	case "fractionDigits":
+		return intFacet(value, &t.fractionDigits)
+	case "minInclusive":
+		return ratFacet(value, &t.minInclusive)
+	case "maxInclusive":
+		return ratFacet(value, &t.maxInclusive)
+	case "minExclusive":
+		return ratFacet(value, &t.minExclusive)
+	case "maxExclusive":
+		return ratFacet(value, &t.maxExclusive)
+	}
+
+	return fmt.Errorf("facet %s is not supported", facet.XMLName.Local)
+}
+
+func intFacet(value string, target **int) error {
+
+	n, err := strconv.Atoi(value)
+
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid facet value %q", value)
+	}
+
+	*target = &n
+
+	return nil
+}
+
+func ratFacet(value string, target **big.Rat) error {
+
+	r, ok := new(big.Rat).SetString(value)
+
+	if !ok {
+		return fmt.Errorf("invalid facet value %q", value)
+	}
+
+	*target = r
+
+	return nil
+}
+
+// node is an element of the document being validated
+type node struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*node
+	text     strings.Builder
+}
+
+func parseNode(data []byte) (*node, error) {
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var root *node
+	var stack []*node
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+
+			n := &node{name: t.Name, attrs: t.Attr}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+
+			stack = append(stack, n)
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+
+	return root, nil
+}
+
+func (s *Schema) validateElement(v *validator, path string, n *node, element *xsdElement) {
+
+	if element.simpleType != nil {
+
+		if len(n.children) > 0 {
+			v.fail(path, "must not have child elements")
+		}
+
+		s.validateAttributes(v, path, n, nil)
+		validateValue(v, path, n.text.String(), element.simpleType)
+
+		return
+	}
+
+	complexType := element.complexType
+
+	s.validateAttributes(v, path, n, complexType.attributes)
+
+	if complexType.simple != nil {
+
+		if len(n.children) > 0 {
+			v.fail(path, "must not have child elements")
+		}
+
+		validateValue(v, path, n.text.String(), complexType.simple)
+
+		return
+	}
+
+	if strings.TrimSpace(n.text.String()) != "" {
+		v.fail(path, "must not have text content")
+	}
+
+	if complexType.content == nil {
+		if len(n.children) > 0 {
+			v.fail(path, "must be empty")
+		}
+		return
+	}
+
+	m := &matcher{schema: s, children: n.children, expected: map[int][]string{}}
+
+	if _, ok := m.ends(complexType.content, 0)[len(n.children)]; !ok {
+		v.fail(path, m.describe())
+	}
+
+	counts := map[string]int{}
+
+	for _, child := range n.children {
+		counts[child.name.Local]++
+	}
+
+	seen := map[string]int{}
+
+	for _, child := range n.children {
+
+		childPath := path + "/" + child.name.Local
+
+		if counts[child.name.Local] > 1 {
+			childPath = fmt.Sprintf("%s[%d]", childPath, seen[child.name.Local])
+		}
+
+		seen[child.name.Local]++
+
+		if declaration := findElement(complexType.content, child.name.Local); declaration != nil && s.matchesNamespace(child) {
+			s.validateElement(v, childPath, child, declaration)
+		}
+	}
+}
+
+func (s *Schema) validateAttributes(v *validator, path string, n *node, attributes []xsdAttribute) {
+
+	present := map[string]string{}
+
+	for _, attr := range n.attrs {
+
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") ||
+			attr.Name.Space == "http://www.w3.org/2001/XMLSchema-instance" {
+			continue
+		}
+
+		present[attr.Name.Local] = attr.Value
+	}
+
+	for _, attribute := range attributes {
+
+		value, ok := present[attribute.name]
+
+		if !ok {
+			if attribute.required {
+				v.fail(path+"/@"+attribute.name, "is required")
+			}
+			continue
+		}
+
+		delete(present, attribute.name)
+		validateValue(v, path+"/@"+attribute.name, value, attribute.simpleType)
+	}
+
+	names := make([]string, 0, len(present))
+
+	for name := range present {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		v.fail(path+"/@"+name, "is not declared")
+	}
+}
+
+// matchesNamespace checks the namespace of a local element against the elementFormDefault of the schema
+func (s *Schema) matchesNamespace(n *node) bool {
+
+	if s.qualified {
+		return n.name.Space == s.Namespace
+	}
+
+	return n.name.Space == ""
+}
+
+// findElement returns the first declaration of the content model with the given name
+func findElement(particle *xsdParticle, name string) *xsdElement {
+
+	if particle.kind == particleElement {
+		if particle.element.name == name {
+			return particle.element
+		}
+		return nil
+	}
+
+	for _, item := range particle.items {
+		if element := findElement(item, name); element != nil {
+			return element
+		}
+	}
+
+	return nil
+}
+
+// matcher matches the children of an element against its content model, computing every position a particle
+// can end at from a starting position. It records how far the children matched, and which elements were
+// expected there, to describe a mismatch
+type matcher struct {
+	schema   *Schema
+	children []*node
+	furthest int
+	expected map[int][]string
+}
+
+type positions map[int]struct{}
+
+func (m *matcher) ends(particle *xsdParticle, start int) positions {
+
+	result := positions{}
+	current := positions{start: {}}
+
+	if particle.min == 0 {
+		result[start] = struct{}{}
+	}
+
+	for count := 1; particle.max == unbounded || count <= particle.max; count++ {
+
+		next := positions{}
+
+		for position := range current {
+			for end := range m.once(particle, position) {
+				next[end] = struct{}{}
+			}
+		}
+
+		progressed := false
+
+		for end := range next {
+
+			if count >= particle.min {
+				if _, ok := result[end]; !ok {
+					progressed = true
+				}
+				result[end] = struct{}{}
+			} else if _, ok := current[end]; !ok {
+				progressed = true
+			}
+		}
+
+		if !progressed {
+			break
+		}
+
+		current = next
+	}
+
+	return result
+}
+
+// once returns the positions a single occurrence of particle can end at
+func (m *matcher) once(particle *xsdParticle, start int) positions {
+
+	switch particle.kind {
+	case particleElement, particleAny:
+
+		if start < len(m.children) && m.matches(particle, m.children[start]) {
+
+			if start+1 > m.furthest {
+				m.furthest = start + 1
+			}
+
+			return positions{start + 1: {}}
+		}
+
+		m.expected[start] = append(m.expected[start], particle.describe())
+
+		return positions{}
+
+	case particleSequence:
+
+		current := positions{start: {}}
+
+		for _, item := range particle.items {
+
+			next := positions{}
+
+			for position := range current {
+				for end := range m.ends(item, position) {
+					next[end] = struct{}{}
+				}
+			}
+
+			current = next
+		}
+
+		return current
+
+	default:
+
+		result := positions{}
+
+		for _, item := range particle.items {
+			for end := range m.ends(item, start) {
+				result[end] = struct{}{}
+			}
+		}
+
+		return result
+	}
+}
+
+func (m *matcher) matches(particle *xsdParticle, n *node) bool {
+
+	if particle.kind == particleElement {
+		return n.name.Local == particle.element.name && m.schema.matchesNamespace(n)
+	}
+
+	switch particle.namespace {
+	case "##any":
+		return true
+	case "##other":
+		return n.name.Space != m.schema.Namespace && n.name.Space != ""
+	case "##local":
+		return n.name.Space == ""
+	case "##targetNamespace":
+		return n.name.Space == m.schema.Namespace
+	}
+
+	for _, namespace := range strings.Fields(particle.namespace) {
+		if namespace == n.name.Space || (namespace == "##targetNamespace" && n.name.Space == m.schema.Namespace) ||
+			(namespace == "##local" && n.name.Space == "") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *xsdParticle) describe() string {
+
+	if p.kind == particleElement {
+		return p.element.name
+	}
+
+	return "any element"
+}
+
+// describe explains why the children do not match the content model
+func (m *matcher) describe() string {
+
+	expected := uniqueSorted(m.expected[m.furthest])
+
+	if m.furthest < len(m.children) {
+
+		unexpected := m.children[m.furthest].name.Local
+
+		if len(expected) == 0 {
+			return fmt.Sprintf("unexpected element %s", unexpected)
+		}
+
+		return fmt.Sprintf("unexpected element %s, expected %s", unexpected, strings.Join(expected, " or "))
+	}
+
+	if len(expected) == 0 {
+		return "is incomplete"
+	}
+
+	return fmt.Sprintf("is incomplete, expected %s", strings.Join(expected, " or "))
+}
+
+func uniqueSorted(values []string) []string {
+
+	set := map[string]bool{}
+	unique := []string{}
+
+	for _, value := range values {
+		if !set[value] {
+			set[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	sort.Strings(unique)
+
+	return unique
+}
+
+// validateValue checks a text value against a simple type, collapsing its white space unless it is a string
+func validateValue(v *validator, path string, value string, simpleType *xsdSimpleType) {
+
+	if simpleType.builtin != "string" && simpleType.builtin != "anySimpleType" {
+		value = strings.Join(strings.Fields(value), " ")
+	}
+
+	if !builtinChecks[simpleType.builtin](value) {
+		v.fail(path, fmt.Sprintf("%q is not a valid %s", value, simpleType.builtin))
+		return
+	}
+
+	for _, step := range simpleType.patterns {
+
+		matched := false
+
+		for _, pattern := range step {
+			matched = matched || pattern.MatchString(value)
+		}
+
+		if !matched {
+			v.fail(path, fmt.Sprintf("%q does not match %s", value, describePatterns(step)))
+		}
+	}
+
+	if simpleType.enumeration != nil && !contains(simpleType.enumeration, value) {
+		v.fail(path, fmt.Sprintf("%q is not one of %s", value, strings.Join(simpleType.enumeration, ", ")))
+	}
+
+	validateLength(v, path, value, simpleType)
+
+	if numericBuiltins[simpleType.builtin] {
+		validateNumber(v, path, value, simpleType)
+	}
+}
+
+func describePatterns(patterns []*regexp.Regexp) string {
+
+	described := make([]string, len(patterns))
+
+	for i, pattern := range patterns {
+		described[i] = strings.TrimSuffix(strings.TrimPrefix(pattern.String(), "^(?:"), ")$")
+	}
+
+	return strings.Join(described, " or ")
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateLength(v *validator, path string, value string, simpleType *xsdSimpleType) {
+
+	length := utf8.RuneCountInString(value)
+
+	if simpleType.builtin == "base64Binary" {
+		decoded, _ := decodeBase64(value)
+		length = len(decoded)
+	}
+
+	if simpleType.length != nil && length != *simpleType.length {
+		v.fail(path, fmt.Sprintf("is %d long, exactly %d required", length, *simpleType.length))
+	}
+
+	if simpleType.minLength != nil && length < *simpleType.minLength {
+		v.fail(path, fmt.Sprintf("is %d long, at least %d required", length, *simpleType.minLength))
+	}
+
+	if simpleType.maxLength != nil && length > *simpleType.maxLength {
+		v.fail(path, fmt.Sprintf("is %d long, at most %d allowed", length, *simpleType.maxLength))
+	}
+}
+
+func validateNumber(v *validator, path string, value string, simpleType *xsdSimpleType) {
+
+	number, ok := new(big.Rat).SetString(value)
+
+	if !ok {
+		v.fail(path, fmt.Sprintf("%q is not a number", value))
+		return
+	}
+
+	integer, fraction := digits(value)
+
+	if simpleType.totalDigits != nil && len(integer)+len(fraction) > *simpleType.totalDigits {
+		v.fail(path, fmt.Sprintf("%q has more than %d digits", value, *simpleType.totalDigits))
+	}
+
+	if simpleType.fractionDigits != nil && len(fraction) > *simpleType.fractionDigits {
+		v.fail(path, fmt.Sprintf("%q has more than %d fraction digits", value, *simpleType.fractionDigits))
+	}
+
+	bounds := []struct {
+		bound  *big.Rat
+		failed func(int) bool
+		reason string
+	}{
+		{simpleType.minInclusive, func(c int) bool { return c < 0 }, "less than"},
+		{simpleType.maxInclusive, func(c int) bool { return c > 0 }, "greater than"},
+		{simpleType.minExclusive, func(c int) bool { return c <= 0 }, "at most"},
+		{simpleType.maxExclusive, func(c int) bool { return c >= 0 }, "at least"},
+	}
+
+	for _, b := range bounds {
+		if b.bound != nil && b.failed(number.Cmp(b.bound)) {
+			v.fail(path, fmt.Sprintf("%q is %s %s", value, b.reason, b.bound.RatString()))
+		}
+	}
+}
+
+// digits returns the significant integer and fraction digits of a decimal
+func digits(value string) (string, string) {
+
+	value = strings.TrimLeft(value, "+-")
+	integer, fraction, _ := strings.Cut(value, ".")
+
+	return strings.TrimLeft(integer, "0"), strings.TrimRight(fraction, "0")
+}
@@ -0,0 +1,492 @@
+package iso20022
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// accountOpeningSchema is a trimmed acmt.007.001.03 schema in the layout of the ones published by ISO 20022,
+// covering the elements ToAccountOpening writes
+const accountOpeningSchema = `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema xmlns="urn:iso:std:iso:20022:tech:xsd:acmt.007.001.03" xmlns:xs="http://www.w3.org/2001/XMLSchema" elementFormDefault="qualified" targetNamespace="urn:iso:std:iso:20022:tech:xsd:acmt.007.001.03">
+    <xs:element name="Document" type="Document"/>
+    <xs:complexType name="Document">
+        <xs:sequence>
+            <xs:element name="AcctOpngReq" type="AccountOpeningRequestV03">
+                <xs:annotation>
+                    <xs:documentation source="Name" xml:lang="EN">AccountOpeningRequestV03</xs:documentation>
+                </xs:annotation>
+            </xs:element>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="AccountOpeningRequestV03">
+        <xs:sequence>
+            <xs:element name="Refs" type="References4"/>
+            <xs:element name="Acct" type="CustomerAccount4"/>
+            <xs:element name="CtrctDts" type="AccountContract2" minOccurs="0"/>
+            <xs:element name="AcctSvcrId" type="BranchAndFinancialInstitutionIdentification5"/>
+            <xs:element name="Org" type="Organisation17"/>
+            <xs:element name="SplmtryData" type="SupplementaryData1" minOccurs="0" maxOccurs="unbounded"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="References4">
+        <xs:sequence>
+            <xs:element name="MsgId" type="MessageIdentification1"/>
+            <xs:element name="PrcId" type="MessageIdentification1"/>
+            <xs:element name="AttchdDocNm" type="Max70Text" minOccurs="0" maxOccurs="unbounded"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="MessageIdentification1">
+        <xs:sequence>
+            <xs:element name="Id" type="Max35Text"/>
+            <xs:element name="CreDtTm" type="ISODateTime"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="CustomerAccount4">
+        <xs:sequence>
+            <xs:element name="Id" type="AccountIdentification4Choice"/>
+            <xs:element name="Nm" type="Max70Text" minOccurs="0"/>
+            <xs:element name="Sts" type="AccountStatus3Code" minOccurs="0"/>
+            <xs:element name="Tp" type="CashAccountType2Choice" minOccurs="0"/>
+            <xs:element name="Ccy" type="ActiveOrHistoricCurrencyCode"/>
+            <xs:element name="MnthlyPmtVal" type="ActiveCurrencyAndAmount" minOccurs="0"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="AccountIdentification4Choice">
+        <xs:choice>
+            <xs:element name="IBAN" type="IBAN2007Identifier"/>
+            <xs:element name="Othr" type="GenericAccountIdentification1"/>
+        </xs:choice>
+    </xs:complexType>
+    <xs:complexType name="GenericAccountIdentification1">
+        <xs:sequence>
+            <xs:element name="Id" type="Max34Text"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="CashAccountType2Choice">
+        <xs:choice>
+            <xs:element name="Cd" type="ExternalCashAccountType1Code"/>
+            <xs:element name="Prtry" type="Max35Text"/>
+        </xs:choice>
+    </xs:complexType>
+    <xs:complexType name="AccountContract2">
+        <xs:sequence>
+            <xs:element name="TrgtGoLiveDt" type="ISODate" minOccurs="0"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="BranchAndFinancialInstitutionIdentification5">
+        <xs:sequence>
+            <xs:element name="FinInstnId" type="FinancialInstitutionIdentification8"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="FinancialInstitutionIdentification8">
+        <xs:sequence>
+            <xs:element name="BICFI" type="BICFIIdentifier" minOccurs="0"/>
+            <xs:element name="ClrSysMmbId" type="ClearingSystemMemberIdentification2" minOccurs="0"/>
+            <xs:element name="Nm" type="Max140Text" minOccurs="0"/>
+            <xs:element name="PstlAdr" type="PostalAddress6" minOccurs="0"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="ClearingSystemMemberIdentification2">
+        <xs:sequence>
+            <xs:element name="ClrSysId" type="ClearingSystemIdentification2Choice" minOccurs="0"/>
+            <xs:element name="MmbId" type="Max35Text"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="ClearingSystemIdentification2Choice">
+        <xs:choice>
+            <xs:element name="Cd" type="ExternalClearingSystemIdentification1Code"/>
+            <xs:element name="Prtry" type="Max35Text"/>
+        </xs:choice>
+    </xs:complexType>
+    <xs:complexType name="PostalAddress6">
+        <xs:sequence>
+            <xs:element name="TwnNm" type="Max35Text" minOccurs="0"/>
+            <xs:element name="Ctry" type="CountryCode" minOccurs="0"/>
+            <xs:element name="AdrLine" type="Max70Text" minOccurs="0" maxOccurs="7"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="Organisation17">
+        <xs:sequence>
+            <xs:element name="FullLglNm" type="Max350Text"/>
+            <xs:element name="OrgId" type="OrganisationIdentification8"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="OrganisationIdentification8">
+        <xs:sequence>
+            <xs:element name="AnyBIC" type="AnyBICIdentifier" minOccurs="0"/>
+            <xs:element name="Othr" type="GenericOrganisationIdentification1" minOccurs="0" maxOccurs="unbounded"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="GenericOrganisationIdentification1">
+        <xs:sequence>
+            <xs:element name="Id" type="Max35Text"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="SupplementaryData1">
+        <xs:sequence>
+            <xs:element name="PlcAndNm" type="Max350Text" minOccurs="0"/>
+            <xs:element name="Envlp" type="SupplementaryDataEnvelope1"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="SupplementaryDataEnvelope1">
+        <xs:sequence>
+            <xs:any namespace="##any" processContents="lax"/>
+        </xs:sequence>
+    </xs:complexType>
+    <xs:complexType name="ActiveCurrencyAndAmount">
+        <xs:simpleContent>
+            <xs:extension base="ActiveCurrencyAndAmount_SimpleType">
+                <xs:attribute name="Ccy" type="ActiveCurrencyCode" use="required"/>
+            </xs:extension>
+        </xs:simpleContent>
+    </xs:complexType>
+    <xs:simpleType name="ActiveCurrencyAndAmount_SimpleType">
+        <xs:restriction base="xs:decimal">
+            <xs:fractionDigits value="5"/>
+            <xs:totalDigits value="18"/>
+            <xs:minInclusive value="0"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="ActiveCurrencyCode">
+        <xs:restriction base="xs:string">
+            <xs:pattern value="[A-Z]{3,3}"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="ActiveOrHistoricCurrencyCode">
+        <xs:restriction base="xs:string">
+            <xs:pattern value="[A-Z]{3,3}"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="AccountStatus3Code">
+        <xs:restriction base="xs:string">
+            <xs:enumeration value="ENAB"/>
+            <xs:enumeration value="DISA"/>
+            <xs:enumeration value="DELE"/>
+            <xs:enumeration value="FORM"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="AnyBICIdentifier">
+        <xs:restriction base="xs:string">
+            <xs:pattern value="[A-Z]{6,6}[A-Z2-9][A-NP-Z0-9]([A-Z0-9]{3,3}){0,1}"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="BICFIIdentifier">
+        <xs:restriction base="xs:string">
+            <xs:pattern value="[A-Z]{6,6}[A-Z2-9][A-NP-Z0-9]([A-Z0-9]{3,3}){0,1}"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="CountryCode">
+        <xs:restriction base="xs:string">
+            <xs:pattern value="[A-Z]{2,2}"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="ExternalCashAccountType1Code">
+        <xs:restriction base="xs:string">
+            <xs:minLength value="1"/>
+            <xs:maxLength value="4"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="ExternalClearingSystemIdentification1Code">
+        <xs:restriction base="xs:string">
+            <xs:minLength value="1"/>
+            <xs:maxLength value="5"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="IBAN2007Identifier">
+        <xs:restriction base="xs:string">
+            <xs:pattern value="[A-Z]{2,2}[0-9]{2,2}[a-zA-Z0-9]{1,30}"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="ISODate">
+        <xs:annotation>
+            <xs:documentation source="Name" xml:lang="EN">ISODate</xs:documentation>
+        </xs:annotation>
+        <xs:restriction base="xs:date"/>
+    </xs:simpleType>
+    <xs:simpleType name="ISODateTime">
+        <xs:restriction base="xs:dateTime"/>
+    </xs:simpleType>
+    <xs:simpleType name="Max34Text">
+        <xs:restriction base="xs:string">
+            <xs:minLength value="1"/>
+            <xs:maxLength value="34"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="Max35Text">
+        <xs:restriction base="xs:string">
+            <xs:minLength value="1"/>
+            <xs:maxLength value="35"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="Max70Text">
+        <xs:restriction base="xs:string">
+            <xs:minLength value="1"/>
+            <xs:maxLength value="70"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="Max140Text">
+        <xs:restriction base="xs:string">
+            <xs:minLength value="1"/>
+            <xs:maxLength value="140"/>
+        </xs:restriction>
+    </xs:simpleType>
+    <xs:simpleType name="Max350Text">
+        <xs:restriction base="xs:string">
+            <xs:minLength value="1"/>
+            <xs:maxLength value="350"/>
+        </xs:restriction>
+    </xs:simpleType>
+</xs:schema>
+`
+
+func loadTestSchema(t *testing.T) *Schema {
+
+	schema, err := LoadSchema(strings.NewReader(accountOpeningSchema))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return schema
+}
+
+func marshalTestOpening(t *testing.T) string {
+
+	document, _, err := ToAccountOpening(testAccount())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	encoded, err := Marshal(document)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return string(encoded)
+}
+
+func TestSchema_Validate(t *testing.T) {
+
+	cases := map[string]struct {
+		mutate    func(document string) string
+		violation string
+	}{
+		"Marshalled document is valid": {
+			mutate: func(document string) string { return document },
+		},
+		"Optional elements and wildcards are accepted": {
+			mutate: func(document string) string {
+				return strings.Replace(strings.Replace(document, "<Ccy>GBP</Ccy>",
+					`<Ccy>GBP</Ccy><MnthlyPmtVal Ccy="GBP">125.50</MnthlyPmtVal>`, 1),
+					"</Org>", `</Org><SplmtryData><Envlp><Ext xmlns="urn:example">x</Ext></Envlp></SplmtryData>`, 1)
+			},
+		},
+		"Elements out of order": {
+			mutate: func(document string) string {
+				return strings.Replace(strings.Replace(document, "<Nm>Samantha Holder</Nm>", "", 1),
+					"<Ccy>GBP</Ccy>", "<Ccy>GBP</Ccy><Nm>Samantha Holder</Nm>", 1)
+			},
+			violation: "Document/AcctOpngReq/Acct: unexpected element Nm, expected MnthlyPmtVal",
+		},
+		"Missing required element": {
+			mutate:    func(document string) string { return strings.Replace(document, "<Ccy>GBP</Ccy>", "", 1) },
+			violation: "Document/AcctOpngReq/Acct: is incomplete, expected Ccy",
+		},
+		"Both choices": {
+			mutate: func(document string) string {
+				return strings.Replace(document, "</IBAN>", "</IBAN><Othr><Id>41426819</Id></Othr>", 1)
+			},
+			violation: "Document/AcctOpngReq/Acct/Id: unexpected element Othr",
+		},
+		"Too many occurrences": {
+			mutate: func(document string) string {
+				return strings.Replace(document, "<Ctry>GB</Ctry>", "<Ctry>GB</Ctry>"+strings.Repeat("<AdrLine>a</AdrLine>", 8), 1)
+			},
+			violation: "Document/AcctOpngReq/AcctSvcrId/FinInstnId/PstlAdr: unexpected element AdrLine",
+		},
+		"Undeclared element": {
+			mutate:    func(document string) string { return strings.Replace(document, "<Refs>", "<Refs><Foo>x</Foo>", 1) },
+			violation: "Document/AcctOpngReq/Refs: unexpected element Foo, expected MsgId",
+		},
+		"Pattern violation": {
+			mutate: func(document string) string {
+				return strings.Replace(document, "<BICFI>NWBKGB22</BICFI>", "<BICFI>nwbkgb22</BICFI>", 1)
+			},
+			violation: `Document/AcctOpngReq/AcctSvcrId/FinInstnId/BICFI: "nwbkgb22" does not match`,
+		},
+		"Length violation": {
+			mutate: func(document string) string {
+				return strings.Replace(document, "<Prtry>Personal</Prtry>", "<Prtry>"+strings.Repeat("a", 36)+"</Prtry>", 1)
+			},
+			violation: "Document/AcctOpngReq/Acct/Tp/Prtry: is 36 long, at most 35 allowed",
+		},
+		"Enumeration violation": {
+			mutate:    func(document string) string { return strings.Replace(document, "<Tp>", "<Sts>OPEN</Sts><Tp>", 1) },
+			violation: `Document/AcctOpngReq/Acct/Sts: "OPEN" is not one of ENAB, DISA, DELE, FORM`,
+		},
+		"Invalid date time": {
+			mutate: func(document string) string {
+				return regexp.MustCompile(`<CreDtTm>[^<]*</CreDtTm>`).ReplaceAllString(document, "<CreDtTm>19/10/2026</CreDtTm>")
+			},
+			violation: `Document/AcctOpngReq/Refs/PrcId/CreDtTm: "19/10/2026" is not a valid dateTime`,
+		},
+		"Fraction digits and missing attribute": {
+			mutate: func(document string) string {
+				return strings.Replace(document, "<Ccy>GBP</Ccy>", "<Ccy>GBP</Ccy><MnthlyPmtVal>1.123456</MnthlyPmtVal>", 1)
+			},
+			violation: "Document/AcctOpngReq/Acct/MnthlyPmtVal/@Ccy: is required; Document/AcctOpngReq/Acct/MnthlyPmtVal: \"1.123456\" has more than 5 fraction digits",
+		},
+		"Wrong root namespace": {
+			mutate: func(document string) string {
+				return strings.Replace(document, "acmt.007.001.03", "acmt.007.001.02", 1)
+			},
+			violation: "root element {urn:iso:std:iso:20022:tech:xsd:acmt.007.001.02}Document is not declared by the schema",
+		},
+	}
+
+	schema := loadTestSchema(t)
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			document := c.mutate(marshalTestOpening(t))
+
+			// Act
+
+			err := schema.Validate([]byte(document))
+
+			// Assert
+
+			if c.violation == "" {
+				if err != nil {
+					t.Errorf("validate returned unexpected error: got %v want %v", err, nil)
+				}
+				return
+			}
+
+			if !errors.Is(err, accounts.ValidationError) || !strings.Contains(err.Error(), c.violation) {
+				t.Errorf("validate returned unexpected error: got %v want %s", err, c.violation)
+			}
+		})
+	}
+}
+
+func TestSchema_Validate_IndexesRepeatedElements(t *testing.T) {
+
+	// Arrange
+
+	schema := loadTestSchema(t)
+
+	document := strings.Replace(marshalTestOpening(t), "</Othr>\n      </OrgId>",
+		"</Othr><Othr><Id>"+strings.Repeat("a", 36)+"</Id></Othr></OrgId>", 1)
+
+	// Act
+
+	err := schema.Validate([]byte(document))
+
+	// Assert
+
+	if err == nil || !strings.Contains(err.Error(), "Document/AcctOpngReq/Org/OrgId/Othr[1]/Id: is 36 long") {
+		t.Errorf("validate returned unexpected error: got %v", err)
+	}
+}
+
+func TestLoadSchema_UnsupportedOrMalformed_ReturnsErrInvalidSchema(t *testing.T) {
+
+	cases := map[string]string{
+		"Malformed XML":   `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">`,
+		"Not a schema":    `<Document/>`,
+		"Include":         `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"><xs:include schemaLocation="other.xsd"/></xs:schema>`,
+		"Undeclared type": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"><xs:element name="Document" type="Document"/></xs:schema>`,
+		"Union":           `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"><xs:simpleType name="Code"><xs:union memberTypes="xs:string"/></xs:simpleType></xs:schema>`,
+	}
+
+	for name, schema := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+
+			_, err := LoadSchema(strings.NewReader(schema))
+
+			// Assert
+
+			if !errors.Is(err, ErrInvalidSchema) {
+				t.Errorf("load returned unexpected error: got %v want %v", err, ErrInvalidSchema)
+			}
+		})
+	}
+}
+
+func TestLoadSchemaDir_ValidatesByNamespace(t *testing.T) {
+
+	// Arrange
+
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "acmt.007.001.03.xsd"), []byte(accountOpeningSchema), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schemas, err := LoadSchemaDir(dir)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	document, _, err := ToAccountOpening(testAccount())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	closing, _, err := ToAccountClosing(testAccount())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	encoded, err := schemas.Marshal(document)
+
+	if err != nil {
+		t.Fatalf("marshal returned unexpected error: got %v want %v", err, nil)
+	}
+
+	decoded, messageType, err := schemas.Unmarshal(encoded)
+
+	_, closingErr := schemas.Marshal(closing)
+
+	// Assert
+
+	if err != nil || messageType != MessageAcmt007 || decoded.(*AccountOpeningDocument).Request.Account.Currency != "GBP" {
+		t.Errorf("unmarshal returned unexpected result: got %v, %s, %v", decoded, messageType, err)
+	}
+
+	if !errors.Is(closingErr, accounts.ValidationError) || !strings.Contains(closingErr.Error(), "no schema loaded for namespace") {
+		t.Errorf("marshal returned unexpected error for a namespace without schema: got %v", closingErr)
+	}
+}
+
+func TestLoadSchemaDir_NoSchema_ReturnsErrInvalidSchema(t *testing.T) {
+
+	// Act
+
+	_, err := LoadSchemaDir(t.TempDir())
+
+	// Assert
+
+	if !errors.Is(err, ErrInvalidSchema) {
+		t.Errorf("load returned unexpected error: got %v want %v", err, ErrInvalidSchema)
+	}
+}
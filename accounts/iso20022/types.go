@@ -0,0 +1,113 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MessageType identifies a supported acmt message
+type MessageType string
+
+// Supported messages
+const (
+	MessageAcmt007 MessageType = "acmt.007"
+	MessageAcmt019 MessageType = "acmt.019"
+	MessageAcmt023 MessageType = "acmt.023"
+	MessageAcmt024 MessageType = "acmt.024"
+)
+
+const (
+	namespacePrefix              = "urn:iso:std:iso:20022:tech:xsd:"
+	accountOpeningNamespace      = namespacePrefix + "acmt.007.001.03"
+	accountClosingNamespace      = namespacePrefix + "acmt.019.001.03"
+	verificationRequestNamespace = namespacePrefix + "acmt.023.001.03"
+	verificationReportNamespace  = namespacePrefix + "acmt.024.001.03"
+	isoDateTimeLayout            = "2006-01-02T15:04:05"
+)
+
+// References identifies a message and the process it belongs to
+type References struct {
+	MessageID MessageIdentification `xml:"MsgId"`
+	ProcessID MessageIdentification `xml:"PrcId"`
+}
+
+// MessageIdentification is an identifier and its creation time
+type MessageIdentification struct {
+	ID             string `xml:"Id"`
+	CreationDtTime string `xml:"CreDtTm"`
+}
+
+// AccountIdentification identifies an account by IBAN or by a proprietary account number
+type AccountIdentification struct {
+	IBAN  string                        `xml:"IBAN,omitempty"`
+	Other *GenericAccountIdentification `xml:"Othr,omitempty"`
+}
+
+// GenericAccountIdentification is a proprietary account identifier, such as a UK account number
+type GenericAccountIdentification struct {
+	ID string `xml:"Id"`
+}
+
+// AccountType holds the proprietary account classification
+type AccountType struct {
+	Proprietary string `xml:"Prtry,omitempty"`
+}
+
+// CashAccount describes the account to open
+type CashAccount struct {
+	ID          AccountIdentification `xml:"Id"`
+	Name        string                `xml:"Nm,omitempty"`
+	Type        *AccountType          `xml:"Tp,omitempty"`
+	Currency    string                `xml:"Ccy,omitempty"`
+	Unsupported []AnyElement          `xml:",any"`
+}
+
+// FinancialInstitution identifies the account servicer by BIC and clearing system member id
+type FinancialInstitution struct {
+	FinInstnID FinancialInstitutionIdentification `xml:"FinInstnId"`
+}
+
+// FinancialInstitutionIdentification holds the identifiers of a financial institution
+type FinancialInstitutionIdentification struct {
+	BICFI         string                  `xml:"BICFI,omitempty"`
+	ClrSysMmbID   *ClearingSystemMemberID `xml:"ClrSysMmbId,omitempty"`
+	PostalAddress *PostalAddress          `xml:"PstlAdr,omitempty"`
+	Unsupported   []AnyElement            `xml:",any"`
+}
+
+// ClearingSystemMemberID is a bank id within a clearing system, e.g. a sort code within GBDSC
+type ClearingSystemMemberID struct {
+	ClrSysID ClearingSystemID `xml:"ClrSysId"`
+	MemberID string           `xml:"MmbId"`
+}
+
+// ClearingSystemID is the code of a clearing system
+type ClearingSystemID struct {
+	Code string `xml:"Cd"`
+}
+
+// PostalAddress holds the country of an institution
+type PostalAddress struct {
+	Country string `xml:"Ctry"`
+}
+
+// Organisation is the account owner organisation
+type Organisation struct {
+	FullLegalName string                      `xml:"FullLglNm"`
+	OrgID         *OrganisationIdentification `xml:"OrgId,omitempty"`
+}
+
+// OrganisationIdentification holds a proprietary organisation identifier
+type OrganisationIdentification struct {
+	Other GenericAccountIdentification `xml:"Othr"`
+}
+
+// AnyElement captures an element the converters do not support, reported as unmapped on import
+type AnyElement struct {
+	XMLName xml.Name
+}
+
+// newMessageIdentification builds an identifier from a uuid, dropping its dashes to fit Max35Text
+func newMessageIdentification(id string, now time.Time) MessageIdentification {
+	return MessageIdentification{ID: compactID(id), CreationDtTime: now.UTC().Format(isoDateTimeLayout)}
+}
@@ -0,0 +1,255 @@
+package iso20022
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Patterns and lengths of the XSD simple types checked by ValidateStructure
+var (
+	bicPattern      = regexp.MustCompile(`^[A-Z0-9]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+	ibanPattern     = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[a-zA-Z0-9]{1,30}$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	countryPattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+
+	isoDateTimeLayouts = []string{isoDateTimeLayout, "2006-01-02T15:04:05.999999999", time.RFC3339Nano}
+)
+
+const (
+	max4Text   = 4
+	max5Text   = 5
+	max34Text  = 34
+	max35Text  = 35
+	max70Text  = 70
+	max140Text = 140
+	max350Text = 350
+)
+
+// ValidateStructure checks a document against a hand-written subset of its XSD constraints, on the elements the
+// converters map only: required elements, text lengths, code patterns and date times. It is not XSD validation:
+// elements the converters do not map, element order, cardinalities and code lists are not checked, so a document
+// passing it may still be rejected by a validating parser; use a SchemaSet to validate against the XSDs. Every
+// violation is listed in the returned ValidationError
+func ValidateStructure(document interface{}) error {
+
+	v := &validator{}
+
+	switch d := document.(type) {
+	case *AccountOpeningDocument:
+		v.namespace(d.Xmlns, accountOpeningNamespace)
+		v.references("AcctOpngReq/Refs", d.Request.References)
+		v.cashAccount("AcctOpngReq/Acct", d.Request.Account)
+		v.institution("AcctOpngReq/AcctSvcrId", d.Request.AccountServicer)
+		v.organisation("AcctOpngReq/Org", d.Request.Organisation)
+
+	case *AccountClosingDocument:
+		v.namespace(d.Xmlns, accountClosingNamespace)
+		v.references("AcctClsgReq/Refs", d.Request.References)
+		v.account("AcctClsgReq/AcctId", d.Request.AccountID)
+		v.institution("AcctClsgReq/AcctSvcrId", d.Request.AccountServicer)
+		v.organisation("AcctClsgReq/Org", d.Request.Organisation)
+
+	case *VerificationRequestDocument:
+		v.namespace(d.Xmlns, verificationRequestNamespace)
+		v.assignment("IdVrfctnReq/Assgnmt", d.Request.Assignment)
+		v.require("IdVrfctnReq/Vrfctn", len(d.Request.Verifications) > 0)
+
+		for i, verification := range d.Request.Verifications {
+			path := fmt.Sprintf("IdVrfctnReq/Vrfctn[%d]", i)
+			v.text(path+"/Id", verification.ID, max35Text, true)
+			v.partyAndAccount(path+"/PtyAndAcctId", verification.PartyAndAccount)
+		}
+
+	case *VerificationReportDocument:
+		v.namespace(d.Xmlns, verificationReportNamespace)
+		v.assignment("IdVrfctnRpt/Assgnmt", d.Report.Assignment)
+		v.assignment("IdVrfctnRpt/OrgnlAssgnmt", d.Report.OriginalAssignment)
+		v.require("IdVrfctnRpt/Rpt", len(d.Report.Reports) > 0)
+
+		for i, entry := range d.Report.Reports {
+			path := fmt.Sprintf("IdVrfctnRpt/Rpt[%d]", i)
+			v.text(path+"/OrgnlId", entry.OriginalID, max35Text, true)
+			v.partyAndAccount(path+"/OrgnlPtyAndAcctId", entry.OriginalParty)
+
+			if entry.Reason != nil {
+				v.text(path+"/Rsn/Cd", entry.Reason.Code, max4Text, true)
+			}
+
+			if entry.UpdatedParty != nil {
+				v.partyAndAccount(path+"/UpdtdPtyAndAcctId", *entry.UpdatedParty)
+			}
+		}
+
+	default:
+		return validationError(fmt.Sprintf("unsupported document type %T", document))
+	}
+
+	return v.err()
+}
+
+func validationError(msg string) error {
+	return fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, msg)
+}
+
+// validator collects the violations found in a document
+type validator struct {
+	violations []string
+}
+
+func (v *validator) err() error {
+
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	return validationError(strings.Join(v.violations, "; "))
+}
+
+func (v *validator) fail(path string, reason string) {
+	v.violations = append(v.violations, path+": "+reason)
+}
+
+func (v *validator) require(path string, present bool) {
+	if !present {
+		v.fail(path, "is required")
+	}
+}
+
+func (v *validator) namespace(namespace string, expected string) {
+	if namespace != expected {
+		v.fail("Document", fmt.Sprintf("namespace %q, expected %q", namespace, expected))
+	}
+}
+
+func (v *validator) text(path string, value string, max int, required bool) {
+
+	if value == "" {
+		if required {
+			v.fail(path, "is required")
+		}
+		return
+	}
+
+	if length := utf8.RuneCountInString(value); length > max {
+		v.fail(path, fmt.Sprintf("is %d characters long, at most %d allowed", length, max))
+	}
+}
+
+func (v *validator) pattern(path string, value string, pattern *regexp.Regexp, required bool) {
+
+	if value == "" {
+		if required {
+			v.fail(path, "is required")
+		}
+		return
+	}
+
+	if !pattern.MatchString(value) {
+		v.fail(path, fmt.Sprintf("%q does not match %s", value, pattern))
+	}
+}
+
+func (v *validator) dateTime(path string, value string) {
+
+	if value == "" {
+		v.fail(path, "is required")
+		return
+	}
+
+	for _, layout := range isoDateTimeLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return
+		}
+	}
+
+	v.fail(path, fmt.Sprintf("%q is not an ISODateTime", value))
+}
+
+func (v *validator) identification(path string, identification MessageIdentification) {
+	v.text(path+"/Id", identification.ID, max35Text, true)
+	v.dateTime(path+"/CreDtTm", identification.CreationDtTime)
+}
+
+func (v *validator) references(path string, references References) {
+	v.identification(path+"/MsgId", references.MessageID)
+	v.identification(path+"/PrcId", references.ProcessID)
+}
+
+func (v *validator) assignment(path string, assignment Assignment) {
+	v.text(path+"/MsgId", assignment.MessageID, max35Text, true)
+	v.dateTime(path+"/CreDtTm", assignment.CreationDtTime)
+}
+
+// account checks the choice between an IBAN and a proprietary identifier
+func (v *validator) account(path string, identification AccountIdentification) {
+
+	switch {
+	case identification.IBAN != "" && identification.Other != nil:
+		v.fail(path, "IBAN and Othr are exclusive")
+	case identification.IBAN != "":
+		v.pattern(path+"/IBAN", identification.IBAN, ibanPattern, true)
+	case identification.Other != nil:
+		v.text(path+"/Othr/Id", identification.Other.ID, max34Text, true)
+	default:
+		v.fail(path, "IBAN or Othr is required")
+	}
+}
+
+func (v *validator) cashAccount(path string, account CashAccount) {
+
+	v.account(path+"/Id", account.ID)
+	v.text(path+"/Nm", account.Name, max70Text, false)
+	v.pattern(path+"/Ccy", account.Currency, currencyPattern, false)
+
+	if account.Type != nil {
+		v.text(path+"/Tp/Prtry", account.Type.Proprietary, max35Text, true)
+	}
+}
+
+func (v *validator) institution(path string, institution FinancialInstitution) {
+
+	identification := institution.FinInstnID
+	path += "/FinInstnId"
+
+	v.pattern(path+"/BICFI", identification.BICFI, bicPattern, false)
+
+	if member := identification.ClrSysMmbID; member != nil {
+		v.text(path+"/ClrSysMmbId/ClrSysId/Cd", member.ClrSysID.Code, max5Text, false)
+		v.text(path+"/ClrSysMmbId/MmbId", member.MemberID, max35Text, true)
+	}
+
+	if identification.PostalAddress != nil {
+		v.pattern(path+"/PstlAdr/Ctry", identification.PostalAddress.Country, countryPattern, true)
+	}
+}
+
+func (v *validator) organisation(path string, organisation *Organisation) {
+
+	if organisation == nil {
+		return
+	}
+
+	v.text(path+"/FullLglNm", organisation.FullLegalName, max350Text, true)
+
+	if organisation.OrgID != nil {
+		v.text(path+"/OrgId/Othr/Id", organisation.OrgID.Other.ID, max35Text, true)
+	}
+}
+
+func (v *validator) partyAndAccount(path string, party PartyAndAccount) {
+
+	if party.Party != nil {
+		v.text(path+"/Pty/Nm", party.Party.Name, max140Text, true)
+	}
+
+	v.account(path+"/Acct", party.Account)
+
+	if party.Agent != nil {
+		v.institution(path+"/Agt", *party.Agent)
+	}
+}
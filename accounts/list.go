@@ -0,0 +1,78 @@
+package accounts
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Default page size used by List when none is given
+const (
+	DefaultPageSize = 100
+)
+
+// ListOptions holds the pagination and filtering parameters of a List request
+type ListOptions struct {
+	PageNumber int
+	PageSize   int
+
+	// Filter is sent as filter[key]=value query string parameters, e.g. {"bank_id": "400300"}
+	Filter map[string]string
+}
+
+// AccountListResponse returned by the client SDK containing a page of accounts
+type AccountListResponse struct {
+	Data  []*Data    `json:"data"`
+	Links *ListLinks `json:"links"`
+	apiCommonResult
+}
+
+// ListLinks contains the pagination links returned along with a page of resources
+type ListLinks struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Next  string `json:"next"`
+	Prev  string `json:"prev"`
+	Self  string `json:"self"`
+}
+
+// HasNext reports whether there is a page after this one
+func (r *AccountListResponse) HasNext() bool {
+	return r.Links != nil && r.Links.Next != ""
+}
+
+// List retrieves a page of accounts
+func (c *Client) List(ctx context.Context, options *ListOptions) (*AccountListResponse, error) {
+
+	response, err := c.accountsResource().List(ctx, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountListResponse{
+		Data:            response.Data,
+		Links:           response.Links,
+		apiCommonResult: response.apiCommonResult,
+	}, nil
+}
+
+func listQuery(options *ListOptions) url.Values {
+
+	q := url.Values{}
+
+	pageSize := options.PageSize
+
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	q.Set("page[number]", strconv.Itoa(options.PageNumber))
+	q.Set("page[size]", strconv.Itoa(pageSize))
+
+	for k, v := range options.Filter {
+		q.Set("filter["+k+"]", v)
+	}
+
+	return q
+}
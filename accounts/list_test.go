@@ -0,0 +1,96 @@
+package accounts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestList_validPage_returnsAccountsAndLinks(t *testing.T) {
+
+	// Arrange
+
+	expectedCorrectResponse := `{"data":[{"attributes":{"bank_id":"400300","bank_id_code":"GBDSC","country":"GB","name":["Account One"]},"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","organisation_id":"eb0bd6f5-c3f5-44b2-b677-acd23cdde73c","type":"accounts","version":0},{"attributes":{"bank_id":"400300","bank_id_code":"GBDSC","country":"GB","name":["Account Two"]},"id":"b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d","organisation_id":"eb0bd6f5-c3f5-44b2-b677-acd23cdde73c","type":"accounts","version":1}],"links":{"first":"/v1/organisation/accounts?page%5Bnumber%5D=first","next":"/v1/organisation/accounts?page%5Bnumber%5D=2","self":"/v1/organisation/accounts?page%5Bnumber%5D=1"}}`
+	expectedCorrectRequest := `/v1/organisation/accounts`
+	expectedRawQuery := `filter%5Bbank_id%5D=400300&page%5Bnumber%5D=1&page%5Bsize%5D=2`
+
+	ts := newTestServer(expectedCorrectRequest, func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.RawQuery != expectedRawQuery {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error_message":"unexpected query"}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, expectedCorrectResponse)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithTimeout(time.Duration(100*time.Millisecond)))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	ctx := context.Background()
+
+	// Act
+
+	response, err := accountsClient.List(ctx, &ListOptions{PageNumber: 1, PageSize: 2, Filter: map[string]string{"bank_id": "400300"}})
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("list returned an error: got %v want %v", err, nil)
+	}
+
+	if len(response.Data) != 2 {
+		t.Fatalf("handler returned unexpected number of accounts: got %d want %d", len(response.Data), 2)
+	}
+
+	if response.Data[1].Version != 1 {
+		t.Errorf("handler returned unexpected version: got %d want %d", response.Data[1].Version, 1)
+	}
+
+	if !response.HasNext() {
+		t.Errorf("handler returned no next page link")
+	}
+}
+
+func TestList_ApiError_returnsApiHttpErrorType(t *testing.T) {
+
+	// Arrange
+
+	expectedErrorMessage := `invalid page size`
+	expectedStatus := http.StatusBadRequest
+
+	ts := newTestServer(`/v1/organisation/accounts`, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(expectedStatus)
+		io.WriteString(w, `{"error_message":"invalid page size"}`)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithTimeout(time.Duration(100*time.Millisecond)))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	// Act
+
+	response, err := accountsClient.List(context.Background(), nil)
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v",
+			response, nil)
+	}
+
+	assertClientError(err, expectedErrorMessage, t, ApiHttpErrorType, expectedStatus)
+}
@@ -0,0 +1,120 @@
+package lookup
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"strings"
+	"sync"
+)
+
+// Index is an in-memory Finder of accounts by IBAN and by bank id with account number. Passed to WithFallback, it
+// caches the accounts resolved through the API; it may also be filled up front, e.g. from a mirror sync
+type Index struct {
+	mu            sync.RWMutex
+	byID          map[string]*accounts.Data
+	byIban        map[string]*accounts.Data
+	byBankAccount map[string][]*accounts.Data
+}
+
+// NewIndex constructs an empty Index
+func NewIndex() *Index {
+	return &Index{
+		byID:          map[string]*accounts.Data{},
+		byIban:        map[string]*accounts.Data{},
+		byBankAccount: map[string][]*accounts.Data{},
+	}
+}
+
+// Add indexes the given accounts, replacing the accounts with the same id along with their former IBAN and bank
+// account entries
+func (x *Index) Add(accountsData ...*accounts.Data) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, account := range accountsData {
+
+		if account == nil || account.Attributes == nil {
+			continue
+		}
+
+		if indexed, ok := x.byID[account.ID]; ok {
+			x.remove(indexed)
+		}
+
+		x.byID[account.ID] = account
+
+		attributes := account.Attributes
+
+		if attributes.Iban != "" {
+			x.byIban[ByIBAN(attributes.Iban).Iban] = account
+		}
+
+		if attributes.BankID != "" && attributes.AccountNumber != "" {
+			key := bankAccountKey(attributes)
+			x.byBankAccount[key] = append(x.byBankAccount[key], account)
+		}
+	}
+}
+
+// remove drops the entries of an indexed account
+func (x *Index) remove(account *accounts.Data) {
+
+	attributes := account.Attributes
+
+	if attributes.Iban != "" {
+
+		iban := ByIBAN(attributes.Iban).Iban
+
+		if x.byIban[iban] == account {
+			delete(x.byIban, iban)
+		}
+	}
+
+	key := bankAccountKey(attributes)
+	var kept []*accounts.Data
+
+	for _, indexed := range x.byBankAccount[key] {
+		if indexed != account {
+			kept = append(kept, indexed)
+		}
+	}
+
+	if len(kept) == 0 {
+		delete(x.byBankAccount, key)
+	} else {
+		x.byBankAccount[key] = kept
+	}
+}
+
+func bankAccountKey(attributes *accounts.AccountAttributes) string {
+	return attributes.BankID + "/" + attributes.AccountNumber
+}
+
+// FindAccounts returns the indexed accounts matching an iban, or a bank_id, account_number and optional
+// bank_id_code filter
+func (x *Index) FindAccounts(_ context.Context, attributes map[string]string) ([]*accounts.Data, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	if iban := attributes["iban"]; iban != "" {
+
+		if account, ok := x.byIban[iban]; ok {
+			return []*accounts.Data{account}, nil
+		}
+
+		return nil, nil
+	}
+
+	var found []*accounts.Data
+
+	for _, account := range x.byBankAccount[attributes["bank_id"]+"/"+attributes["account_number"]] {
+
+		code := attributes["bank_id_code"]
+
+		if code == "" || strings.EqualFold(code, account.Attributes.BankIDCode) {
+			found = append(found, account)
+		}
+	}
+
+	return found, nil
+}
@@ -0,0 +1,208 @@
+// Package lookup resolves inbound account identifiers, an IBAN or a bank id with account number, to our accounts,
+// falling back to local copies such as the mirror when the API cannot answer
+package lookup
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/mirror"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when no account matches the identifier
+	ErrNotFound = errors.New("No account matches the identifier")
+
+	// ErrAmbiguous is returned when several accounts match the identifier
+	ErrAmbiguous = errors.New("Several accounts match the identifier")
+)
+
+// Sources of a Result
+const (
+	SourceAPI      = "api"
+	SourceFallback = "fallback"
+)
+
+// Identifier identifies an account either by Iban or by BankID and AccountNumber
+type Identifier struct {
+	Iban          string
+	BankID        string
+	BankIDCode    string
+	AccountNumber string
+}
+
+// ByIBAN returns the Identifier of the account with the given IBAN, spaces and case ignored
+func ByIBAN(iban string) Identifier {
+	return Identifier{Iban: strings.ToUpper(strings.ReplaceAll(iban, " ", ""))}
+}
+
+// ByBankAccount returns the Identifier of the account with the given bank id and account number.
+// bankIdCode, e.g. "GBDSC", may be empty
+func ByBankAccount(bankIdCode string, bankId string, accountNumber string) Identifier {
+	return Identifier{BankID: bankId, BankIDCode: bankIdCode, AccountNumber: accountNumber}
+}
+
+// filter returns the List filter, keyed by attribute json name, matching the identifier
+func (i Identifier) filter() (map[string]string, error) {
+
+	if i.Iban != "" {
+		return map[string]string{"iban": i.Iban}, nil
+	}
+
+	if i.BankID == "" || i.AccountNumber == "" {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, "an iban, or a bank id and account number, is required")
+	}
+
+	filter := map[string]string{"bank_id": i.BankID, "account_number": i.AccountNumber}
+
+	if i.BankIDCode != "" {
+		filter["bank_id_code"] = i.BankIDCode
+	}
+
+	return filter, nil
+}
+
+// Lister is the subset of the accounts client used to search accounts
+type Lister interface {
+	List(ctx context.Context, options *accounts.ListOptions) (*accounts.AccountListResponse, error)
+}
+
+// Finder searches a local copy of the accounts by attribute values keyed by json name. mirror.GormStore and Index
+// implement it
+type Finder interface {
+	FindAccounts(ctx context.Context, attributes map[string]string) ([]*accounts.Data, error)
+}
+
+var _ Finder = (*mirror.GormStore)(nil)
+
+// Result is a resolved account along with where it was found
+type Result struct {
+	Account *accounts.Data
+	Source  string
+
+	// APIErr holds the error which made the resolver fall back, if any
+	APIErr error
+}
+
+// Resolver resolves identifiers through the accounts List filters
+type Resolver struct {
+	lister    Lister
+	fallbacks []Finder
+}
+
+// ResolverOption is the type of constructor options for NewResolver(...)
+type ResolverOption func(*Resolver)
+
+// WithFallback adds finders queried, in order, when the API call fails. Finders which are an *Index are also fed
+// with every account resolved through the API
+func WithFallback(finders ...Finder) ResolverOption {
+	return func(r *Resolver) {
+		r.fallbacks = append(r.fallbacks, finders...)
+	}
+}
+
+// NewResolver constructs a Resolver searching through lister
+func NewResolver(lister Lister, options ...ResolverOption) *Resolver {
+
+	r := &Resolver{lister: lister}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Resolve returns the account matching identifier. It fails with ErrNotFound or ErrAmbiguous when the API answers
+// with no or several accounts, and falls back to the local finders when the API cannot answer: the API could not
+// be reached or timed out (an accounts.TransportError), the circuit is open or the API failed with a 5xx or 429
+// status. Other errors, e.g. 401 Unauthorized, a canceled context or an undecodable answer, are returned as they
+// are rather than masked by a possibly stale local copy
+func (r *Resolver) Resolve(ctx context.Context, identifier Identifier) (*Result, error) {
+
+	filter, err := identifier.filter()
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, apiErr := r.lister.List(ctx, &accounts.ListOptions{PageSize: 2, Filter: filter})
+
+	if apiErr == nil {
+
+		account, err := single(response.Data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.remember(account)
+
+		return &Result{Account: account, Source: SourceAPI}, nil
+	}
+
+	if !unavailable(apiErr) || len(r.fallbacks) == 0 {
+		return nil, apiErr
+	}
+
+	for _, finder := range r.fallbacks {
+
+		found, err := finder.FindAccounts(ctx, filter)
+
+		if err != nil || len(found) == 0 {
+			continue
+		}
+
+		account, err := single(found)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Result{Account: account, Source: SourceFallback, APIErr: apiErr}, nil
+	}
+
+	return nil, apiErr
+}
+
+// unavailable reports whether err means the API could not answer, rather than refused the request. A canceled
+// request, a failed request hook or an undecodable answer is not an outage
+func unavailable(err error) bool {
+
+	var apiErr *accounts.ApiError
+
+	if errors.As(err, &apiErr) {
+		return apiErr.Status >= http.StatusInternalServerError || apiErr.Status == http.StatusTooManyRequests
+	}
+
+	var transportErr *accounts.TransportError
+
+	if errors.As(err, &transportErr) {
+		return !errors.Is(err, context.Canceled)
+	}
+
+	return errors.Is(err, accounts.ErrCircuitOpen)
+}
+
+func (r *Resolver) remember(account *accounts.Data) {
+	for _, finder := range r.fallbacks {
+		if index, ok := finder.(*Index); ok {
+			index.Add(account)
+		}
+	}
+}
+
+func single(found []*accounts.Data) (*accounts.Data, error) {
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%w | %d | %s", ErrNotFound, http.StatusNotFound, "")
+	case 1:
+		return found[0], nil
+	}
+
+	return nil, fmt.Errorf("%w | %d | %s", ErrAmbiguous, http.StatusConflict, "")
+}
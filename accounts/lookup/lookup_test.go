@@ -0,0 +1,332 @@
+package lookup
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/accountsmock"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func ibanAccount(id string, iban string) *accounts.Data {
+	return &accounts.Data{ID: id, Attributes: &accounts.AccountAttributes{
+		AccountNumber: "41426819",
+		BankID:        "400300",
+		BankIDCode:    "GBDSC",
+		Iban:          iban,
+	}}
+}
+
+func TestResolve_ApiHit_FeedsTheIndexUsedWhenTheApiFails(t *testing.T) {
+
+	// Arrange
+
+	account := ibanAccount("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "GB11NWBK40030041426819")
+	unavailable := fmt.Errorf("%w | %d | %s", accounts.ErrCircuitOpen, http.StatusServiceUnavailable, accounts.EndpointList)
+
+	mock := accountsmock.New().Script(accountsmock.MethodList,
+		accountsmock.Result{Response: &accounts.AccountListResponse{Data: []*accounts.Data{account}}},
+		accountsmock.Result{Err: unavailable},
+	)
+
+	resolver := NewResolver(mock, WithFallback(NewIndex()))
+	ctx := context.Background()
+
+	first, err := resolver.Resolve(ctx, ByIBAN("gb11 nwbk 4003 0041 4268 19"))
+
+	if err != nil {
+		t.Fatalf("resolve returned an error: got %v want %v", err, nil)
+	}
+
+	// Act
+
+	second, err := resolver.Resolve(ctx, ByBankAccount("GBDSC", "400300", "41426819"))
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("resolve returned an error: got %v want %v", err, nil)
+	}
+
+	if first.Source != SourceAPI || second.Source != SourceFallback {
+		t.Errorf("resolve returned unexpected sources: got %s and %s want %s and %s", first.Source, second.Source, SourceAPI, SourceFallback)
+	}
+
+	if second.Account.ID != account.ID || !errors.Is(second.APIErr, accounts.ErrCircuitOpen) {
+		t.Errorf("fallback returned unexpected result: got %+v", second)
+	}
+
+	options := mock.CallsTo(accountsmock.MethodList)[0].Args[0].(*accounts.ListOptions)
+
+	if !reflect.DeepEqual(options.Filter, map[string]string{"iban": "GB11NWBK40030041426819"}) {
+		t.Errorf("list received unexpected filter: got %v", options.Filter)
+	}
+}
+
+func TestResolveErrorCases(t *testing.T) {
+
+	// Arrange
+
+	unavailable := fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, http.StatusBadRequest, "connection refused")
+
+	errorCases := map[string]struct {
+		identifier        Identifier
+		result            accountsmock.Result
+		expectedErrorType error
+	}{
+		"No account matches": {
+			identifier:        ByIBAN("GB11NWBK40030041426819"),
+			result:            accountsmock.Result{Response: &accounts.AccountListResponse{}},
+			expectedErrorType: ErrNotFound,
+		},
+		"Several accounts match": {
+			identifier: ByBankAccount("", "400300", "41426819"),
+			result: accountsmock.Result{Response: &accounts.AccountListResponse{Data: []*accounts.Data{
+				ibanAccount("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", ""),
+				ibanAccount("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb", ""),
+			}}},
+			expectedErrorType: ErrAmbiguous,
+		},
+		"Incomplete identifier": {
+			identifier:        ByBankAccount("GBDSC", "400300", ""),
+			expectedErrorType: accounts.ValidationError,
+		},
+		"Api failure missing from the fallback": {
+			identifier:        ByIBAN("GB11NWBK40030041426819"),
+			result:            accountsmock.Result{Err: unavailable},
+			expectedErrorType: accounts.BuildingRequestError,
+		},
+	}
+
+	for name, errCase := range errorCases {
+
+		resolver := NewResolver(accountsmock.New().Script(accountsmock.MethodList, errCase.result), WithFallback(NewIndex()))
+
+		// Act
+
+		result, err := resolver.Resolve(context.Background(), errCase.identifier)
+
+		// Assert
+
+		if result != nil {
+			t.Errorf("%s: Returned result: got %v want %v", name, result, nil)
+		}
+
+		if !errors.Is(err, errCase.expectedErrorType) {
+			t.Errorf("%s: unexpected error: got %v want %v", name, err, errCase.expectedErrorType)
+		}
+	}
+}
+
+func TestResolve_FallsBackOnlyWhenTheApiCannotAnswer(t *testing.T) {
+
+	account := ibanAccount("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "GB11NWBK40030041426819")
+
+	cases := map[string]struct {
+		apiErr            error
+		expectedSource    string
+		expectedErrorType error
+	}{
+		"Unauthorized is returned": {
+			apiErr:            &accounts.ApiError{Status: http.StatusUnauthorized, Message: "invalid token"},
+			expectedErrorType: accounts.ApiHttpErrorType,
+		},
+		"Forbidden is returned": {
+			apiErr:            &accounts.ApiError{Status: http.StatusForbidden},
+			expectedErrorType: accounts.ApiHttpErrorType,
+		},
+		"Bad request is returned": {
+			apiErr:            &accounts.ApiError{Status: http.StatusBadRequest},
+			expectedErrorType: accounts.ApiHttpErrorType,
+		},
+		"Server error falls back": {
+			apiErr:         &accounts.ApiError{Status: http.StatusBadGateway},
+			expectedSource: SourceFallback,
+		},
+		"Too many requests falls back": {
+			apiErr:         &accounts.ApiError{Status: http.StatusTooManyRequests},
+			expectedSource: SourceFallback,
+		},
+		"Transport error falls back": {
+			apiErr:         &accounts.TransportError{Status: http.StatusBadRequest, Err: errors.New("connection refused")},
+			expectedSource: SourceFallback,
+		},
+		"Circuit open falls back": {
+			apiErr:         fmt.Errorf("%w | %d | %s", accounts.ErrCircuitOpen, http.StatusServiceUnavailable, accounts.EndpointList),
+			expectedSource: SourceFallback,
+		},
+		"Other building request error is returned": {
+			apiErr:            fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, http.StatusBadRequest, "hook failed"),
+			expectedErrorType: accounts.BuildingRequestError,
+		},
+	}
+
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			index := NewIndex()
+			index.Add(account)
+
+			resolver := NewResolver(accountsmock.New().Script(accountsmock.MethodList, accountsmock.Result{Err: testCase.apiErr}), WithFallback(index))
+
+			// Act
+
+			result, err := resolver.Resolve(context.Background(), ByIBAN("GB11NWBK40030041426819"))
+
+			// Assert
+
+			if testCase.expectedErrorType != nil {
+
+				if result != nil {
+					t.Errorf("Returned result: got %v want %v", result, nil)
+				}
+
+				if !errors.Is(err, testCase.expectedErrorType) {
+					t.Errorf("unexpected error: got %v want %v", err, testCase.expectedErrorType)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("resolve returned an error: got %v want %v", err, nil)
+			}
+
+			if result.Source != testCase.expectedSource || result.Account.ID != account.ID {
+				t.Errorf("resolve returned unexpected result: got %+v", result)
+			}
+		})
+	}
+}
+
+func TestResolve_WithAccountsClient_FallsBackOnlyOnOutages(t *testing.T) {
+
+	account := ibanAccount("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "GB11NWBK40030041426819")
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := map[string]struct {
+		handler        http.HandlerFunc
+		closed         bool
+		options        []accounts.ClientOption
+		ctx            context.Context
+		timeout        time.Duration
+		expectFallback bool
+	}{
+		"Refused connection falls back": {
+			closed:         true,
+			expectFallback: true,
+		},
+		"Timeout falls back": {
+			handler:        func(w http.ResponseWriter, r *http.Request) { time.Sleep(100 * time.Millisecond) },
+			timeout:        10 * time.Millisecond,
+			expectFallback: true,
+		},
+		"Canceled request is returned": {
+			handler: func(w http.ResponseWriter, r *http.Request) {},
+			ctx:     canceled,
+		},
+		"Undecodable answer is returned": {
+			handler: func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, `not json`) },
+		},
+		"Request hook failure is returned": {
+			handler: func(w http.ResponseWriter, r *http.Request) {},
+			options: []accounts.ClientOption{accounts.WithRequestHook(func(ctx context.Context, req *http.Request) error {
+				return errors.New("signing failed")
+			})},
+		},
+	}
+
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			ts := httptest.NewServer(testCase.handler)
+			defer ts.Close()
+
+			if testCase.closed {
+				ts.Close()
+			}
+
+			client, err := accounts.NewClient(append([]accounts.ClientOption{accounts.WithBaseURL(ts.URL)}, testCase.options...)...)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			ctx := testCase.ctx
+
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			if testCase.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, testCase.timeout)
+				defer cancel()
+			}
+
+			index := NewIndex()
+			index.Add(account)
+
+			// Act
+
+			result, err := NewResolver(client, WithFallback(index)).Resolve(ctx, ByIBAN("GB11NWBK40030041426819"))
+
+			// Assert
+
+			if testCase.expectFallback {
+				if err != nil || result.Source != SourceFallback {
+					t.Errorf("resolve returned unexpected result: got %+v, %v want the fallback account", result, err)
+				}
+				return
+			}
+
+			if result != nil || !errors.Is(err, accounts.BuildingRequestError) {
+				t.Errorf("resolve returned unexpected result: got %+v, %v want %v", result, err, accounts.BuildingRequestError)
+			}
+		})
+	}
+}
+
+func TestIndex_AccountAddedAgain_ReplacesItsFormerEntries(t *testing.T) {
+
+	// Arrange
+
+	index := NewIndex()
+	index.Add(ibanAccount("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "GB11NWBK40030041426819"))
+
+	updated := ibanAccount("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "GB29NWBK40030041426819")
+	updated.Attributes.AccountNumber = "41426820"
+
+	ctx := context.Background()
+
+	// Act
+
+	index.Add(updated)
+
+	// Assert
+
+	formerIban, _ := index.FindAccounts(ctx, map[string]string{"iban": "GB11NWBK40030041426819"})
+	formerBankAccount, _ := index.FindAccounts(ctx, map[string]string{"bank_id": "400300", "account_number": "41426819"})
+	currentIban, _ := index.FindAccounts(ctx, map[string]string{"iban": "GB29NWBK40030041426819"})
+	currentBankAccount, _ := index.FindAccounts(ctx, map[string]string{"bank_id": "400300", "account_number": "41426820"})
+
+	if len(formerIban) != 0 || len(formerBankAccount) != 0 {
+		t.Errorf("index returned unexpected former entries: got %v and %v want none", formerIban, formerBankAccount)
+	}
+
+	if len(currentIban) != 1 || currentIban[0] != updated || len(currentBankAccount) != 1 || currentBankAccount[0] != updated {
+		t.Errorf("index returned unexpected current entries: got %v and %v want %v", currentIban, currentBankAccount, updated)
+	}
+}
@@ -0,0 +1,141 @@
+// Package mandates manages direct debit mandates, built on the accounts client core
+package mandates
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Default values used by the mandates client
+const (
+	MandatesPath = "/v1/transaction/mandates"
+	mandateType  = "mandates"
+)
+
+// Client may be used to make mandates requests to the Form3 API
+type Client struct {
+	mandates *accounts.Resource[Mandate]
+}
+
+// NewClient constructs a mandates Client sending its requests through core. Circuit breaker endpoints are
+// prefixed with "mandates."
+func NewClient(core *accounts.Client) *Client {
+	return &Client{
+		mandates: accounts.NewResource[Mandate](core, "mandates", MandatesPath),
+	}
+}
+
+// Create stores the given mandate, which must be linked to an account and have both parties
+func (c *Client) Create(ctx context.Context, mandate *Mandate) (*accounts.Response[Mandate], error) {
+
+	if err := validate(mandate); err != nil {
+		return nil, err
+	}
+
+	if mandate.Type == "" {
+		mandate.Type = mandateType
+	}
+
+	if mandate.Attributes.Status == "" {
+		mandate.Attributes.Status = StatusPending
+	}
+
+	return c.mandates.Create(ctx, &accounts.Document[Mandate]{Data: mandate})
+}
+
+// Fetch retrieves the mandate with the given id
+func (c *Client) Fetch(ctx context.Context, mandateId uuid.UUID) (*accounts.Response[Mandate], error) {
+	return c.mandates.Fetch(ctx, mandateId.String())
+}
+
+// List retrieves a page of mandates
+func (c *Client) List(ctx context.Context, options *accounts.ListOptions) (*accounts.ListResponse[Mandate], error) {
+	return c.mandates.List(ctx, options)
+}
+
+// ListForAccount retrieves a page of the mandates linked to the given account
+func (c *Client) ListForAccount(ctx context.Context, accountId uuid.UUID, options *accounts.ListOptions) (*accounts.ListResponse[Mandate], error) {
+
+	filtered := accounts.ListOptions{}
+
+	if options != nil {
+		filtered = *options
+	}
+
+	filter := map[string]string{RelationshipAccount: accountId.String()}
+
+	for k, v := range filtered.Filter {
+		filter[k] = v
+	}
+
+	filtered.Filter = filter
+
+	return c.mandates.List(ctx, &filtered)
+}
+
+// Cancel fetches the mandate and moves it to StatusCancelled with the given reason. It fails with a
+// TransitionError, without updating the mandate, when its current status cannot be cancelled
+func (c *Client) Cancel(ctx context.Context, mandateId uuid.UUID, reason string) (*accounts.Response[Mandate], error) {
+	return c.transition(ctx, mandateId, StatusCancelled, reason)
+}
+
+// transition moves the mandate to next, patching the version it was fetched at
+func (c *Client) transition(ctx context.Context, mandateId uuid.UUID, next Status, reason string) (*accounts.Response[Mandate], error) {
+
+	current, err := c.Fetch(ctx, mandateId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTransition(current.Data, next); err != nil {
+		return nil, err
+	}
+
+	return c.mandates.Patch(ctx, mandateId.String(), &accounts.Document[Mandate]{Data: &Mandate{
+		ID:             current.Data.ID,
+		OrganisationID: current.Data.OrganisationID,
+		Type:           mandateType,
+		Version:        current.Data.Version,
+		Attributes:     &MandateAttributes{Status: next, StatusReason: reason},
+	}})
+}
+
+func validate(mandate *Mandate) error {
+
+	if mandate == nil || mandate.Attributes == nil {
+		return validationError("mandate attributes are required")
+	}
+
+	if _, err := uuid.Parse(mandate.ID); err != nil {
+		return validationError(fmt.Sprintf("id must be a uuid: %q", mandate.ID))
+	}
+
+	if mandate.AccountID() == "" {
+		return validationError("mandate must be linked to an account")
+	}
+
+	attributes := mandate.Attributes
+
+	if attributes.PayerParty == nil || attributes.PayerParty.AccountNumber == "" {
+		return validationError("payer party account number is required")
+	}
+
+	if attributes.BeneficiaryParty == nil || attributes.BeneficiaryParty.AccountNumber == "" {
+		return validationError("beneficiary party account number is required")
+	}
+
+	if attributes.Scheme != SchemeBacs && attributes.Scheme != SchemeSEPADD {
+		return validationError(fmt.Sprintf("scheme must be %s or %s: %q", SchemeBacs, SchemeSEPADD, attributes.Scheme))
+	}
+
+	return nil
+}
+
+func validationError(msg string) error {
+	return fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, msg)
+}
@@ -0,0 +1,249 @@
+package mandates
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+const (
+	mandateId     = "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43"
+	beneficiaryId = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+)
+
+// fakeServer stores mandates in memory, answering Create, Fetch, List and Patch like the API
+type fakeServer struct {
+	mu       sync.Mutex
+	mandates map[string]*Mandate
+	patches  int
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, MandatesPath), "/")
+
+	switch {
+	case r.Method == http.MethodPost:
+		document := &accounts.Document[Mandate]{}
+		json.NewDecoder(r.Body).Decode(document)
+		f.mandates[document.Data.ID] = document.Data
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(document)
+
+	case r.Method == http.MethodPatch:
+		body, _ := io.ReadAll(r.Body)
+		document := &accounts.Document[Mandate]{}
+		json.Unmarshal(body, document)
+		stored := f.mandates[id]
+		if stored.Version != document.Data.Version {
+			w.WriteHeader(http.StatusConflict)
+			io.WriteString(w, `{"error_message":"invalid version"}`)
+			return
+		}
+		// merge the patch into the stored mandate, like the API: members sent replace the stored ones
+		json.Unmarshal(body, &accounts.Document[Mandate]{Data: stored})
+		f.patches++
+		stored.Version++
+		json.NewEncoder(w).Encode(&accounts.Document[Mandate]{Data: stored})
+
+	case id == "":
+		list := &accounts.ListDocument[Mandate]{}
+		for _, mandate := range f.mandates {
+			if mandate.AccountID() == r.URL.Query().Get("filter["+RelationshipAccount+"]") {
+				list.Data = append(list.Data, mandate)
+			}
+		}
+		json.NewEncoder(w).Encode(list)
+
+	default:
+		mandate, ok := f.mandates[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error_message":"mandate not found"}`)
+			return
+		}
+		json.NewEncoder(w).Encode(&accounts.Document[Mandate]{Data: mandate})
+	}
+}
+
+func newTestClient(t *testing.T) (*Client, *fakeServer, *httptest.Server) {
+
+	fake := &fakeServer{mandates: map[string]*Mandate{}}
+
+	mux := http.NewServeMux()
+	mux.Handle(MandatesPath, fake)
+	mux.Handle(MandatesPath+"/", fake)
+	ts := httptest.NewServer(mux)
+
+	core, err := accounts.NewClient(accounts.WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return NewClient(core), fake, ts
+}
+
+func generateMandate(t *testing.T) *Mandate {
+
+	beneficiary := &accounts.Data{ID: beneficiaryId, OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c", Attributes: &accounts.AccountAttributes{
+		AccountNumber: "41426819", BankID: "400300", BankIDCode: "GBDSC", Name: []string{"Gym Ltd"},
+	}}
+
+	payer := &accounts.Data{Attributes: &accounts.AccountAttributes{
+		AccountNumber: "71268996", BankID: "200000", BankIDCode: "GBDSC", Name: []string{"Samantha Holder"},
+	}}
+
+	mandate, err := New(mandateId, beneficiary, payer, SchemeBacs, "GYM-000123")
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return mandate
+}
+
+func TestMandateLifecycle_CreateListAndCancel(t *testing.T) {
+
+	// Arrange
+
+	client, _, ts := newTestClient(t)
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	if _, err := client.Create(ctx, generateMandate(t)); err != nil {
+		t.Fatalf("create returned an error: got %v want %v", err, nil)
+	}
+
+	// Act
+
+	listed, listErr := client.ListForAccount(ctx, uuid.MustParse(beneficiaryId), nil)
+	cancelled, cancelErr := client.Cancel(ctx, uuid.MustParse(mandateId), "customer request")
+
+	// Assert
+
+	if listErr != nil || len(listed.Data) != 1 || listed.Data[0].Attributes.PayerParty.AccountNumber != "71268996" {
+		t.Errorf("list returned unexpected mandates: got %+v, %v", listed, listErr)
+	}
+
+	if cancelErr != nil {
+		t.Fatalf("cancel returned an error: got %v want %v", cancelErr, nil)
+	}
+
+	if cancelled.Data.Attributes.Status != StatusCancelled || cancelled.Data.Attributes.StatusReason != "customer request" || cancelled.Data.Version != 1 {
+		t.Errorf("cancel returned unexpected mandate: got %+v", cancelled.Data.Attributes)
+	}
+
+	attributes := cancelled.Data.Attributes
+
+	if attributes.PayerParty == nil || attributes.PayerParty.AccountNumber != "71268996" || attributes.Reference != "GYM-000123" || attributes.Scheme != SchemeBacs {
+		t.Errorf("cancel wiped unexpected attributes: got %+v", attributes)
+	}
+}
+
+func TestCancel_CancelledMandate_ReturnsTransitionError(t *testing.T) {
+
+	// Arrange
+
+	client, fake, ts := newTestClient(t)
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	mandate := generateMandate(t)
+	mandate.Attributes.Status = StatusCancelled
+
+	fake.mandates[mandateId] = mandate
+
+	// Act
+
+	response, err := client.Cancel(ctx, uuid.MustParse(mandateId), "customer request")
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	var transitionErr *TransitionError
+
+	if !errors.As(err, &transitionErr) || !errors.Is(err, InvalidTransitionError) {
+		t.Fatalf("cancel returned unexpected error: got %v want %v", err, InvalidTransitionError)
+	}
+
+	if transitionErr.From != StatusCancelled || transitionErr.To != StatusCancelled {
+		t.Errorf("unexpected transition: got %s to %s", transitionErr.From, transitionErr.To)
+	}
+
+	if fake.patches != 0 {
+		t.Errorf("server received unexpected number of updates: got %d want %d", fake.patches, 0)
+	}
+}
+
+func TestCreateValidationCases(t *testing.T) {
+
+	// Arrange
+
+	client, _, ts := newTestClient(t)
+	defer ts.Close()
+
+	cases := map[string]func(m *Mandate){
+		"Missing account relationship": func(m *Mandate) { m.Relationships = nil },
+		"Missing payer":                func(m *Mandate) { m.Attributes.PayerParty = nil },
+		"Unknown scheme":               func(m *Mandate) { m.Attributes.Scheme = "FPS" },
+	}
+
+	for name, mutate := range cases {
+
+		mandate := generateMandate(t)
+		mutate(mandate)
+
+		// Act
+
+		response, err := client.Create(context.Background(), mandate)
+
+		// Assert
+
+		if response != nil || !errors.Is(err, accounts.ValidationError) {
+			t.Errorf("%s: unexpected result: got %v, %v want %v", name, response, err, accounts.ValidationError)
+		}
+	}
+}
+
+func TestNew_NilAccount_ReturnsValidationError(t *testing.T) {
+
+	beneficiary := &accounts.Data{ID: beneficiaryId, Attributes: &accounts.AccountAttributes{AccountNumber: "41426819"}}
+
+	cases := map[string]struct {
+		beneficiary *accounts.Data
+		payer       *accounts.Data
+	}{
+		"Nil beneficiary": {payer: beneficiary},
+		"Nil payer":       {beneficiary: beneficiary},
+	}
+
+	for name, testCase := range cases {
+
+		// Act
+
+		mandate, err := New(mandateId, testCase.beneficiary, testCase.payer, SchemeBacs, "GYM-000123")
+
+		// Assert
+
+		if mandate != nil || !errors.Is(err, accounts.ValidationError) {
+			t.Errorf("%s: unexpected result: got %v, %v want %v", name, mandate, err, accounts.ValidationError)
+		}
+	}
+}
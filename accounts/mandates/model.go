@@ -0,0 +1,79 @@
+package mandates
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/payments"
+	"time"
+)
+
+// RelationshipAccount names the relationship linking a mandate to the account collecting its direct debits
+const RelationshipAccount = "beneficiary_account"
+
+// Direct debit schemes
+const (
+	SchemeBacs   = "Bacs"
+	SchemeSEPADD = "SEPADD"
+)
+
+// Mandate authorises the beneficiary to collect direct debits from the payer account
+type Mandate struct {
+	Attributes     *MandateAttributes     `json:"attributes"`
+	CreatedOn      time.Time              `json:"created_on,omitempty"`
+	ID             string                 `json:"id"`
+	ModifiedOn     time.Time              `json:"modified_on,omitempty"`
+	OrganisationID string                 `json:"organisation_id"`
+	Relationships  accounts.Relationships `json:"relationships,omitempty"`
+	Type           string                 `json:"type"`
+	Version        int                    `json:"version"`
+}
+
+// MandateAttributes holds the details of a mandate. Every attribute is omitted when empty, so that a PATCH only
+// changes the attributes it sets
+type MandateAttributes struct {
+	BeneficiaryParty *payments.Party `json:"beneficiary_party,omitempty"`
+	PayerParty       *payments.Party `json:"payer_party,omitempty"`
+	Reference        string          `json:"reference,omitempty"`
+	Scheme           string          `json:"scheme,omitempty"`
+	Status           Status          `json:"status,omitempty"`
+	StatusReason     string          `json:"status_reason,omitempty"`
+	// SignatureDate uses the YYYY-MM-DD layout
+	SignatureDate string `json:"signature_date,omitempty"`
+}
+
+// AccountID returns the id of the account linked to the mandate, or an empty string when none is
+func (m *Mandate) AccountID() string {
+
+	relationship := m.Relationships[RelationshipAccount]
+
+	if relationship == nil || len(relationship.Data) == 0 {
+		return ""
+	}
+
+	return relationship.Data[0].ID
+}
+
+// New builds a pending mandate collecting from the payer account into the given beneficiary account,
+// which is linked by relationship. Both parties reuse the account identifiers. A nil account fails with
+// accounts.ValidationError
+func New(id string, beneficiary *accounts.Data, payer *accounts.Data, scheme string, reference string) (*Mandate, error) {
+
+	if beneficiary == nil || payer == nil {
+		return nil, validationError("beneficiary and payer accounts are required")
+	}
+
+	return &Mandate{
+		ID:             id,
+		OrganisationID: beneficiary.OrganisationID,
+		Type:           mandateType,
+		Attributes: &MandateAttributes{
+			BeneficiaryParty: payments.PartyFromAccount(beneficiary),
+			PayerParty:       payments.PartyFromAccount(payer),
+			Reference:        reference,
+			Scheme:           scheme,
+			Status:           StatusPending,
+		},
+		Relationships: accounts.Relationships{
+			RelationshipAccount: {Data: []accounts.ResourceIdentifier{{ID: beneficiary.ID, Type: "accounts"}}},
+		},
+	}, nil
+}
@@ -0,0 +1,79 @@
+package mandates
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Status is the lifecycle status of a mandate
+type Status string
+
+// Mandate statuses
+const (
+	StatusPending   Status = "pending"
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+	StatusCancelled Status = "cancelled"
+	StatusExpired   Status = "expired"
+	StatusFailed    Status = "failed"
+)
+
+// transitions lists the statuses a mandate may move to from each status
+var transitions = map[Status][]Status{
+	StatusPending:   {StatusActive, StatusCancelled, StatusFailed},
+	StatusActive:    {StatusSuspended, StatusCancelled, StatusExpired},
+	StatusSuspended: {StatusActive, StatusCancelled},
+}
+
+// Terminal reports whether the status will not change anymore
+func (s Status) Terminal() bool {
+	return len(transitions[s]) == 0
+}
+
+// CanTransitionTo reports whether a mandate may move from s to next
+func (s Status) CanTransitionTo(next Status) bool {
+
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InvalidTransitionError is wrapped by every TransitionError
+var InvalidTransitionError = errors.New("Invalid mandate status transition")
+
+// TransitionError is returned when a mandate is asked to move to a status its current status does not allow
+type TransitionError struct {
+	MandateID string
+	From      Status
+	To        Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s | %d | mandate %s cannot move from %s to %s", InvalidTransitionError, http.StatusConflict, e.MandateID, e.From, e.To)
+}
+
+// Unwrap lets errors.Is match InvalidTransitionError
+func (e *TransitionError) Unwrap() error {
+	return InvalidTransitionError
+}
+
+// checkTransition returns a TransitionError unless the mandate may move to next
+func checkTransition(mandate *Mandate, next Status) error {
+
+	var current Status
+
+	if mandate.Attributes != nil {
+		current = mandate.Attributes.Status
+	}
+
+	if !current.CanTransitionTo(next) {
+		return &TransitionError{MandateID: mandate.ID, From: current, To: next}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package mirror
+
+import (
+	"database/sql/driver"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Record is the local copy of an account as stored in the mirrored_accounts table
+type Record struct {
+	ID             string     `gorm:"primary_key;type:uuid"`
+	OrganisationID string     `gorm:"type:uuid;index"`
+	Type           string     `gorm:"type:varchar(64)"`
+	Version        int        `gorm:"not null"`
+	CreatedOn      time.Time  `gorm:"not null"`
+	ModifiedOn     time.Time  `gorm:"not null;index"`
+	Attributes     Attributes `gorm:"type:jsonb"`
+	SyncedAt       time.Time  `gorm:"not null"`
+	RemovedAt      *time.Time `gorm:"index"`
+}
+
+func (*Record) TableName() string {
+	return "mirrored_accounts"
+}
+
+// Data converts the record back to the API representation
+func (r *Record) Data() *accounts.Data {
+
+	attributes := accounts.AccountAttributes(r.Attributes)
+
+	return &accounts.Data{
+		Attributes:     &attributes,
+		CreatedOn:      r.CreatedOn,
+		ID:             r.ID,
+		ModifiedOn:     r.ModifiedOn,
+		OrganisationID: r.OrganisationID,
+		Type:           r.Type,
+		Version:        r.Version,
+	}
+}
+
+func newRecord(data *accounts.Data, syncedAt time.Time) *Record {
+
+	record := &Record{
+		ID:             data.ID,
+		OrganisationID: data.OrganisationID,
+		Type:           data.Type,
+		Version:        data.Version,
+		CreatedOn:      data.CreatedOn,
+		ModifiedOn:     data.ModifiedOn,
+		SyncedAt:       syncedAt,
+	}
+
+	if data.Attributes != nil {
+		record.Attributes = Attributes(*data.Attributes)
+	}
+
+	return record
+}
+
+// Attributes stores the account attributes as a jsonb column
+type Attributes accounts.AccountAttributes
+
+// Value Marshal
+func (a Attributes) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// Scan Unmarshal
+func (a *Attributes) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &a)
+}
+
+// SyncState holds the watermark of the last completed sync of a scope, stored in the mirror_sync_state table.
+// Each filter has its own scope so that filtered syncs do not move each other's watermarks
+type SyncState struct {
+	ID         int    `gorm:"primary_key"`
+	Scope      string `gorm:"not null;default:'';unique_index"`
+	ModifiedOn time.Time
+	SyncedAt   time.Time
+}
+
+func (*SyncState) TableName() string {
+	return "mirror_sync_state"
+}
@@ -0,0 +1,177 @@
+package mirror
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Store persists the mirrored accounts and the sync watermark
+type Store interface {
+
+	// Migrate creates or updates the mirror schema
+	Migrate(ctx context.Context) error
+
+	// State returns the watermark of the last completed sync of scope, or a zero SyncState if none completed yet
+	State(ctx context.Context, scope string) (*SyncState, error)
+
+	// SaveState stores the watermark of a completed sync
+	SaveState(ctx context.Context, state *SyncState) error
+
+	// Versions returns the version of every account not marked as removed and matching filter, keyed by account id.
+	// filter holds List filters, e.g. {"organisation_id": "..."} or {"bank_id": "400300"}, and an empty filter
+	// matches every account
+	Versions(ctx context.Context, filter map[string]string) (map[string]int, error)
+
+	// Upsert inserts or replaces the given records, clearing their removal mark
+	Upsert(ctx context.Context, records []*Record) error
+
+	// MarkRemoved flags the given accounts as deleted upstream
+	MarkRemoved(ctx context.Context, ids []string, removedAt time.Time) error
+}
+
+// GormStore is a Store backed by a PostgreSQL database through gorm
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore constructs a GormStore using the given connection
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+// Migrate creates the mirrored_accounts and mirror_sync_state tables
+func (s *GormStore) Migrate(_ context.Context) error {
+	return s.db.AutoMigrate(&Record{}, &SyncState{}).Error
+}
+
+// State returns the stored sync watermark of scope
+func (s *GormStore) State(_ context.Context, scope string) (*SyncState, error) {
+
+	state := &SyncState{}
+
+	err := s.db.Where("scope = ?", scope).First(state).Error
+
+	if gorm.IsRecordNotFoundError(err) {
+		return &SyncState{Scope: scope}, nil
+	}
+
+	return state, err
+}
+
+// SaveState stores the sync watermark of the state scope
+func (s *GormStore) SaveState(_ context.Context, state *SyncState) error {
+	return s.db.Save(state).Error
+}
+
+// Versions returns the version of every live mirrored account matching filter. organisation_id is matched
+// against its column and the other filters against the attribute with the same json name
+func (s *GormStore) Versions(_ context.Context, filter map[string]string) (map[string]int, error) {
+
+	var rows []struct {
+		ID      string
+		Version int
+	}
+
+	query := s.db.Model(&Record{}).Select("id, version").Where("removed_at IS NULL")
+
+	for name, value := range filter {
+		if name == "organisation_id" {
+			query = query.Where("organisation_id = ?", value)
+			continue
+		}
+		query = query.Where("attributes->>? = ?", name, value)
+	}
+
+	err := query.Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]int, len(rows))
+
+	for _, row := range rows {
+		versions[row.ID] = row.Version
+	}
+
+	return versions, nil
+}
+
+// Upsert inserts or replaces the given records in a single transaction
+func (s *GormStore) Upsert(_ context.Context, records []*Record) error {
+
+	return s.transaction(func(tx *gorm.DB) error {
+		for _, record := range records {
+			if err := tx.Save(record).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MarkRemoved flags the given accounts as deleted upstream, keeping their last known state
+func (s *GormStore) MarkRemoved(_ context.Context, ids []string, removedAt time.Time) error {
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.db.Model(&Record{}).Where("id IN (?)", ids).Update("removed_at", removedAt).Error
+}
+
+// Get returns the mirrored account with the given id, including accounts marked as removed
+func (s *GormStore) Get(_ context.Context, id string) (*Record, error) {
+
+	record := &Record{}
+
+	if err := s.db.Where("id = ?", id).First(record).Error; err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAccounts returns the live mirrored accounts whose attributes equal the given values, keyed by their json
+// name, e.g. {"iban": "GB11NWBK40030041426819"}
+func (s *GormStore) FindAccounts(_ context.Context, attributes map[string]string) ([]*accounts.Data, error) {
+
+	query := s.db.Where("removed_at IS NULL")
+
+	for name, value := range attributes {
+		query = query.Where("attributes->>? = ?", name, value)
+	}
+
+	var records []*Record
+
+	if err := query.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	found := make([]*accounts.Data, 0, len(records))
+
+	for _, record := range records {
+		found = append(found, record.Data())
+	}
+
+	return found, nil
+}
+
+func (s *GormStore) transaction(fn func(tx *gorm.DB) error) error {
+
+	tx := s.db.Begin()
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
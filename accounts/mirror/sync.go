@@ -0,0 +1,177 @@
+// Package mirror keeps a local PostgreSQL copy of the accounts exposed by the Form3 API
+package mirror
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"net/url"
+	"time"
+)
+
+// Lister is the subset of the accounts client used to walk the accounts collection
+type Lister interface {
+	List(ctx context.Context, options *accounts.ListOptions) (*accounts.AccountListResponse, error)
+}
+
+// SyncResult summarises a completed sync
+type SyncResult struct {
+	Pages     int
+	Seen      int
+	Upserted  int
+	Unchanged int
+	Removed   int
+	Watermark time.Time
+}
+
+// Syncer copies accounts from the API into a Store
+type Syncer struct {
+	lister   Lister
+	store    Store
+	pageSize int
+	filter   map[string]string
+	now      func() time.Time
+}
+
+// SyncerOption is the type of constructor options for NewSyncer(...)
+type SyncerOption func(*Syncer)
+
+// WithPageSize sets the page size used when listing accounts
+func WithPageSize(pageSize int) SyncerOption {
+	return func(s *Syncer) {
+		s.pageSize = pageSize
+	}
+}
+
+// WithFilter restricts the synced accounts using List filters, e.g. {"organisation_id": "..."}.
+// Removal detection only considers the mirrored accounts matching the filter, and the filter keeps its own
+// watermark, apart from the unfiltered sync and other filters
+func WithFilter(filter map[string]string) SyncerOption {
+	return func(s *Syncer) {
+		s.filter = filter
+	}
+}
+
+// NewSyncer constructs a Syncer reading from lister and writing into store
+func NewSyncer(lister Lister, store Store, options ...SyncerOption) *Syncer {
+
+	s := &Syncer{
+		lister:   lister,
+		store:    store,
+		pageSize: accounts.DefaultPageSize,
+		now:      time.Now,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// Sync walks every page of accounts and brings the store up to date. Accounts are only written when their
+// version is higher than the mirrored one or they were modified after the last watermark. Mirrored accounts
+// no longer returned by the API are marked as removed once the walk completes
+func (s *Syncer) Sync(ctx context.Context) (*SyncResult, error) {
+
+	state, err := s.store.State(ctx, Scope(s.filter))
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.store.Versions(ctx, s.filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	syncedAt := s.now()
+	result := &SyncResult{Watermark: state.ModifiedOn}
+	seen := make(map[string]bool, len(versions))
+
+	for pageNumber := 0; ; pageNumber++ {
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		page, err := s.lister.List(ctx, &accounts.ListOptions{
+			PageNumber: pageNumber,
+			PageSize:   s.pageSize,
+			Filter:     s.filter,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Pages++
+
+		var changed []*Record
+
+		for _, data := range page.Data {
+
+			seen[data.ID] = true
+			result.Seen++
+
+			mirroredVersion, mirrored := versions[data.ID]
+
+			if mirrored && data.Version <= mirroredVersion && !data.ModifiedOn.After(state.ModifiedOn) {
+				result.Unchanged++
+				continue
+			}
+
+			changed = append(changed, newRecord(data, syncedAt))
+
+			if data.ModifiedOn.After(result.Watermark) {
+				result.Watermark = data.ModifiedOn
+			}
+		}
+
+		if len(changed) > 0 {
+			if err := s.store.Upsert(ctx, changed); err != nil {
+				return nil, err
+			}
+			result.Upserted += len(changed)
+		}
+
+		if !page.HasNext() || len(page.Data) == 0 {
+			break
+		}
+	}
+
+	var removed []string
+
+	for id := range versions {
+		if !seen[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	if err := s.store.MarkRemoved(ctx, removed, syncedAt); err != nil {
+		return nil, err
+	}
+
+	result.Removed = len(removed)
+
+	state.ModifiedOn = result.Watermark
+	state.SyncedAt = syncedAt
+
+	if err := s.store.SaveState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Scope returns the SyncState scope of a filter: its sorted, url encoded values, or "" when there is no filter
+func Scope(filter map[string]string) string {
+
+	values := url.Values{}
+
+	for name, value := range filter {
+		values.Set(name, value)
+	}
+
+	return values.Encode()
+}
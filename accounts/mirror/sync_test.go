@@ -0,0 +1,257 @@
+package mirror
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"testing"
+	"time"
+)
+
+type memoryStore struct {
+	records map[string]*Record
+	states  map[string]*SyncState
+	writes  int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[string]*Record{}, states: map[string]*SyncState{}}
+}
+
+func (m *memoryStore) Migrate(context.Context) error { return nil }
+
+func (m *memoryStore) State(_ context.Context, scope string) (*SyncState, error) {
+	if state, found := m.states[scope]; found {
+		copied := *state
+		return &copied, nil
+	}
+	return &SyncState{Scope: scope}, nil
+}
+
+func (m *memoryStore) SaveState(_ context.Context, state *SyncState) error {
+	m.states[state.Scope] = state
+	return nil
+}
+
+func (m *memoryStore) Versions(_ context.Context, filter map[string]string) (map[string]int, error) {
+	versions := map[string]int{}
+	for id, record := range m.records {
+		if record.RemovedAt == nil && matches(record, filter) {
+			versions[id] = record.Version
+		}
+	}
+	return versions, nil
+}
+
+// matches mimics GormStore.Versions for the organisation_id and bank_id filters
+func matches(record *Record, filter map[string]string) bool {
+	for name, value := range filter {
+		switch {
+		case name == "organisation_id" && record.OrganisationID != value:
+			return false
+		case name == "bank_id" && record.Attributes.BankID != value:
+			return false
+		}
+	}
+	return true
+}
+
+func (m *memoryStore) Upsert(_ context.Context, records []*Record) error {
+	for _, record := range records {
+		m.records[record.ID] = record
+		m.writes++
+	}
+	return nil
+}
+
+func (m *memoryStore) MarkRemoved(_ context.Context, ids []string, removedAt time.Time) error {
+	for _, id := range ids {
+		m.records[id].RemovedAt = &removedAt
+	}
+	return nil
+}
+
+type pagedLister struct {
+	accounts []*accounts.Data
+	requests []*accounts.ListOptions
+}
+
+func (p *pagedLister) List(_ context.Context, options *accounts.ListOptions) (*accounts.AccountListResponse, error) {
+
+	p.requests = append(p.requests, options)
+
+	start := options.PageNumber * options.PageSize
+	end := start + options.PageSize
+
+	if start > len(p.accounts) {
+		start = len(p.accounts)
+	}
+
+	if end > len(p.accounts) {
+		end = len(p.accounts)
+	}
+
+	links := &accounts.ListLinks{}
+
+	if end < len(p.accounts) {
+		links.Next = "next"
+	}
+
+	return &accounts.AccountListResponse{Data: p.accounts[start:end], Links: links}, nil
+}
+
+func account(id string, version int, modifiedOn time.Time) *accounts.Data {
+	return &accounts.Data{
+		ID:             id,
+		Version:        version,
+		ModifiedOn:     modifiedOn,
+		OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		Type:           "accounts",
+		Attributes:     &accounts.AccountAttributes{Country: "GB", BankID: "400300"},
+	}
+}
+
+func TestSync_EmptyStore_CopiesEveryPage(t *testing.T) {
+
+	// Arrange
+
+	modifiedOn := time.Date(2021, 7, 31, 22, 9, 2, 0, time.UTC)
+
+	lister := &pagedLister{accounts: []*accounts.Data{
+		account("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", 0, modifiedOn),
+		account("b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d", 0, modifiedOn.Add(time.Hour)),
+		account("c6d2f2a4-2c7d-4c9b-8e2a-3f4a5b6c7d8e", 0, modifiedOn),
+	}}
+
+	store := newMemoryStore()
+
+	syncer := NewSyncer(lister, store, WithPageSize(2))
+
+	// Act
+
+	result, err := syncer.Sync(context.Background())
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("sync returned an error: got %v want %v", err, nil)
+	}
+
+	if result.Pages != 2 {
+		t.Errorf("sync walked unexpected number of pages: got %d want %d", result.Pages, 2)
+	}
+
+	if result.Upserted != 3 || len(store.records) != 3 {
+		t.Errorf("sync stored unexpected number of accounts: got %d want %d", len(store.records), 3)
+	}
+
+	if !store.states[""].ModifiedOn.Equal(modifiedOn.Add(time.Hour)) {
+		t.Errorf("sync saved unexpected watermark: got %s want %s", store.states[""].ModifiedOn, modifiedOn.Add(time.Hour))
+	}
+
+	if store.records["ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"].Attributes.BankID != "400300" {
+		t.Errorf("sync stored unexpected attributes: got %+v", store.records["ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"].Attributes)
+	}
+}
+
+func TestSync_SecondRun_OnlyWritesChangedAccountsAndMarksRemovals(t *testing.T) {
+
+	// Arrange
+
+	modifiedOn := time.Date(2021, 7, 31, 22, 9, 2, 0, time.UTC)
+
+	lister := &pagedLister{accounts: []*accounts.Data{
+		account("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", 0, modifiedOn),
+		account("b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d", 0, modifiedOn),
+		account("c6d2f2a4-2c7d-4c9b-8e2a-3f4a5b6c7d8e", 0, modifiedOn),
+	}}
+
+	store := newMemoryStore()
+
+	syncer := NewSyncer(lister, store, WithPageSize(2))
+
+	if _, err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	lister.accounts = []*accounts.Data{
+		account("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", 0, modifiedOn),
+		account("b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d", 1, modifiedOn.Add(time.Minute)),
+	}
+
+	store.writes = 0
+
+	// Act
+
+	result, err := syncer.Sync(context.Background())
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("sync returned an error: got %v want %v", err, nil)
+	}
+
+	if store.writes != 1 || result.Unchanged != 1 {
+		t.Errorf("sync wrote unexpected number of accounts: got %d want %d", store.writes, 1)
+	}
+
+	if store.records["b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d"].Version != 1 {
+		t.Errorf("sync kept a stale version: got %d want %d", store.records["b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d"].Version, 1)
+	}
+
+	if result.Removed != 1 || store.records["c6d2f2a4-2c7d-4c9b-8e2a-3f4a5b6c7d8e"].RemovedAt == nil {
+		t.Errorf("sync did not mark the deleted account as removed")
+	}
+}
+
+func TestSync_FilteredAfterFull_OnlyConsidersMatchingAccounts(t *testing.T) {
+
+	// Arrange
+
+	modifiedOn := time.Date(2021, 7, 31, 22, 9, 2, 0, time.UTC)
+
+	other := account("c6d2f2a4-2c7d-4c9b-8e2a-3f4a5b6c7d8e", 0, modifiedOn.Add(time.Hour))
+	other.Attributes = &accounts.AccountAttributes{Country: "GB", BankID: "400301"}
+
+	lister := &pagedLister{accounts: []*accounts.Data{
+		account("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", 0, modifiedOn),
+		account("b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d", 0, modifiedOn),
+		other,
+	}}
+
+	store := newMemoryStore()
+
+	if _, err := NewSyncer(lister, store).Sync(context.Background()); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	filter := map[string]string{"bank_id": "400300"}
+
+	// the fake lister ignores filters, so serve the accounts the API would return, one of them now deleted
+	lister.accounts = []*accounts.Data{account("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", 0, modifiedOn)}
+
+	// Act
+
+	result, err := NewSyncer(lister, store, WithFilter(filter)).Sync(context.Background())
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("sync returned an error: got %v want %v", err, nil)
+	}
+
+	if result.Removed != 1 || store.records["b5c1e1f3-1b6c-4b8a-9d1f-2e3f4a5b6c7d"].RemovedAt == nil {
+		t.Errorf("sync did not mark the deleted matching account as removed")
+	}
+
+	if store.records[other.ID].RemovedAt != nil {
+		t.Errorf("sync marked an account outside the filter as removed")
+	}
+
+	if len(store.states) != 2 || !store.states[""].ModifiedOn.Equal(modifiedOn.Add(time.Hour)) {
+		t.Errorf("unexpected sync states: got %v want the full sync watermark kept apart", store.states)
+	}
+
+	if !store.states[Scope(filter)].ModifiedOn.Equal(modifiedOn) {
+		t.Errorf("unexpected filtered watermark: got %s want %s", store.states[Scope(filter)].ModifiedOn, modifiedOn)
+	}
+}
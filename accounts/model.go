@@ -10,6 +10,7 @@ type AccountResponse struct {
 	apiCommonResult
 }
 
+// AccountData is the JSON:API document holding an account
 type AccountData struct {
 	Data  *Data  `json:"data" gorm:"type:data"`
 	Links *Links `json:"links" gorm:"type:links"`
@@ -32,15 +33,48 @@ type Data struct {
 
 type AccountAttributes struct {
 	AccountClassification string   `json:"account_classification" gorm:"type:account_classification"`
+	AccountMatchingOptOut bool     `json:"account_matching_opt_out,omitempty" gorm:"type:account_matching_opt_out"`
+	AccountNumber         string   `json:"account_number,omitempty" gorm:"type:account_number"`
 	AlternativeNames      []string `json:"alternative_names" gorm:"type:alternative_names"`
 	BankID                string   `json:"bank_id" gorm:"type:bank_id"`
 	BankIDCode            string   `json:"bank_id_code" gorm:"type:bank_id_code"`
 	BaseCurrency          string   `json:"base_currency" gorm:"type:base_currency"`
 	Bic                   string   `json:"bic" gorm:"type:bic"`
 	Country               string   `json:"country" gorm:"type:country"`
+	Iban                  string   `json:"iban,omitempty" gorm:"type:iban"`
 	Name                  []string `json:"name" gorm:"type:name"`
 }
 
 type Links struct {
 	Self string `json:"self" gorm:"type:self"`
 }
+
+// accountsResource returns the Resource serving the accounts collection
+func (c *Client) accountsResource() *Resource[Data] {
+	return &Resource[Data]{client: c, config: AccountsApiDefaultUrl}
+}
+
+// accountDocument builds the request document of accountData on a copy of its data, so the client can complete
+// the document without changing the caller's account
+func accountDocument(accountData *AccountData) *Document[Data] {
+
+	if accountData == nil {
+		return &Document[Data]{}
+	}
+
+	document := &Document[Data]{Links: accountData.Links}
+
+	if accountData.Data != nil {
+		data := *accountData.Data
+		document.Data = &data
+	}
+
+	return document
+}
+
+func newAccountResponse(response *Response[Data]) *AccountResponse {
+	return &AccountResponse{
+		AccountData:     &AccountData{Data: response.Data, Links: response.Links},
+		apiCommonResult: response.apiCommonResult,
+	}
+}
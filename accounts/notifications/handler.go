@@ -0,0 +1,221 @@
+package notifications
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"ei09010/form3-api-client/accounts"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Default values used by the webhook handler
+const (
+	DefaultSignatureHeader = "X-Form3-Signature"
+	DefaultMaxBodyBytes    = 1 << 20
+	DefaultDedupeTTL       = 24 * time.Hour
+)
+
+// HandlerFunc processes a verified event. Returning an error answers the callback with a 500 so the event is retried
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// AccountHandlerFunc processes a verified accounts event along with its decoded account
+type AccountHandlerFunc func(ctx context.Context, event *Event, account *accounts.Data) error
+
+// Deduper remembers the ids of processed events so retried deliveries are acknowledged without being dispatched again
+type Deduper interface {
+
+	// MarkIfAbsent remembers id and reports true, or reports false when id is already remembered. It must be
+	// atomic so that concurrent deliveries of the same event are dispatched once
+	MarkIfAbsent(id string) bool
+
+	// Unmark forgets id, so that a retried delivery of an event whose dispatch failed is dispatched again
+	Unmark(id string)
+}
+
+// Handler is an http.Handler receiving webhook callbacks. It verifies the HMAC-SHA256 signature of the body,
+// drops events already processed and dispatches the others to the functions registered for their record and event type
+type Handler struct {
+	secret          []byte
+	signatureHeader string
+	deduper         Deduper
+
+	mu       sync.RWMutex
+	handlers map[string][]HandlerFunc
+}
+
+// HandlerOption is the type of constructor options for NewHandler(...)
+type HandlerOption func(*Handler)
+
+// WithSignatureHeader sets the header holding the hex encoded signature of the body
+func WithSignatureHeader(header string) HandlerOption {
+	return func(h *Handler) {
+		h.signatureHeader = header
+	}
+}
+
+// WithDeduper replaces the in-memory deduper, e.g. with one shared between replicas
+func WithDeduper(deduper Deduper) HandlerOption {
+	return func(h *Handler) {
+		h.deduper = deduper
+	}
+}
+
+// NewHandler constructs a Handler verifying callbacks with secret
+func NewHandler(secret []byte, options ...HandlerOption) *Handler {
+
+	h := &Handler{
+		secret:          secret,
+		signatureHeader: DefaultSignatureHeader,
+		deduper:         NewMemoryDeduper(DefaultDedupeTTL),
+		handlers:        map[string][]HandlerFunc{},
+	}
+
+	for _, option := range options {
+		option(h)
+	}
+
+	return h
+}
+
+// Handle registers fn for the events of recordType and eventType. An empty eventType matches every event type
+func (h *Handler) Handle(recordType string, eventType string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := recordType + "/" + eventType
+	h.handlers[key] = append(h.handlers[key], fn)
+}
+
+// HandleAccounts registers fn for the accounts events of eventType, decoding the account before calling it
+func (h *Handler) HandleAccounts(eventType string, fn AccountHandlerFunc) {
+	h.Handle(RecordTypeAccounts, eventType, func(ctx context.Context, event *Event) error {
+
+		account, err := event.Account()
+
+		if err != nil {
+			return err
+		}
+
+		return fn(ctx, event, account)
+	})
+}
+
+// ServeHTTP verifies, dedupes and dispatches a callback
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, DefaultMaxBodyBytes))
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !h.verify(body, r.Header.Get(h.signatureHeader)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	event := &Event{}
+
+	if err := json.Unmarshal(body, event); err != nil || event.ID == "" || event.Attributes == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !h.deduper.MarkIfAbsent(event.ID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	for _, fn := range h.matching(event) {
+		if err := fn(r.Context(), event); err != nil {
+			h.deduper.Unmark(event.ID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) matching(event *Event) []HandlerFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var matching []HandlerFunc
+
+	matching = append(matching, h.handlers[event.Attributes.RecordType+"/"+event.Attributes.EventType]...)
+	matching = append(matching, h.handlers[event.Attributes.RecordType+"/"]...)
+
+	return matching
+}
+
+func (h *Handler) verify(body []byte, signature string) bool {
+
+	received, err := hex.DecodeString(signature)
+
+	if err != nil || len(received) == 0 {
+		return false
+	}
+
+	return hmac.Equal(received, sign(h.secret, body))
+}
+
+func sign(secret []byte, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// MemoryDeduper is an in-process Deduper forgetting event ids after a ttl
+type MemoryDeduper struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	seen map[string]time.Time
+}
+
+// NewMemoryDeduper constructs a MemoryDeduper remembering event ids for ttl
+func NewMemoryDeduper(ttl time.Duration) *MemoryDeduper {
+	return &MemoryDeduper{ttl: ttl, seen: map[string]time.Time{}}
+}
+
+// MarkIfAbsent remembers id unless it was marked within the ttl, dropping the expired ids
+func (d *MemoryDeduper) MarkIfAbsent(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+
+	for seenId, markedAt := range d.seen {
+		if now.Sub(markedAt) >= d.ttl {
+			delete(d.seen, seenId)
+		}
+	}
+
+	if _, ok := d.seen[id]; ok {
+		return false
+	}
+
+	d.seen[id] = now
+
+	return true
+}
+
+// Unmark forgets id
+func (d *MemoryDeduper) Unmark(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.seen, id)
+}
@@ -0,0 +1,252 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func sampleAccount() *accounts.Data {
+	return &accounts.Data{
+		ID:             "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		Type:           "accounts",
+		Attributes:     &accounts.AccountAttributes{Country: "GB", BankID: "400300", Name: []string{"Account Holder"}},
+	}
+}
+
+func TestHandler_SignedAccountEvent_IsDispatchedOnceAcrossRetries(t *testing.T) {
+
+	// Arrange
+
+	secret := []byte("webhook-secret")
+	handler := NewHandler(secret)
+
+	var received []*accounts.Data
+
+	handler.HandleAccounts(EventTypeCreated, func(ctx context.Context, event *Event, account *accounts.Data) error {
+		received = append(received, account)
+		return nil
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	event, err := NewAccountEvent(EventTypeCreated, sampleAccount())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx := context.Background()
+
+	// Act
+
+	first, err := PostEvent(ctx, nil, ts.URL, secret, event)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	retry, err := PostEvent(ctx, nil, ts.URL, secret, event)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Assert
+
+	if first.StatusCode != http.StatusOK || retry.StatusCode != http.StatusOK {
+		t.Errorf("handler returned unexpected statuses: got %d and %d want %d", first.StatusCode, retry.StatusCode, http.StatusOK)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("handler dispatched unexpected number of events: got %d want %d", len(received), 1)
+	}
+
+	if received[0].ID != "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc" || received[0].Attributes.BankID != "400300" {
+		t.Errorf("handler dispatched unexpected account: got %+v", received[0])
+	}
+}
+
+func TestHandlerErrorCases(t *testing.T) {
+
+	// Arrange
+
+	secret := []byte("webhook-secret")
+
+	event, _ := NewAccountEvent(EventTypeCreated, sampleAccount())
+
+	errorCases := map[string]struct {
+		secret         []byte
+		handlerErr     error
+		expectedStatus int
+	}{
+		"Invalid signature": {
+			secret:         []byte("another-secret"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"Failing handler asks for a retry": {
+			secret:         secret,
+			handlerErr:     errors.New("downstream unavailable"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, errCase := range errorCases {
+
+		handler := NewHandler(secret)
+
+		handler.Handle(RecordTypeAccounts, "", func(ctx context.Context, event *Event) error {
+			return errCase.handlerErr
+		})
+
+		ts := httptest.NewServer(handler)
+
+		// Act
+
+		response, err := PostEvent(context.Background(), nil, ts.URL, errCase.secret, event)
+
+		// Assert
+
+		if err != nil {
+			t.Errorf("%s: %s", name, err.Error())
+		} else if response.StatusCode != errCase.expectedStatus {
+			t.Errorf("%s: handler returned unexpected status: got %d want %d", name, response.StatusCode, errCase.expectedStatus)
+		}
+
+		ts.Close()
+	}
+}
+
+func TestHandler_MalformedSignedBody_Returns400(t *testing.T) {
+
+	// Arrange
+
+	secret := []byte("webhook-secret")
+	body := []byte(`{"id":`)
+
+	req := httptest.NewRequest(http.MethodPost, "/callbacks", bytes.NewReader(body))
+	req.Header.Set(DefaultSignatureHeader, Sign(secret, body))
+
+	recorder := httptest.NewRecorder()
+
+	// Act
+
+	NewHandler(secret).ServeHTTP(recorder, req)
+
+	// Assert
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("handler returned unexpected status: got %d want %d", recorder.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandler_ConcurrentDeliveries_AreDispatchedOnce(t *testing.T) {
+
+	// Arrange
+
+	secret := []byte("webhook-secret")
+	handler := NewHandler(secret)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	dispatched := 0
+
+	handler.HandleAccounts(EventTypeCreated, func(ctx context.Context, event *Event, account *accounts.Data) error {
+		dispatched++
+		close(entered)
+		<-release
+		return nil
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	event, err := NewAccountEvent(EventTypeCreated, sampleAccount())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx := context.Background()
+
+	firstDone := make(chan *http.Response)
+
+	go func() {
+		first, _ := PostEvent(ctx, nil, ts.URL, secret, event)
+		firstDone <- first
+	}()
+
+	<-entered
+
+	// Act
+
+	concurrent, err := PostEvent(ctx, nil, ts.URL, secret, event)
+
+	close(release)
+
+	first := <-firstDone
+
+	// Assert
+
+	if err != nil || concurrent.StatusCode != http.StatusOK || first == nil || first.StatusCode != http.StatusOK {
+		t.Fatalf("handler returned unexpected outcomes: got %v and %v", concurrent, err)
+	}
+
+	if dispatched != 1 {
+		t.Errorf("handler dispatched unexpected number of events: got %d want %d", dispatched, 1)
+	}
+}
+
+func TestHandler_FailedDispatch_IsDispatchedAgainOnRetry(t *testing.T) {
+
+	// Arrange
+
+	secret := []byte("webhook-secret")
+	handler := NewHandler(secret)
+
+	attempts := 0
+
+	handler.HandleAccounts(EventTypeCreated, func(ctx context.Context, event *Event, account *accounts.Data) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	event, err := NewAccountEvent(EventTypeCreated, sampleAccount())
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx := context.Background()
+
+	// Act
+
+	failed, failedErr := PostEvent(ctx, nil, ts.URL, secret, event)
+	retry, retryErr := PostEvent(ctx, nil, ts.URL, secret, event)
+
+	// Assert
+
+	if failedErr != nil || retryErr != nil {
+		t.Fatalf("unexpected errors: got %v and %v", failedErr, retryErr)
+	}
+
+	if failed.StatusCode != http.StatusInternalServerError || retry.StatusCode != http.StatusOK {
+		t.Errorf("handler returned unexpected statuses: got %d and %d want %d and %d", failed.StatusCode, retry.StatusCode, http.StatusInternalServerError, http.StatusOK)
+	}
+
+	if attempts != 2 {
+		t.Errorf("handler dispatched unexpected number of events: got %d want %d", attempts, 2)
+	}
+}
@@ -0,0 +1,65 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewAccountEvent builds a sample accounts event carrying account, for use with PostEvent in local tests
+func NewAccountEvent(eventType string, account *accounts.Data) (*Event, error) {
+
+	data, err := json.Marshal(account)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:             uuid.New().String(),
+		OrganisationID: account.OrganisationID,
+		Type:           "notifications",
+		CreatedOn:      time.Now().UTC(),
+		Attributes: &EventAttributes{
+			EventType:  eventType,
+			RecordType: RecordTypeAccounts,
+			Data:       data,
+		},
+	}, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of body expected by Handler
+func Sign(secret []byte, body []byte) string {
+	return hex.EncodeToString(sign(secret, body))
+}
+
+// PostEvent delivers event to a callback url the way the API does, signing the body with secret
+func PostEvent(ctx context.Context, httpClient *http.Client, url string, secret []byte, event *Event) (*http.Response, error) {
+
+	body, err := json.Marshal(event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DefaultSignatureHeader, Sign(secret, body))
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return httpClient.Do(req)
+}
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Record and event types used by subscriptions and events
+const (
+	RecordTypeAccounts = "accounts"
+	EventTypeCreated   = "created"
+	EventTypeUpdated   = "updated"
+	EventTypeDeleted   = "deleted"
+	TransportHTTP      = "http"
+)
+
+// Subscription registers a callback uri notified of the events of a record type
+type Subscription struct {
+	Attributes     *SubscriptionAttributes `json:"attributes"`
+	CreatedOn      time.Time               `json:"created_on,omitempty"`
+	ID             string                  `json:"id"`
+	ModifiedOn     time.Time               `json:"modified_on,omitempty"`
+	OrganisationID string                  `json:"organisation_id"`
+	Type           string                  `json:"type"`
+	Version        int                     `json:"version"`
+}
+
+// SubscriptionAttributes holds the details of a subscription
+type SubscriptionAttributes struct {
+	CallbackTransport string `json:"callback_transport"`
+	CallbackURI       string `json:"callback_uri"`
+	Deactivated       bool   `json:"deactivated,omitempty"`
+	EventType         string `json:"event_type"`
+	RecordType        string `json:"record_type"`
+	UserID            string `json:"user_id,omitempty"`
+}
+
+// Event is a notification delivered to a subscription callback
+type Event struct {
+	Attributes     *EventAttributes `json:"attributes"`
+	CreatedOn      time.Time        `json:"created_on,omitempty"`
+	ID             string           `json:"id"`
+	OrganisationID string           `json:"organisation_id"`
+	Type           string           `json:"type"`
+	Version        int              `json:"version"`
+}
+
+// EventAttributes holds the record an event refers to
+type EventAttributes struct {
+	EventType  string          `json:"event_type"`
+	RecordType string          `json:"record_type"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Account decodes the account carried by an accounts event
+func (e *Event) Account() (*accounts.Data, error) {
+
+	if e.Attributes == nil || e.Attributes.RecordType != RecordTypeAccounts {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, "event does not carry an account")
+	}
+
+	account := &accounts.Data{}
+
+	if err := json.Unmarshal(e.Attributes.Data, account); err != nil {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, err)
+	}
+
+	return account, nil
+}
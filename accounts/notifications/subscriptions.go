@@ -0,0 +1,76 @@
+// Package notifications registers Form3 notification subscriptions and receives their webhook callbacks
+package notifications
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Default values used by the subscriptions client
+const (
+	SubscriptionsPath = "/v1/notification/subscriptions"
+	subscriptionType  = "subscriptions"
+)
+
+// Client may be used to manage notification subscriptions
+type Client struct {
+	subscriptions *accounts.Resource[Subscription]
+}
+
+// NewClient constructs a subscriptions Client sending its requests through core. Circuit breaker endpoints are
+// prefixed with "subscriptions."
+func NewClient(core *accounts.Client) *Client {
+	return &Client{
+		subscriptions: accounts.NewResource[Subscription](core, "subscriptions", SubscriptionsPath),
+	}
+}
+
+// Create registers a subscription. A nil subscription fails with accounts.ValidationError
+func (c *Client) Create(ctx context.Context, subscription *Subscription) (*accounts.Response[Subscription], error) {
+
+	if subscription == nil {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, "subscription is required")
+	}
+
+	if subscription.Type == "" {
+		subscription.Type = subscriptionType
+	}
+
+	if subscription.Attributes != nil && subscription.Attributes.CallbackTransport == "" {
+		subscription.Attributes.CallbackTransport = TransportHTTP
+	}
+
+	return c.subscriptions.Create(ctx, &accounts.Document[Subscription]{Data: subscription})
+}
+
+// SubscribeToAccounts registers callbackURI for the given account event type, e.g. EventTypeCreated
+func (c *Client) SubscribeToAccounts(ctx context.Context, organisationId string, eventType string, callbackURI string) (*accounts.Response[Subscription], error) {
+	return c.Create(ctx, &Subscription{
+		ID:             uuid.New().String(),
+		OrganisationID: organisationId,
+		Attributes: &SubscriptionAttributes{
+			CallbackURI: callbackURI,
+			EventType:   eventType,
+			RecordType:  RecordTypeAccounts,
+		},
+	})
+}
+
+// Fetch retrieves the subscription with the given id
+func (c *Client) Fetch(ctx context.Context, subscriptionId uuid.UUID) (*accounts.Response[Subscription], error) {
+	return c.subscriptions.Fetch(ctx, subscriptionId.String())
+}
+
+// List retrieves a page of subscriptions
+func (c *Client) List(ctx context.Context, options *accounts.ListOptions) (*accounts.ListResponse[Subscription], error) {
+	return c.subscriptions.List(ctx, options)
+}
+
+// Delete removes the subscription with the given id and version
+func (c *Client) Delete(ctx context.Context, subscriptionId uuid.UUID, version int) error {
+	return c.subscriptions.Delete(ctx, subscriptionId.String(), version)
+}
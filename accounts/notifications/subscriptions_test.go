@@ -0,0 +1,89 @@
+package notifications
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubscribeToAccounts_sendsAccountsSubscription(t *testing.T) {
+
+	// Arrange
+
+	var received accounts.Document[Subscription]
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(SubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(bodyBytes, &received)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(bodyBytes)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	core, err := accounts.NewClient(accounts.WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	response, err := NewClient(core).SubscribeToAccounts(context.Background(), "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c", EventTypeCreated, "https://example.org/callbacks")
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("subscribe returned an error: got %v want %v", err, nil)
+	}
+
+	attributes := received.Data.Attributes
+
+	if received.Data.Type != "subscriptions" || attributes.RecordType != RecordTypeAccounts || attributes.CallbackTransport != TransportHTTP {
+		t.Errorf("server received unexpected subscription: got %+v", received.Data)
+	}
+
+	if response.Data.Attributes.CallbackURI != "https://example.org/callbacks" {
+		t.Errorf("handler returned unexpected callback uri: got %s", response.Data.Attributes.CallbackURI)
+	}
+}
+
+func TestCreate_NilSubscription_ReturnsValidationError(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	core, err := accounts.NewClient(accounts.WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	response, err := NewClient(core).Create(context.Background(), nil)
+
+	// Assert
+
+	if response != nil || !errors.Is(err, accounts.ValidationError) {
+		t.Errorf("create returned unexpected result: got %v, %v want %v", response, err, accounts.ValidationError)
+	}
+
+	if requests != 0 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 0)
+	}
+}
@@ -0,0 +1,118 @@
+// Package organisations gives access to the Form3 organisation units, built on the accounts client core
+package organisations
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Default values used by the organisations client
+const (
+	UnitsPath        = "/v1/organisation/units"
+	organisationType = "organisations"
+)
+
+// Client may be used to make organisation units requests to the Form3 API
+type Client struct {
+	core  *accounts.Client
+	units *accounts.Resource[Organisation]
+}
+
+// NewClient constructs an organisations Client sending its requests through core. Circuit breaker endpoints are
+// prefixed with "organisations."
+func NewClient(core *accounts.Client) *Client {
+	return &Client{
+		core:  core,
+		units: accounts.NewResource[Organisation](core, "organisations", UnitsPath),
+	}
+}
+
+// Create stores the given organisation unit. A nil organisation fails with accounts.ValidationError
+func (c *Client) Create(ctx context.Context, organisation *Organisation) (*accounts.Response[Organisation], error) {
+
+	if organisation == nil {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, "organisation is required")
+	}
+
+	if organisation.Type == "" {
+		organisation.Type = organisationType
+	}
+
+	return c.units.Create(ctx, &accounts.Document[Organisation]{Data: organisation})
+}
+
+// CreateChild stores a unit named name under the parent unit
+func (c *Client) CreateChild(ctx context.Context, parentId uuid.UUID, name string) (*accounts.Response[Organisation], error) {
+	return c.Create(ctx, &Organisation{
+		ID:             uuid.New().String(),
+		OrganisationID: parentId.String(),
+		Attributes:     &OrganisationAttributes{Name: name},
+		Relationships: accounts.Relationships{
+			RelationshipParent: {Data: []accounts.ResourceIdentifier{{ID: parentId.String(), Type: organisationType}}},
+		},
+	})
+}
+
+// Fetch retrieves the organisation unit with the given id
+func (c *Client) Fetch(ctx context.Context, organisationId uuid.UUID) (*accounts.Response[Organisation], error) {
+	return c.units.Fetch(ctx, organisationId.String())
+}
+
+// List retrieves a page of organisation units
+func (c *Client) List(ctx context.Context, options *accounts.ListOptions) (*accounts.ListResponse[Organisation], error) {
+	return c.units.List(ctx, options)
+}
+
+// Children fetches the child units of the given unit
+func (c *Client) Children(ctx context.Context, organisationId uuid.UUID) ([]*Organisation, error) {
+
+	parent, err := c.Fetch(ctx, organisationId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var children []*Organisation
+
+	for _, childId := range parent.Data.ChildIDs() {
+
+		id, err := uuid.Parse(childId)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, http.StatusBadRequest, err)
+		}
+
+		child, err := c.Fetch(ctx, id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		children = append(children, child.Data)
+	}
+
+	return children, nil
+}
+
+// ValidateScope checks the organisation the core client is scoped to, see accounts.WithOrganisationID, exists.
+// Call it before bulk operations so they fail once rather than once per account
+func (c *Client) ValidateScope(ctx context.Context) (*Organisation, error) {
+
+	organisationId, err := uuid.Parse(c.core.OrganisationID())
+
+	if err != nil {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, "client is not scoped to an organisation")
+	}
+
+	response, err := c.Fetch(ctx, organisationId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
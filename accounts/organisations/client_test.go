@@ -0,0 +1,235 @@
+package organisations
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+const (
+	rootId  = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+	childId = "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+	emptyId = "5b1c7e43-2f0e-4d7a-9a53-2a9b0f7f6c11"
+)
+
+// newTestServer serves organisation units from memory
+func newTestServer(units map[string]string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(UnitsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+
+		unit, ok := units[strings.TrimPrefix(r.URL.Path, UnitsPath+"/")]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error_message":"organisation not found"}`)
+			return
+		}
+
+		io.WriteString(w, `{"data":`+unit+`}`)
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestClient(t *testing.T, url string, options ...accounts.ClientOption) *Client {
+
+	core, err := accounts.NewClient(append([]accounts.ClientOption{accounts.WithBaseURL(url)}, options...)...)
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return NewClient(core)
+}
+
+func TestChildren_fetchesRelatedUnits(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(map[string]string{
+		rootId:  `{"id":"` + rootId + `","type":"organisations","attributes":{"name":"Root"},"relationships":{"children":{"data":[{"id":"` + childId + `","type":"organisations"}]}}}`,
+		childId: `{"id":"` + childId + `","type":"organisations","attributes":{"name":"Child"},"relationships":{"parent":{"data":[{"id":"` + rootId + `","type":"organisations"}]}}}`,
+	})
+
+	defer ts.Close()
+
+	// Act
+
+	children, err := newTestClient(t, ts.URL).Children(context.Background(), uuid.MustParse(rootId))
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("children returned an error: got %v want %v", err, nil)
+	}
+
+	if len(children) != 1 || children[0].Attributes.Name != "Child" || children[0].ParentID() != rootId {
+		t.Errorf("children returned unexpected units: got %+v", children)
+	}
+}
+
+func TestChildren_unitWithoutData_ReturnsBuildingRequestError(t *testing.T) {
+
+	cases := map[string]struct {
+		units map[string]string
+	}{
+		"Parent without data": {
+			units: map[string]string{rootId: `null`},
+		},
+		"Child without data": {
+			units: map[string]string{
+				rootId:  `{"id":"` + rootId + `","type":"organisations","attributes":{"name":"Root"},"relationships":{"children":{"data":[{"id":"` + childId + `","type":"organisations"}]}}}`,
+				childId: `null`,
+			},
+		},
+	}
+
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			ts := newTestServer(testCase.units)
+			defer ts.Close()
+
+			// Act
+
+			children, err := newTestClient(t, ts.URL).Children(context.Background(), uuid.MustParse(rootId))
+
+			// Assert
+
+			if children != nil {
+				t.Errorf("Returned children: got %v want %v", children, nil)
+			}
+
+			if !errors.Is(err, accounts.BuildingRequestError) {
+				t.Errorf("unexpected error: got %v want %v", err, accounts.BuildingRequestError)
+			}
+		})
+	}
+}
+
+func TestCreateChild_sendsParentRelationship(t *testing.T) {
+
+	// Arrange
+
+	var received accounts.Document[Organisation]
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(UnitsPath, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(bodyBytes, &received)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(bodyBytes)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	// Act
+
+	response, err := newTestClient(t, ts.URL).CreateChild(context.Background(), uuid.MustParse(rootId), "Child")
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("create returned an error: got %v want %v", err, nil)
+	}
+
+	if received.Data.Type != "organisations" || received.Data.ParentID() != rootId || received.Data.OrganisationID != rootId {
+		t.Errorf("server received unexpected unit: got %+v", received.Data)
+	}
+
+	if response.Data.Attributes.Name != "Child" {
+		t.Errorf("create returned unexpected name: got %s want %s", response.Data.Attributes.Name, "Child")
+	}
+}
+
+func TestCreate_NilOrganisation_ReturnsValidationError(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	// Act
+
+	response, err := newTestClient(t, ts.URL).Create(context.Background(), nil)
+
+	// Assert
+
+	if response != nil || !errors.Is(err, accounts.ValidationError) {
+		t.Errorf("create returned unexpected result: got %v, %v want %v", response, err, accounts.ValidationError)
+	}
+
+	if requests != 0 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 0)
+	}
+}
+
+func TestValidateScopeCases(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(map[string]string{
+		rootId:  `{"id":"` + rootId + `","type":"organisations","attributes":{"name":"Root"}}`,
+		emptyId: `null`,
+	})
+
+	defer ts.Close()
+
+	cases := map[string]struct {
+		options           []accounts.ClientOption
+		expectedName      string
+		expectedErrorType error
+	}{
+		"Existing organisation": {
+			options:      []accounts.ClientOption{accounts.WithOrganisationID(rootId)},
+			expectedName: "Root",
+		},
+		"Unknown organisation": {
+			options:           []accounts.ClientOption{accounts.WithOrganisationID(childId)},
+			expectedErrorType: accounts.ApiHttpErrorType,
+		},
+		"Organisation without data": {
+			options:           []accounts.ClientOption{accounts.WithOrganisationID(emptyId)},
+			expectedErrorType: accounts.BuildingRequestError,
+		},
+		"Unscoped client": {
+			expectedErrorType: accounts.ValidationError,
+		},
+	}
+
+	for name, testCase := range cases {
+
+		// Act
+
+		organisation, err := newTestClient(t, ts.URL, testCase.options...).ValidateScope(context.Background())
+
+		// Assert
+
+		if testCase.expectedErrorType != nil {
+			if !errors.Is(err, testCase.expectedErrorType) {
+				t.Errorf("%s: unexpected error: got %v want %v", name, err, testCase.expectedErrorType)
+			}
+			continue
+		}
+
+		if err != nil || organisation.Attributes.Name != testCase.expectedName {
+			t.Errorf("%s: unexpected organisation: got %+v, %v want %s", name, organisation, err, testCase.expectedName)
+		}
+	}
+}
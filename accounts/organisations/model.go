@@ -0,0 +1,63 @@
+package organisations
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"time"
+)
+
+// Relationship names linking organisation units
+const (
+	RelationshipParent   = "parent"
+	RelationshipChildren = "children"
+)
+
+// Organisation is an organisation unit. Units form a tree through their parent and children relationships
+type Organisation struct {
+	Attributes     *OrganisationAttributes `json:"attributes"`
+	CreatedOn      time.Time               `json:"created_on,omitempty"`
+	ID             string                  `json:"id"`
+	ModifiedOn     time.Time               `json:"modified_on,omitempty"`
+	OrganisationID string                  `json:"organisation_id,omitempty"`
+	Relationships  accounts.Relationships  `json:"relationships,omitempty"`
+	Type           string                  `json:"type"`
+	Version        int                     `json:"version"`
+}
+
+// OrganisationAttributes holds the details of an organisation unit
+type OrganisationAttributes struct {
+	Name string `json:"name"`
+}
+
+// ParentID returns the id of the parent unit, or an empty string for a root unit
+func (o *Organisation) ParentID() string {
+
+	ids := o.related(RelationshipParent)
+
+	if len(ids) == 0 {
+		return ""
+	}
+
+	return ids[0]
+}
+
+// ChildIDs returns the ids of the child units
+func (o *Organisation) ChildIDs() []string {
+	return o.related(RelationshipChildren)
+}
+
+func (o *Organisation) related(name string) []string {
+
+	relationship := o.Relationships[name]
+
+	if relationship == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(relationship.Data))
+
+	for _, identifier := range relationship.Data {
+		ids = append(ids, identifier.ID)
+	}
+
+	return ids
+}
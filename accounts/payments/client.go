@@ -0,0 +1,82 @@
+// Package payments gives access to the Form3 payments resources, built on the accounts client core
+package payments
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Default values used by the payments client
+const (
+	PaymentsPath              = "/v1/transaction/payments"
+	DefaultPollInterval       = accounts.DefaultPollInterval
+	paymentType               = "payments"
+	submissionType            = "payment_submissions"
+	submissionsResourceSuffix = "submissions"
+)
+
+// Client may be used to make payments requests to the Form3 API
+type Client struct {
+	core     *accounts.Client
+	payments *accounts.Resource[Payment]
+}
+
+// NewClient constructs a payments Client sending its requests through core, which supplies the base url,
+// rate limiter and circuit breakers. Circuit breaker endpoints are prefixed with "payments."
+func NewClient(core *accounts.Client) *Client {
+	return &Client{
+		core:     core,
+		payments: accounts.NewResource[Payment](core, "payments", PaymentsPath),
+	}
+}
+
+// Create validates and stores the given payment
+func (c *Client) Create(ctx context.Context, payment *Payment) (*accounts.Response[Payment], error) {
+
+	if err := payment.Validate(); err != nil {
+		return nil, err
+	}
+
+	if payment.Type == "" {
+		payment.Type = paymentType
+	}
+
+	return c.payments.Create(ctx, &accounts.Document[Payment]{Data: payment})
+}
+
+// Fetch retrieves the payment with the given id
+func (c *Client) Fetch(ctx context.Context, paymentId uuid.UUID) (*accounts.Response[Payment], error) {
+	return c.payments.Fetch(ctx, paymentId.String())
+}
+
+// List retrieves a page of payments
+func (c *Client) List(ctx context.Context, options *accounts.ListOptions) (*accounts.ListResponse[Payment], error) {
+	return c.payments.List(ctx, options)
+}
+
+// subResource returns the collection named name nested under the given payment, e.g. its submissions
+func subResource[T any](c *Client, paymentId uuid.UUID, name string) *accounts.Resource[T] {
+	return accounts.NewResource[T](c.core, "payments."+name, PaymentsPath+"/"+paymentId.String()+"/"+name)
+}
+
+// nestedResource returns the collection named name nested under a sub-resource of the given payment, e.g. the
+// submissions of a return
+func nestedResource[T any](c *Client, paymentId uuid.UUID, parent string, parentId uuid.UUID, name string) *accounts.Resource[T] {
+	return accounts.NewResource[T](c.core, "payments."+parent+"."+name, PaymentsPath+"/"+paymentId.String()+"/"+parent+"/"+parentId.String()+"/"+name)
+}
+
+// parseID parses the id of a resource returned by the API
+func parseID(id string) (uuid.UUID, error) {
+
+	parsed, err := uuid.Parse(id)
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, http.StatusBadRequest, err)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,141 @@
+package payments
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"time"
+)
+
+// Payment is a Form3 payment resource
+type Payment struct {
+	Attributes     *PaymentAttributes     `json:"attributes"`
+	CreatedOn      time.Time              `json:"created_on,omitempty"`
+	ID             string                 `json:"id"`
+	ModifiedOn     time.Time              `json:"modified_on,omitempty"`
+	OrganisationID string                 `json:"organisation_id"`
+	Relationships  accounts.Relationships `json:"relationships,omitempty"`
+	Type           string                 `json:"type"`
+	Version        int                    `json:"version"`
+}
+
+// PaymentAttributes holds the details of a payment
+type PaymentAttributes struct {
+	// Amount is a decimal string, e.g. "100.21"
+	Amount            string `json:"amount"`
+	Currency          string `json:"currency"`
+	BeneficiaryParty  *Party `json:"beneficiary_party"`
+	DebtorParty       *Party `json:"debtor_party"`
+	EndToEndReference string `json:"end_to_end_reference,omitempty"`
+	NumericReference  string `json:"numeric_reference,omitempty"`
+	PaymentScheme     string `json:"payment_scheme"`
+	PaymentType       string `json:"payment_type,omitempty"`
+	// ProcessingDate uses the YYYY-MM-DD layout
+	ProcessingDate    string `json:"processing_date"`
+	Reference         string `json:"reference"`
+	SchemePaymentType string `json:"scheme_payment_type,omitempty"`
+}
+
+// Party is the debtor or beneficiary of a payment
+type Party struct {
+	AccountName       string       `json:"account_name,omitempty"`
+	AccountNumber     string       `json:"account_number"`
+	AccountNumberCode string       `json:"account_number_code"`
+	AccountWith       *AccountWith `json:"account_with"`
+	Name              string       `json:"name,omitempty"`
+}
+
+// AccountWith identifies the bank holding a party account
+type AccountWith struct {
+	BankID     string `json:"bank_id"`
+	BankIDCode string `json:"bank_id_code"`
+	Bic        string `json:"bic,omitempty"`
+}
+
+// Account number codes
+const (
+	AccountNumberCodeBBAN = "BBAN"
+	AccountNumberCodeIBAN = "IBAN"
+)
+
+// Payment schemes
+const (
+	SchemeFPS   = "FPS"
+	SchemeBacs  = "Bacs"
+	SchemeSEPA  = "SEPA"
+	SchemeChaps = "CHAPS"
+)
+
+// PartyFromAccount builds a payment party from the identifiers of an account. The IBAN is used when the account
+// has no account number
+func PartyFromAccount(account *accounts.Data) *Party {
+
+	party := &Party{AccountWith: &AccountWith{}}
+
+	if account == nil || account.Attributes == nil {
+		return party
+	}
+
+	attributes := account.Attributes
+
+	party.AccountNumber = attributes.AccountNumber
+	party.AccountNumberCode = AccountNumberCodeBBAN
+
+	if party.AccountNumber == "" && attributes.Iban != "" {
+		party.AccountNumber = attributes.Iban
+		party.AccountNumberCode = AccountNumberCodeIBAN
+	}
+
+	if len(attributes.Name) > 0 {
+		party.AccountName = attributes.Name[0]
+		party.Name = attributes.Name[0]
+	}
+
+	party.AccountWith.BankID = attributes.BankID
+	party.AccountWith.BankIDCode = attributes.BankIDCode
+	party.AccountWith.Bic = attributes.Bic
+
+	return party
+}
+
+// Submission is the request to process a payment through its scheme
+type Submission struct {
+	Attributes     *SubmissionAttributes `json:"attributes"`
+	CreatedOn      time.Time             `json:"created_on,omitempty"`
+	ID             string                `json:"id"`
+	ModifiedOn     time.Time             `json:"modified_on,omitempty"`
+	OrganisationID string                `json:"organisation_id"`
+	Type           string                `json:"type"`
+	Version        int                   `json:"version"`
+}
+
+// SubmissionAttributes holds the processing status of a submission
+type SubmissionAttributes struct {
+	Status             SubmissionStatus `json:"status,omitempty"`
+	StatusReason       string           `json:"status_reason,omitempty"`
+	SchemeStatusCode   string           `json:"scheme_status_code,omitempty"`
+	SubmissionDatetime *time.Time       `json:"submission_datetime,omitempty"`
+}
+
+// SubmissionStatus is the processing status of a submission
+type SubmissionStatus string
+
+// Submission statuses
+const (
+	SubmissionAccepted          SubmissionStatus = "accepted"
+	SubmissionValidationPending SubmissionStatus = "validation_pending"
+	SubmissionValidationPassed  SubmissionStatus = "validation_passed"
+	SubmissionValidationFailed  SubmissionStatus = "validation_failed"
+	SubmissionQueuedForDelivery SubmissionStatus = "queued_for_delivery"
+	SubmissionReleasedToGateway SubmissionStatus = "released_to_gateway"
+	SubmissionDeliveryConfirmed SubmissionStatus = "delivery_confirmed"
+	SubmissionDeliveryFailed    SubmissionStatus = "delivery_failed"
+)
+
+// Terminal reports whether the status will not change anymore
+func (s SubmissionStatus) Terminal() bool {
+	return s == SubmissionDeliveryConfirmed || s == SubmissionDeliveryFailed || s == SubmissionValidationFailed
+}
+
+// Succeeded reports whether the payment was delivered
+func (s SubmissionStatus) Succeeded() bool {
+	return s == SubmissionDeliveryConfirmed
+}
@@ -0,0 +1,214 @@
+package payments
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// newTestServer creates a multiplex server to handle API endpoints
+func newTestServer(path string, h func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	mux.HandleFunc(path, h)
+	return server
+}
+
+func newTestClient(t *testing.T, url string) *Client {
+
+	core, err := accounts.NewClient(accounts.WithBaseURL(url))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return NewClient(core)
+}
+
+func generateValidPayment() *Payment {
+
+	debtor := &accounts.Data{Attributes: &accounts.AccountAttributes{
+		AccountNumber: "41426819",
+		BankID:        "400300",
+		BankIDCode:    "GBDSC",
+		Bic:           "NWBKGB22",
+		Name:          []string{"Debtor Name"},
+	}}
+
+	beneficiary := &accounts.Data{Attributes: &accounts.AccountAttributes{
+		Iban: "GB11NWBK40030041426819",
+		Name: []string{"Beneficiary Name"},
+	}}
+
+	return &Payment{
+		ID:             "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43",
+		OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		Attributes: &PaymentAttributes{
+			Amount:           "100.21",
+			Currency:         "GBP",
+			DebtorParty:      PartyFromAccount(debtor),
+			BeneficiaryParty: PartyFromAccount(beneficiary),
+			PaymentScheme:    SchemeFPS,
+			ProcessingDate:   "2021-08-01",
+			Reference:        "Payment for Em's piano lessons",
+		},
+	}
+}
+
+func TestCreate_validPayment_sendsPartiesBuiltFromAccounts(t *testing.T) {
+
+	// Arrange
+
+	var received accounts.Document[Payment]
+
+	ts := newTestServer(PaymentsPath, func(w http.ResponseWriter, r *http.Request) {
+
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(bodyBytes, &received)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(bodyBytes)
+	})
+
+	defer ts.Close()
+
+	paymentsClient := newTestClient(t, ts.URL)
+
+	// Act
+
+	response, err := paymentsClient.Create(context.Background(), generateValidPayment())
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("create returned an error: got %v want %v", err, nil)
+	}
+
+	if received.Data.Type != "payments" {
+		t.Errorf("server received unexpected type: got %s want %s", received.Data.Type, "payments")
+	}
+
+	debtor := received.Data.Attributes.DebtorParty
+
+	if debtor.AccountNumber != "41426819" || debtor.AccountNumberCode != AccountNumberCodeBBAN || debtor.AccountWith.BankID != "400300" {
+		t.Errorf("server received unexpected debtor party: got %+v", debtor)
+	}
+
+	beneficiary := received.Data.Attributes.BeneficiaryParty
+
+	if beneficiary.AccountNumber != "GB11NWBK40030041426819" || beneficiary.AccountNumberCode != AccountNumberCodeIBAN {
+		t.Errorf("server received unexpected beneficiary party: got %+v", beneficiary)
+	}
+
+	if response.Data.Attributes.Amount != "100.21" {
+		t.Errorf("handler returned unexpected amount: got %s want %s", response.Data.Attributes.Amount, "100.21")
+	}
+}
+
+func TestCreateErrorCases(t *testing.T) {
+
+	// Arrange
+	errorCases := map[string]struct {
+		mutate               func(p *Payment)
+		expectedErrorMessage string
+	}{
+		"Zero amount": {
+			mutate:               func(p *Payment) { p.Attributes.Amount = "0.00" },
+			expectedErrorMessage: `amount must be a positive decimal: "0.00"`,
+		},
+		"Invalid currency": {
+			mutate:               func(p *Payment) { p.Attributes.Currency = "pounds" },
+			expectedErrorMessage: `currency must be an ISO 4217 code: "pounds"`,
+		},
+		"Invalid processing date": {
+			mutate:               func(p *Payment) { p.Attributes.ProcessingDate = "01/08/2021" },
+			expectedErrorMessage: `processing_date must use the YYYY-MM-DD layout: "01/08/2021"`,
+		},
+		"Missing beneficiary": {
+			mutate:               func(p *Payment) { p.Attributes.BeneficiaryParty = nil },
+			expectedErrorMessage: `beneficiary_party is required`,
+		},
+		"Debtor without bank id": {
+			mutate:               func(p *Payment) { p.Attributes.DebtorParty.AccountWith.BankID = "" },
+			expectedErrorMessage: `debtor_party.account_with.bank_id is required`,
+		},
+	}
+
+	requests := 0
+
+	ts := newTestServer(PaymentsPath, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	defer ts.Close()
+
+	paymentsClient := newTestClient(t, ts.URL)
+
+	for name, errCase := range errorCases {
+
+		payment := generateValidPayment()
+		errCase.mutate(payment)
+
+		// Act
+
+		response, err := paymentsClient.Create(context.Background(), payment)
+
+		// Assert
+
+		if response != nil {
+			t.Errorf("%s: Returned reponse: got %v want %v", name, response, nil)
+		}
+
+		if !errors.Is(err, accounts.ValidationError) || err.Error() != "Invalid request content | 400 | "+errCase.expectedErrorMessage {
+			t.Errorf("%s: Returned error: got %v want %s", name, err, errCase.expectedErrorMessage)
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 0)
+	}
+}
+
+func TestList_returnsPageOfPayments(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(PaymentsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"data":[{"id":"4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43","type":"payments","attributes":{"amount":"100.21","currency":"GBP"}}],"links":{"self":"/v1/transaction/payments"}}`)
+	})
+
+	defer ts.Close()
+
+	// Act
+
+	response, err := newTestClient(t, ts.URL).List(context.Background(), nil)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("list returned an error: got %v want %v", err, nil)
+	}
+
+	if len(response.Data) != 1 || response.Data[0].ID != "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43" {
+		t.Errorf("handler returned unexpected payments: got %+v", response.Data)
+	}
+
+	if _, err := uuid.Parse(response.Data[0].ID); err != nil {
+		t.Errorf(err.Error())
+	}
+}
+
+func decodeBody(r *http.Request, out interface{}) {
+	bodyBytes, _ := ioutil.ReadAll(r.Body)
+	json.Unmarshal(bodyBytes, out)
+}
@@ -0,0 +1,117 @@
+package payments
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	recallType           = "recalls"
+	recallSubmissionType = "recall_submissions"
+	recallsSuffix        = "recalls"
+)
+
+// Recall asks the beneficiary bank to send back the funds of a settled payment, e.g. a duplicate or a fraud
+type Recall struct {
+	Attributes     *RecallAttributes `json:"attributes"`
+	CreatedOn      time.Time         `json:"created_on,omitempty"`
+	ID             string            `json:"id"`
+	ModifiedOn     time.Time         `json:"modified_on,omitempty"`
+	OrganisationID string            `json:"organisation_id"`
+	Type           string            `json:"type"`
+	Version        int               `json:"version"`
+}
+
+// RecallAttributes holds the details of a recall
+type RecallAttributes struct {
+	// Reason is a free text explanation of the recall
+	Reason string `json:"reason,omitempty"`
+	// ReasonCode is the scheme reason code of the recall, e.g. "DUPL" for a duplicate payment
+	ReasonCode string `json:"reason_code"`
+}
+
+// RecallSubmission is the request to process a recall through the payment scheme
+type RecallSubmission struct {
+	Attributes     *RecallSubmissionAttributes `json:"attributes"`
+	CreatedOn      time.Time                   `json:"created_on,omitempty"`
+	ID             string                      `json:"id"`
+	ModifiedOn     time.Time                   `json:"modified_on,omitempty"`
+	OrganisationID string                      `json:"organisation_id"`
+	Type           string                      `json:"type"`
+	Version        int                         `json:"version"`
+}
+
+// RecallSubmissionAttributes holds the processing status of a recall submission
+type RecallSubmissionAttributes struct {
+	Status           RecallStatus `json:"status,omitempty"`
+	StatusReason     string       `json:"status_reason,omitempty"`
+	SchemeStatusCode string       `json:"scheme_status_code,omitempty"`
+}
+
+// RecallStatus is the processing status of a recall submission
+type RecallStatus string
+
+// Recall submission statuses
+const (
+	RecallAccepted          RecallStatus = "accepted"
+	RecallValidationFailed  RecallStatus = "validation_failed"
+	RecallReleasedToGateway RecallStatus = "released_to_gateway"
+	RecallDeliveryConfirmed RecallStatus = "delivery_confirmed"
+	RecallDeliveryFailed    RecallStatus = "delivery_failed"
+)
+
+// Terminal reports whether the status will not change anymore
+func (s RecallStatus) Terminal() bool {
+	return s == RecallDeliveryConfirmed || s == RecallDeliveryFailed || s == RecallValidationFailed
+}
+
+// Succeeded reports whether the recall was delivered
+func (s RecallStatus) Succeeded() bool {
+	return s == RecallDeliveryConfirmed
+}
+
+// CreateRecall stores a recall of the given payment
+func (c *Client) CreateRecall(ctx context.Context, paymentId uuid.UUID, recall *Recall) (*accounts.Response[Recall], error) {
+
+	if recall.Type == "" {
+		recall.Type = recallType
+	}
+
+	return subResource[Recall](c, paymentId, recallsSuffix).Create(ctx, &accounts.Document[Recall]{Data: recall})
+}
+
+// FetchRecall retrieves a recall of the given payment
+func (c *Client) FetchRecall(ctx context.Context, paymentId uuid.UUID, recallId uuid.UUID) (*accounts.Response[Recall], error) {
+	return subResource[Recall](c, paymentId, recallsSuffix).Fetch(ctx, recallId.String())
+}
+
+// ListRecalls retrieves a page of the recalls of the given payment
+func (c *Client) ListRecalls(ctx context.Context, paymentId uuid.UUID, options *accounts.ListOptions) (*accounts.ListResponse[Recall], error) {
+	return subResource[Recall](c, paymentId, recallsSuffix).List(ctx, options)
+}
+
+// SubmitRecall requests the processing of the given recall through the payment scheme
+func (c *Client) SubmitRecall(ctx context.Context, paymentId uuid.UUID, recallId uuid.UUID, organisationId string) (*accounts.Response[RecallSubmission], error) {
+
+	submission := &RecallSubmission{
+		ID:             uuid.New().String(),
+		OrganisationID: organisationId,
+		Type:           recallSubmissionType,
+		Attributes:     &RecallSubmissionAttributes{},
+	}
+
+	return nestedResource[RecallSubmission](c, paymentId, recallsSuffix, recallId, submissionsResourceSuffix).Create(ctx, &accounts.Document[RecallSubmission]{Data: submission})
+}
+
+// FetchRecallSubmission retrieves a submission of the given recall
+func (c *Client) FetchRecallSubmission(ctx context.Context, paymentId uuid.UUID, recallId uuid.UUID, submissionId uuid.UUID) (*accounts.Response[RecallSubmission], error) {
+	return nestedResource[RecallSubmission](c, paymentId, recallsSuffix, recallId, submissionsResourceSuffix).Fetch(ctx, submissionId.String())
+}
+
+// ListRecallSubmissions retrieves a page of the submissions of the given recall
+func (c *Client) ListRecallSubmissions(ctx context.Context, paymentId uuid.UUID, recallId uuid.UUID, options *accounts.ListOptions) (*accounts.ListResponse[RecallSubmission], error) {
+	return nestedResource[RecallSubmission](c, paymentId, recallsSuffix, recallId, submissionsResourceSuffix).List(ctx, options)
+}
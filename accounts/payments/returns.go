@@ -0,0 +1,174 @@
+package payments
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	returnType           = "returns"
+	returnSubmissionType = "return_submissions"
+	returnsSuffix        = "returns"
+)
+
+// Return sends a received payment back to its debtor, e.g. because the beneficiary account is closed
+type Return struct {
+	Attributes     *ReturnAttributes `json:"attributes"`
+	CreatedOn      time.Time         `json:"created_on,omitempty"`
+	ID             string            `json:"id"`
+	ModifiedOn     time.Time         `json:"modified_on,omitempty"`
+	OrganisationID string            `json:"organisation_id"`
+	Type           string            `json:"type"`
+	Version        int               `json:"version"`
+}
+
+// ReturnAttributes holds the details of a return
+type ReturnAttributes struct {
+	Amount   string `json:"amount,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	// ReturnCode is the scheme reason code of the return, e.g. "AC01" for an incorrect account number
+	ReturnCode string `json:"return_code"`
+}
+
+// ReturnSubmission is the request to process a return through the payment scheme
+type ReturnSubmission struct {
+	Attributes     *ReturnSubmissionAttributes `json:"attributes"`
+	CreatedOn      time.Time                   `json:"created_on,omitempty"`
+	ID             string                      `json:"id"`
+	ModifiedOn     time.Time                   `json:"modified_on,omitempty"`
+	OrganisationID string                      `json:"organisation_id"`
+	Type           string                      `json:"type"`
+	Version        int                         `json:"version"`
+}
+
+// ReturnSubmissionAttributes holds the processing status of a return submission
+type ReturnSubmissionAttributes struct {
+	Status           ReturnStatus `json:"status,omitempty"`
+	StatusReason     string       `json:"status_reason,omitempty"`
+	SchemeStatusCode string       `json:"scheme_status_code,omitempty"`
+}
+
+// ReturnStatus is the processing status of a return submission
+type ReturnStatus string
+
+// Return submission statuses
+const (
+	ReturnAccepted          ReturnStatus = "accepted"
+	ReturnValidationFailed  ReturnStatus = "validation_failed"
+	ReturnReleasedToGateway ReturnStatus = "released_to_gateway"
+	ReturnDeliveryConfirmed ReturnStatus = "delivery_confirmed"
+	ReturnDeliveryFailed    ReturnStatus = "delivery_failed"
+)
+
+// Terminal reports whether the status will not change anymore
+func (s ReturnStatus) Terminal() bool {
+	return s == ReturnDeliveryConfirmed || s == ReturnDeliveryFailed || s == ReturnValidationFailed
+}
+
+// Succeeded reports whether the return was delivered
+func (s ReturnStatus) Succeeded() bool {
+	return s == ReturnDeliveryConfirmed
+}
+
+// CreateReturn stores a return of the given payment
+func (c *Client) CreateReturn(ctx context.Context, paymentId uuid.UUID, paymentReturn *Return) (*accounts.Response[Return], error) {
+
+	if paymentReturn.Type == "" {
+		paymentReturn.Type = returnType
+	}
+
+	return subResource[Return](c, paymentId, returnsSuffix).Create(ctx, &accounts.Document[Return]{Data: paymentReturn})
+}
+
+// FetchReturn retrieves a return of the given payment
+func (c *Client) FetchReturn(ctx context.Context, paymentId uuid.UUID, returnId uuid.UUID) (*accounts.Response[Return], error) {
+	return subResource[Return](c, paymentId, returnsSuffix).Fetch(ctx, returnId.String())
+}
+
+// ListReturns retrieves a page of the returns of the given payment
+func (c *Client) ListReturns(ctx context.Context, paymentId uuid.UUID, options *accounts.ListOptions) (*accounts.ListResponse[Return], error) {
+	return subResource[Return](c, paymentId, returnsSuffix).List(ctx, options)
+}
+
+// SubmitReturn requests the processing of the given return through the payment scheme
+func (c *Client) SubmitReturn(ctx context.Context, paymentId uuid.UUID, returnId uuid.UUID, organisationId string) (*accounts.Response[ReturnSubmission], error) {
+
+	submission := &ReturnSubmission{
+		ID:             uuid.New().String(),
+		OrganisationID: organisationId,
+		Type:           returnSubmissionType,
+		Attributes:     &ReturnSubmissionAttributes{},
+	}
+
+	return nestedResource[ReturnSubmission](c, paymentId, returnsSuffix, returnId, submissionsResourceSuffix).Create(ctx, &accounts.Document[ReturnSubmission]{Data: submission})
+}
+
+// FetchReturnSubmission retrieves a submission of the given return
+func (c *Client) FetchReturnSubmission(ctx context.Context, paymentId uuid.UUID, returnId uuid.UUID, submissionId uuid.UUID) (*accounts.Response[ReturnSubmission], error) {
+	return nestedResource[ReturnSubmission](c, paymentId, returnsSuffix, returnId, submissionsResourceSuffix).Fetch(ctx, submissionId.String())
+}
+
+// ListReturnSubmissions retrieves a page of the submissions of the given return
+func (c *Client) ListReturnSubmissions(ctx context.Context, paymentId uuid.UUID, returnId uuid.UUID, options *accounts.ListOptions) (*accounts.ListResponse[ReturnSubmission], error) {
+	return nestedResource[ReturnSubmission](c, paymentId, returnsSuffix, returnId, submissionsResourceSuffix).List(ctx, options)
+}
+
+// ReturnPayment returns the full amount of the given payment with returnCode, submits the return and polls its
+// submission every interval until its status is terminal or the context is done, as WaitForSubmission does
+func (c *Client) ReturnPayment(ctx context.Context, paymentId uuid.UUID, returnCode string, interval time.Duration) (*accounts.Response[ReturnSubmission], error) {
+
+	payment, err := c.Fetch(ctx, paymentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	paymentReturn := &Return{
+		ID:             uuid.New().String(),
+		OrganisationID: payment.Data.OrganisationID,
+		Attributes:     &ReturnAttributes{ReturnCode: returnCode},
+	}
+
+	if payment.Data.Attributes != nil {
+		paymentReturn.Attributes.Amount = payment.Data.Attributes.Amount
+		paymentReturn.Attributes.Currency = payment.Data.Attributes.Currency
+	}
+
+	created, err := c.CreateReturn(ctx, paymentId, paymentReturn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	returnId, err := parseID(created.Data.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	submitted, err := c.SubmitReturn(ctx, paymentId, returnId, payment.Data.OrganisationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	submissionId, err := parseID(submitted.Data.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts.Poll(ctx, interval, func() (*accounts.Response[ReturnSubmission], bool, error) {
+
+		response, err := c.FetchReturnSubmission(ctx, paymentId, returnId, submissionId)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response, response.Data.Attributes != nil && response.Data.Attributes.Status.Terminal(), nil
+	})
+}
@@ -0,0 +1,216 @@
+package payments
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestReturnPayment_returnsFullAmountAndWaitsForTerminalStatus(t *testing.T) {
+
+	// Arrange
+
+	paymentId := uuid.MustParse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	returnsPath := PaymentsPath + "/" + paymentId.String() + "/returns"
+	statuses := []ReturnStatus{ReturnAccepted, ReturnReleasedToGateway, ReturnDeliveryConfirmed}
+	polls := 0
+
+	var received accounts.Document[Return]
+
+	ts := newTestServer(PaymentsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+
+		switch {
+		case r.URL.Path == PaymentsPath+"/"+paymentId.String():
+			io.WriteString(w, `{"data":{"id":"`+paymentId.String()+`","organisation_id":"eb0bd6f5-c3f5-44b2-b677-acd23cdde73c","attributes":{"amount":"100.21","currency":"GBP"}}}`)
+
+		case r.URL.Path == returnsPath && r.Method == http.MethodPost:
+			decodeBody(r, &received)
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, fmt.Sprintf(`{"data":{"id":"%s","type":"returns"}}`, received.Data.ID))
+
+		case strings.HasSuffix(r.URL.Path, "/submissions") && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"data":{"id":"9a5a5b3b-4f4e-4c55-8c6c-2b7f4c6f7c1d","type":"return_submissions","attributes":{}}}`)
+
+		case strings.HasSuffix(r.URL.Path, "/submissions/9a5a5b3b-4f4e-4c55-8c6c-2b7f4c6f7c1d"):
+			status := statuses[polls]
+			polls++
+			io.WriteString(w, fmt.Sprintf(`{"data":{"id":"9a5a5b3b-4f4e-4c55-8c6c-2b7f4c6f7c1d","type":"return_submissions","attributes":{"status":"%s"}}}`, status))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error_message":"not found"}`)
+		}
+	})
+
+	defer ts.Close()
+
+	// Act
+
+	final, err := newTestClient(t, ts.URL).ReturnPayment(context.Background(), paymentId, "AC01", time.Millisecond)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("return returned an error: got %v want %v", err, nil)
+	}
+
+	if !final.Data.Attributes.Status.Succeeded() {
+		t.Errorf("unexpected final status: got %s want %s", final.Data.Attributes.Status, ReturnDeliveryConfirmed)
+	}
+
+	attributes := received.Data.Attributes
+
+	if received.Data.Type != "returns" || attributes.ReturnCode != "AC01" || attributes.Amount != "100.21" || attributes.Currency != "GBP" {
+		t.Errorf("server received unexpected return: got %+v", attributes)
+	}
+
+	if polls != len(statuses) {
+		t.Errorf("unexpected number of polls: got %d want %d", polls, len(statuses))
+	}
+}
+
+func TestListSubResources_requestTheNestedCollections(t *testing.T) {
+
+	// Arrange
+
+	paymentId := uuid.MustParse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	childId := uuid.MustParse("9a5a5b3b-4f4e-4c55-8c6c-2b7f4c6f7c1d")
+	base := PaymentsPath + "/" + paymentId.String()
+
+	var requested string
+
+	ts := newTestServer(PaymentsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		io.WriteString(w, `{"data":[{"id":"`+childId.String()+`"}]}`)
+	})
+
+	defer ts.Close()
+
+	paymentsClient := newTestClient(t, ts.URL)
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		list         func() (int, error)
+		expectedPath string
+	}{
+		"Returns": {
+			list: func() (int, error) {
+				response, err := paymentsClient.ListReturns(ctx, paymentId, nil)
+				return countOf(response, err)
+			},
+			expectedPath: base + "/returns",
+		},
+		"Reversal submissions": {
+			list: func() (int, error) {
+				response, err := paymentsClient.ListReversalSubmissions(ctx, paymentId, childId, nil)
+				return countOf(response, err)
+			},
+			expectedPath: base + "/reversals/" + childId.String() + "/submissions",
+		},
+		"Recalls": {
+			list: func() (int, error) {
+				response, err := paymentsClient.ListRecalls(ctx, paymentId, nil)
+				return countOf(response, err)
+			},
+			expectedPath: base + "/recalls",
+		},
+	}
+
+	for name, testCase := range cases {
+
+		// Act
+
+		count, err := testCase.list()
+
+		// Assert
+
+		if err != nil || count != 1 {
+			t.Errorf("%s: list returned unexpected result: got %d, %v want %d", name, count, err, 1)
+		}
+
+		if requested != testCase.expectedPath {
+			t.Errorf("%s: server received unexpected path: got %s want %s", name, requested, testCase.expectedPath)
+		}
+	}
+}
+
+func countOf[T any](response *accounts.ListResponse[T], err error) (int, error) {
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(response.Data), nil
+}
+
+func TestReturnPayment_StepWithoutData_ReturnsBuildingRequestError(t *testing.T) {
+
+	paymentId := uuid.MustParse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	returnsPath := PaymentsPath + "/" + paymentId.String() + "/returns"
+
+	cases := map[string]struct {
+		emptyStep string
+	}{
+		"Payment without data":           {emptyStep: "payment"},
+		"Created return without data":    {emptyStep: "return"},
+		"Submitted return without data":  {emptyStep: "submit"},
+		"Return submission without data": {emptyStep: "submission"},
+	}
+
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			respond := func(w http.ResponseWriter, step string, status int, body string) {
+				w.WriteHeader(status)
+				if step == testCase.emptyStep {
+					body = `{}`
+				}
+				io.WriteString(w, body)
+			}
+
+			ts := newTestServer(PaymentsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+
+				switch {
+				case r.URL.Path == PaymentsPath+"/"+paymentId.String():
+					respond(w, "payment", http.StatusOK, `{"data":{"id":"`+paymentId.String()+`","attributes":{"amount":"100.21","currency":"GBP"}}}`)
+
+				case r.URL.Path == returnsPath && r.Method == http.MethodPost:
+					respond(w, "return", http.StatusCreated, `{"data":{"id":"0d9b4f0e-2f6b-4c8e-9a57-1f0c3f1b6a11","type":"returns"}}`)
+
+				case strings.HasSuffix(r.URL.Path, "/submissions") && r.Method == http.MethodPost:
+					respond(w, "submit", http.StatusCreated, `{"data":{"id":"9a5a5b3b-4f4e-4c55-8c6c-2b7f4c6f7c1d","type":"return_submissions"}}`)
+
+				default:
+					respond(w, "submission", http.StatusOK, `{"data":{"id":"9a5a5b3b-4f4e-4c55-8c6c-2b7f4c6f7c1d","type":"return_submissions","attributes":{"status":"delivery_confirmed"}}}`)
+				}
+			})
+
+			defer ts.Close()
+
+			// Act
+
+			final, err := newTestClient(t, ts.URL).ReturnPayment(context.Background(), paymentId, "AC01", time.Millisecond)
+
+			// Assert
+
+			if final != nil {
+				t.Errorf("Returned response: got %v want %v", final, nil)
+			}
+
+			if !errors.Is(err, accounts.BuildingRequestError) {
+				t.Errorf("unexpected error: got %v want %v", err, accounts.BuildingRequestError)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package payments
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	reversalType           = "reversals"
+	reversalSubmissionType = "reversal_submissions"
+	reversalsSuffix        = "reversals"
+)
+
+// Reversal cancels a payment sent by mistake, before it is settled
+type Reversal struct {
+	Attributes     *ReversalAttributes `json:"attributes"`
+	CreatedOn      time.Time           `json:"created_on,omitempty"`
+	ID             string              `json:"id"`
+	ModifiedOn     time.Time           `json:"modified_on,omitempty"`
+	OrganisationID string              `json:"organisation_id"`
+	Type           string              `json:"type"`
+	Version        int                 `json:"version"`
+}
+
+// ReversalAttributes holds the details of a reversal
+type ReversalAttributes struct {
+	// Reason is a free text explanation of the reversal
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReversalSubmission is the request to process a reversal through the payment scheme
+type ReversalSubmission struct {
+	Attributes     *ReversalSubmissionAttributes `json:"attributes"`
+	CreatedOn      time.Time                     `json:"created_on,omitempty"`
+	ID             string                        `json:"id"`
+	ModifiedOn     time.Time                     `json:"modified_on,omitempty"`
+	OrganisationID string                        `json:"organisation_id"`
+	Type           string                        `json:"type"`
+	Version        int                           `json:"version"`
+}
+
+// ReversalSubmissionAttributes holds the processing status of a reversal submission
+type ReversalSubmissionAttributes struct {
+	Status           ReversalStatus `json:"status,omitempty"`
+	StatusReason     string         `json:"status_reason,omitempty"`
+	SchemeStatusCode string         `json:"scheme_status_code,omitempty"`
+}
+
+// ReversalStatus is the processing status of a reversal submission
+type ReversalStatus string
+
+// Reversal submission statuses
+const (
+	ReversalAccepted          ReversalStatus = "accepted"
+	ReversalValidationFailed  ReversalStatus = "validation_failed"
+	ReversalReleasedToGateway ReversalStatus = "released_to_gateway"
+	ReversalDeliveryConfirmed ReversalStatus = "delivery_confirmed"
+	ReversalDeliveryFailed    ReversalStatus = "delivery_failed"
+)
+
+// Terminal reports whether the status will not change anymore
+func (s ReversalStatus) Terminal() bool {
+	return s == ReversalDeliveryConfirmed || s == ReversalDeliveryFailed || s == ReversalValidationFailed
+}
+
+// Succeeded reports whether the reversal was delivered
+func (s ReversalStatus) Succeeded() bool {
+	return s == ReversalDeliveryConfirmed
+}
+
+// CreateReversal stores a reversal of the given payment
+func (c *Client) CreateReversal(ctx context.Context, paymentId uuid.UUID, reversal *Reversal) (*accounts.Response[Reversal], error) {
+
+	if reversal.Type == "" {
+		reversal.Type = reversalType
+	}
+
+	return subResource[Reversal](c, paymentId, reversalsSuffix).Create(ctx, &accounts.Document[Reversal]{Data: reversal})
+}
+
+// FetchReversal retrieves a reversal of the given payment
+func (c *Client) FetchReversal(ctx context.Context, paymentId uuid.UUID, reversalId uuid.UUID) (*accounts.Response[Reversal], error) {
+	return subResource[Reversal](c, paymentId, reversalsSuffix).Fetch(ctx, reversalId.String())
+}
+
+// ListReversals retrieves a page of the reversals of the given payment
+func (c *Client) ListReversals(ctx context.Context, paymentId uuid.UUID, options *accounts.ListOptions) (*accounts.ListResponse[Reversal], error) {
+	return subResource[Reversal](c, paymentId, reversalsSuffix).List(ctx, options)
+}
+
+// SubmitReversal requests the processing of the given reversal through the payment scheme
+func (c *Client) SubmitReversal(ctx context.Context, paymentId uuid.UUID, reversalId uuid.UUID, organisationId string) (*accounts.Response[ReversalSubmission], error) {
+
+	submission := &ReversalSubmission{
+		ID:             uuid.New().String(),
+		OrganisationID: organisationId,
+		Type:           reversalSubmissionType,
+		Attributes:     &ReversalSubmissionAttributes{},
+	}
+
+	return nestedResource[ReversalSubmission](c, paymentId, reversalsSuffix, reversalId, submissionsResourceSuffix).Create(ctx, &accounts.Document[ReversalSubmission]{Data: submission})
+}
+
+// FetchReversalSubmission retrieves a submission of the given reversal
+func (c *Client) FetchReversalSubmission(ctx context.Context, paymentId uuid.UUID, reversalId uuid.UUID, submissionId uuid.UUID) (*accounts.Response[ReversalSubmission], error) {
+	return nestedResource[ReversalSubmission](c, paymentId, reversalsSuffix, reversalId, submissionsResourceSuffix).Fetch(ctx, submissionId.String())
+}
+
+// ListReversalSubmissions retrieves a page of the submissions of the given reversal
+func (c *Client) ListReversalSubmissions(ctx context.Context, paymentId uuid.UUID, reversalId uuid.UUID, options *accounts.ListOptions) (*accounts.ListResponse[ReversalSubmission], error) {
+	return nestedResource[ReversalSubmission](c, paymentId, reversalsSuffix, reversalId, submissionsResourceSuffix).List(ctx, options)
+}
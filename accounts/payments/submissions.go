@@ -0,0 +1,42 @@
+package payments
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Submit requests the processing of the given payment through its scheme
+func (c *Client) Submit(ctx context.Context, paymentId uuid.UUID, organisationId string) (*accounts.Response[Submission], error) {
+
+	submission := &Submission{
+		ID:             uuid.New().String(),
+		OrganisationID: organisationId,
+		Type:           submissionType,
+		Attributes:     &SubmissionAttributes{},
+	}
+
+	return subResource[Submission](c, paymentId, submissionsResourceSuffix).Create(ctx, &accounts.Document[Submission]{Data: submission})
+}
+
+// FetchSubmission retrieves a submission of the given payment
+func (c *Client) FetchSubmission(ctx context.Context, paymentId uuid.UUID, submissionId uuid.UUID) (*accounts.Response[Submission], error) {
+	return subResource[Submission](c, paymentId, submissionsResourceSuffix).Fetch(ctx, submissionId.String())
+}
+
+// WaitForSubmission polls a submission every interval until its status is terminal or the context is done.
+// The last fetched submission is returned along with the context error when giving up
+func (c *Client) WaitForSubmission(ctx context.Context, paymentId uuid.UUID, submissionId uuid.UUID, interval time.Duration) (*accounts.Response[Submission], error) {
+	return accounts.Poll(ctx, interval, func() (*accounts.Response[Submission], bool, error) {
+
+		response, err := c.FetchSubmission(ctx, paymentId, submissionId)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return response, response.Data.Attributes != nil && response.Data.Attributes.Status.Terminal(), nil
+	})
+}
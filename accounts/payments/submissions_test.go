@@ -0,0 +1,147 @@
+package payments
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSubmitAndWaitForSubmission_pollsUntilTerminalStatus(t *testing.T) {
+
+	// Arrange
+
+	paymentId := uuid.MustParse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	submissionsPath := PaymentsPath + "/" + paymentId.String() + "/submissions"
+	statuses := []SubmissionStatus{SubmissionAccepted, SubmissionQueuedForDelivery, SubmissionDeliveryConfirmed}
+	polls := 0
+	submissionId := ""
+
+	ts := newTestServer(submissionsPath, func(w http.ResponseWriter, r *http.Request) {
+
+		var body struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		decodeBody(r, &body)
+		submissionId = body.Data.ID
+
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, fmt.Sprintf(`{"data":{"id":"%s","type":"payment_submissions","attributes":{"status":"accepted"}}}`, submissionId))
+	})
+
+	ts.Config.Handler.(*http.ServeMux).HandleFunc(submissionsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, submissionId) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		status := statuses[polls]
+		polls++
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, fmt.Sprintf(`{"data":{"id":"%s","type":"payment_submissions","attributes":{"status":"%s"}}}`, submissionId, status))
+	})
+
+	defer ts.Close()
+
+	paymentsClient := newTestClient(t, ts.URL)
+
+	ctx := context.Background()
+
+	submitted, err := paymentsClient.Submit(ctx, paymentId, "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c")
+
+	if err != nil {
+		t.Fatalf("submit returned an error: got %v want %v", err, nil)
+	}
+
+	// Act
+
+	final, err := paymentsClient.WaitForSubmission(ctx, paymentId, uuid.MustParse(submitted.Data.ID), time.Millisecond)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("wait returned an error: got %v want %v", err, nil)
+	}
+
+	if !final.Data.Attributes.Status.Succeeded() {
+		t.Errorf("unexpected final status: got %s want %s", final.Data.Attributes.Status, SubmissionDeliveryConfirmed)
+	}
+
+	if polls != len(statuses) {
+		t.Errorf("unexpected number of polls: got %d want %d", polls, len(statuses))
+	}
+}
+
+func TestWaitForSubmission_ContextDone_ReturnsLastSubmission(t *testing.T) {
+
+	// Arrange
+
+	paymentId := uuid.MustParse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	submissionId := uuid.MustParse("9a5a5b3b-4f4e-4c55-8c6c-2b7f4c6f7c1d")
+
+	ts := newTestServer(PaymentsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, fmt.Sprintf(`{"data":{"id":"%s","type":"payment_submissions","attributes":{"status":"queued_for_delivery"}}}`, submissionId))
+	})
+
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+
+	last, err := newTestClient(t, ts.URL).WaitForSubmission(ctx, paymentId, submissionId, 5*time.Millisecond)
+
+	// Assert
+
+	if err != context.DeadlineExceeded {
+		t.Errorf("wait returned unexpected error: got %v want %v", err, context.DeadlineExceeded)
+	}
+
+	if last == nil || last.Data.Attributes.Status != SubmissionQueuedForDelivery {
+		t.Errorf("wait returned unexpected last submission: got %+v", last)
+	}
+}
+
+func TestWaitForSubmission_SuccessWithoutData_ReturnsBuildingRequestError(t *testing.T) {
+
+	cases := map[string]string{
+		"empty document": `{}`,
+		"null data":      `{"data":null}`,
+	}
+
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			ts := newTestServer(PaymentsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				io.WriteString(w, body)
+			})
+
+			defer ts.Close()
+
+			// Act
+
+			last, err := newTestClient(t, ts.URL).WaitForSubmission(context.Background(), uuid.New(), uuid.New(), time.Millisecond)
+
+			// Assert
+
+			if last != nil || !errors.Is(err, accounts.BuildingRequestError) {
+				t.Errorf("wait returned unexpected outcome: got %v and %v want %v", last, err, accounts.BuildingRequestError)
+			}
+		})
+	}
+}
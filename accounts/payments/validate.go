@@ -0,0 +1,86 @@
+package payments
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	amountPattern   = regexp.MustCompile(`^[0-9]{1,14}(\.[0-9]{1,4})?$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// Validate checks the payment carries the fields the API requires, returning an accounts.ValidationError otherwise
+func (p *Payment) Validate() error {
+
+	if p == nil || p.Attributes == nil {
+		return validationError("payment attributes are required")
+	}
+
+	if _, err := uuid.Parse(p.ID); err != nil {
+		return validationError(fmt.Sprintf("id must be a uuid: %q", p.ID))
+	}
+
+	if _, err := uuid.Parse(p.OrganisationID); err != nil {
+		return validationError(fmt.Sprintf("organisation_id must be a uuid: %q", p.OrganisationID))
+	}
+
+	attributes := p.Attributes
+
+	if !amountPattern.MatchString(attributes.Amount) || isZeroAmount(attributes.Amount) {
+		return validationError(fmt.Sprintf("amount must be a positive decimal: %q", attributes.Amount))
+	}
+
+	if !currencyPattern.MatchString(attributes.Currency) {
+		return validationError(fmt.Sprintf("currency must be an ISO 4217 code: %q", attributes.Currency))
+	}
+
+	if attributes.PaymentScheme == "" {
+		return validationError("payment_scheme is required")
+	}
+
+	if _, err := time.Parse("2006-01-02", attributes.ProcessingDate); err != nil {
+		return validationError(fmt.Sprintf("processing_date must use the YYYY-MM-DD layout: %q", attributes.ProcessingDate))
+	}
+
+	if err := validateParty("debtor_party", attributes.DebtorParty); err != nil {
+		return err
+	}
+
+	return validateParty("beneficiary_party", attributes.BeneficiaryParty)
+}
+
+func validateParty(field string, party *Party) error {
+
+	if party == nil {
+		return validationError(field + " is required")
+	}
+
+	if party.AccountNumber == "" {
+		return validationError(field + ".account_number is required")
+	}
+
+	if party.AccountNumberCode != AccountNumberCodeIBAN && (party.AccountWith == nil || party.AccountWith.BankID == "") {
+		return validationError(field + ".account_with.bank_id is required")
+	}
+
+	return nil
+}
+
+func isZeroAmount(amount string) bool {
+	for _, r := range amount {
+		if r != '0' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func validationError(message string) error {
+	return fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, message)
+}
@@ -0,0 +1,47 @@
+package accounts
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPollInterval is the interval used by Poll when none is given
+const DefaultPollInterval = 2 * time.Second
+
+// Poll calls fetch every interval until it reports done, fails or the context is done, in which case the last
+// successfully fetched value is returned along with the context error. A value returned with an error, e.g. a
+// resource whose processing failed, is passed on. Resource packages use it to wait for asynchronous processing
+func Poll[T any](ctx context.Context, interval time.Duration, fetch func() (*T, bool, error)) (*T, error) {
+
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *T
+
+	for {
+		current, done, err := fetch()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return current, err
+		}
+
+		last = current
+
+		if done {
+			return last, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
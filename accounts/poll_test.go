@@ -0,0 +1,64 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoll_ContextDone_ReturnsTheLastValue(t *testing.T) {
+
+	// Arrange
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+
+	// Act
+
+	last, err := Poll(ctx, time.Millisecond, func() (*int, bool, error) {
+
+		polls++
+
+		if polls == 3 {
+			cancel()
+			return nil, false, errors.New("context canceled")
+		}
+
+		value := polls
+
+		return &value, false, nil
+	})
+
+	// Assert
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: got %v want %v", err, context.Canceled)
+	}
+
+	if last == nil || *last != 2 {
+		t.Errorf("unexpected last value: got %v want %d", last, 2)
+	}
+}
+
+func TestPoll_FailedFetch_ReturnsItsValue(t *testing.T) {
+
+	// Arrange
+
+	failed := 1
+	failure := errors.New("processing failed")
+
+	// Act
+
+	value, err := Poll(context.Background(), time.Millisecond, func() (*int, bool, error) {
+		return &failed, true, failure
+	})
+
+	// Assert
+
+	if err != failure || value != &failed {
+		t.Errorf("unexpected result: got %v, %v want %v, %v", value, err, &failed, failure)
+	}
+}
@@ -0,0 +1,81 @@
+package accounts
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by every request sent through the client
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a token is available or the context is done
+func (r *rateLimiter) wait(ctx context.Context) error {
+
+	delay := r.reserve()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token, possibly in advance, and returns how long the caller must wait before using it
+func (r *rateLimiter) reserve() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	r.tokens += float64(now.Sub(r.last)) / float64(r.interval)
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+	r.last = now
+
+	r.tokens--
+
+	if r.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-r.tokens * float64(r.interval))
+}
+
+// cancel gives back a token reserved by a caller which stopped waiting
+func (r *rateLimiter) cancel() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens++
+}
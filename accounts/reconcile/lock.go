@@ -0,0 +1,65 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// ErrLocked is returned when another apply holds the lock file
+var ErrLocked = errors.New("Manifest is locked by another apply")
+
+// LockInfo is the content of a lock file, identifying its holder
+type LockInfo struct {
+	Holder     string    `json:"holder"`
+	PID        int       `json:"pid"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// Lock is a held lock file
+type Lock struct {
+	path string
+}
+
+// AcquireLock creates the lock file at path, failing with ErrLocked and the current holder when it exists.
+// holder identifies the operator or process, e.g. "alice@ci-42"
+func AcquireLock(path string, holder string) (*Lock, error) {
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+
+	if os.IsExist(err) {
+
+		info := &LockInfo{}
+		content, _ := ioutil.ReadFile(path)
+		json.Unmarshal(content, info)
+
+		return nil, fmt.Errorf("%w | held by %s (pid %d) since %s, remove %s once it is stale", ErrLocked, info.Holder, info.PID, info.AcquiredAt.Format(time.RFC3339), path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := LockInfo{Holder: holder, PID: os.Getpid(), AcquiredAt: time.Now().UTC()}
+
+	if err := json.NewEncoder(file).Encode(info); err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	return &Lock{path: path}, nil
+}
+
+// Release removes the lock file
+func (l *Lock) Release() error {
+	return os.Remove(l.path)
+}
@@ -0,0 +1,46 @@
+package reconcile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAcquireLock(t *testing.T) {
+
+	// Arrange
+
+	path := filepath.Join(t.TempDir(), "accounts.yaml.lock")
+
+	lock, err := AcquireLock(path, "alice")
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	_, heldErr := AcquireLock(path, "bob")
+
+	releaseErr := lock.Release()
+
+	relock, relockErr := AcquireLock(path, "bob")
+
+	// Assert
+
+	if !errors.Is(heldErr, ErrLocked) || !strings.Contains(heldErr.Error(), "alice") {
+		t.Errorf("unexpected error: got %v want %v naming alice", heldErr, ErrLocked)
+	}
+
+	if releaseErr != nil || relockErr != nil {
+		t.Fatalf("unexpected errors: got %v and %v", releaseErr, relockErr)
+	}
+
+	relock.Release()
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("unexpected lock file: got %v want it removed", err)
+	}
+}
@@ -0,0 +1,173 @@
+// Package reconcile converges the accounts of an organisation to the desired state declared in a YAML or JSON
+// manifest. Plan compares the manifest with the live accounts and Apply executes the resulting actions
+package reconcile
+
+import (
+	"bytes"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestVersion is the manifest layout understood by this package
+const ManifestVersion = 1
+
+// ErrInvalidManifest is returned when a manifest cannot be parsed or is inconsistent
+var ErrInvalidManifest = errors.New("Invalid manifest")
+
+// Manifest declares the accounts an organisation should hold. Keys follow the API json names, e.g.
+//
+//	version: 1
+//	organisation_id: eb0bd6f5-c3f5-44b2-b677-acd23cdde73c
+//	prune: true
+//	protected: [ad27e265-9605-4b4b-a0e5-3003ea9cc4dc]
+//	accounts:
+//	  - id: 7d0b3a35-4a8b-4b0c-9f7e-2f3f5b6a1c11
+//	    attributes: {country: GB, bank_id: "400300", bank_id_code: GBDSC, bic: NWBKGB22, name: [Samantha Holder]}
+type Manifest struct {
+	Version        int    `json:"version"`
+	OrganisationID string `json:"organisation_id"`
+
+	// Prune deletes the live accounts the manifest does not declare. Without it, they are left untouched
+	Prune bool `json:"prune"`
+
+	// Protected lists accounts never deleted, even when pruning
+	Protected []string `json:"protected"`
+
+	Accounts []ManifestAccount `json:"accounts"`
+}
+
+// ManifestAccount is an account declared in a manifest
+type ManifestAccount struct {
+	ID         string                     `json:"id"`
+	Attributes accounts.AccountAttributes `json:"attributes"`
+}
+
+// ParseManifest decodes a YAML or JSON manifest and checks it is consistent. Unknown keys are rejected and ids are
+// stored in their canonical lower case form
+func ParseManifest(content []byte) (*Manifest, error) {
+
+	// YAML is decoded to generic values then converted through JSON, so the manifest keys are the API json names
+	var generic interface{}
+
+	if err := yaml.Unmarshal(content, &generic); err != nil {
+		return nil, fmt.Errorf("%w | %s", ErrInvalidManifest, err)
+	}
+
+	encoded, err := json.Marshal(generic)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w | %s", ErrInvalidManifest, err)
+	}
+
+	manifest := &Manifest{}
+
+	// Unknown keys are rejected, so that a misspelt one, e.g. "protect", fails instead of being ignored
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(manifest); err != nil {
+		return nil, fmt.Errorf("%w | %s", ErrInvalidManifest, err)
+	}
+
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// LoadManifest reads and parses the manifest stored at path
+func LoadManifest(path string) (*Manifest, error) {
+
+	content, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseManifest(content)
+}
+
+func (m *Manifest) validate() error {
+
+	if m.Version != ManifestVersion {
+		return fmt.Errorf("%w | %s", ErrInvalidManifest, fmt.Sprintf("version %d, expected %d", m.Version, ManifestVersion))
+	}
+
+	organisationId, err := uuid.Parse(m.OrganisationID)
+
+	if err != nil {
+		return fmt.Errorf("%w | %s", ErrInvalidManifest, "organisation_id must be a uuid")
+	}
+
+	m.OrganisationID = organisationId.String()
+
+	seen := map[string]bool{}
+
+	for i := range m.Accounts {
+
+		accountId, err := uuid.Parse(m.Accounts[i].ID)
+
+		if err != nil {
+			return fmt.Errorf("%w | %s", ErrInvalidManifest, fmt.Sprintf("accounts[%d].id must be a uuid", i))
+		}
+
+		m.Accounts[i].ID = accountId.String()
+
+		if seen[m.Accounts[i].ID] {
+			return fmt.Errorf("%w | %s", ErrInvalidManifest, fmt.Sprintf("account %s is declared twice", m.Accounts[i].ID))
+		}
+
+		seen[m.Accounts[i].ID] = true
+	}
+
+	for i, protected := range m.Protected {
+
+		accountId, err := uuid.Parse(protected)
+
+		if err != nil {
+			return fmt.Errorf("%w | %s", ErrInvalidManifest, fmt.Sprintf("protected[%d] must be a uuid", i))
+		}
+
+		m.Protected[i] = accountId.String()
+	}
+
+	return nil
+}
+
+// desired returns the declared accounts as the API represents them
+func (m *Manifest) desired() []*accounts.Data {
+
+	desired := make([]*accounts.Data, 0, len(m.Accounts))
+
+	for _, account := range m.Accounts {
+
+		attributes := account.Attributes
+
+		desired = append(desired, &accounts.Data{
+			ID:             account.ID,
+			OrganisationID: m.OrganisationID,
+			Type:           "accounts",
+			Attributes:     &attributes,
+		})
+	}
+
+	return desired
+}
+
+func (m *Manifest) isProtected(accountId string) bool {
+
+	for _, id := range m.Protected {
+		if id == accountId {
+			return true
+		}
+	}
+
+	return false
+}
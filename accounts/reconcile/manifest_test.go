@@ -0,0 +1,134 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	organisationId = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+	keptId         = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	newId          = "7d0b3a35-4a8b-4b0c-9f7e-2f3f5b6a1c11"
+	strayId        = "ba61483c-d5c5-4f50-ae81-6b8c039bea43"
+	protectedId    = "0c8a9e7e-3b5f-4b6e-8a3c-9d2f1e0b7a64"
+)
+
+func TestParseManifest_YAMLAndJSONAgree(t *testing.T) {
+
+	// Arrange
+
+	yamlManifest := `
+version: 1
+organisation_id: ` + organisationId + `
+prune: true
+protected: [` + protectedId + `]
+accounts:
+  - id: ` + keptId + `
+    attributes:
+      country: GB
+      bank_id: "400300"
+      bank_id_code: GBDSC
+      name: [Samantha Holder]
+      account_matching_opt_out: true
+`
+
+	jsonManifest := `{"version": 1, "organisation_id": "` + organisationId + `", "prune": true, "protected": ["` + protectedId + `"],
+		"accounts": [{"id": "` + keptId + `", "attributes": {"country": "GB", "bank_id": "400300", "bank_id_code": "GBDSC",
+		"name": ["Samantha Holder"], "account_matching_opt_out": true}}]}`
+
+	// Act
+
+	fromYAML, yamlErr := ParseManifest([]byte(yamlManifest))
+	fromJSON, jsonErr := ParseManifest([]byte(jsonManifest))
+
+	// Assert
+
+	if yamlErr != nil || jsonErr != nil {
+		t.Fatalf("unexpected errors: got %v and %v", yamlErr, jsonErr)
+	}
+
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("unexpected manifests: got %+v and %+v", fromYAML, fromJSON)
+	}
+
+	attributes := fromYAML.Accounts[0].Attributes
+
+	if attributes.BankID != "400300" || !attributes.AccountMatchingOptOut || !reflect.DeepEqual(attributes.Name, []string{"Samantha Holder"}) {
+		t.Errorf("unexpected attributes: got %+v", attributes)
+	}
+}
+
+func TestParseManifest_RejectsInconsistentManifests(t *testing.T) {
+
+	cases := map[string]struct {
+		manifest string
+	}{
+		"not yaml":             {manifest: "version: [1"},
+		"unknown version":      {manifest: "version: 2\norganisation_id: " + organisationId},
+		"missing organisation": {manifest: "version: 1"},
+		"invalid account id":   {manifest: "version: 1\norganisation_id: " + organisationId + "\naccounts: [{id: account-1}]"},
+		"duplicate account":    {manifest: "version: 1\norganisation_id: " + organisationId + "\naccounts: [{id: " + keptId + "}, {id: " + keptId + "}]"},
+		"duplicate account in another case": {
+			manifest: "version: 1\norganisation_id: " + organisationId + "\naccounts: [{id: " + keptId + "}, {id: " + strings.ToUpper(keptId) + "}]",
+		},
+		"invalid protected id":  {manifest: "version: 1\norganisation_id: " + organisationId + "\nprotected: [account-1]"},
+		"unknown key":           {manifest: "version: 1\norganisation_id: " + organisationId + "\nprotect: [" + protectedId + "]"},
+		"unknown attribute key": {manifest: "version: 1\norganisation_id: " + organisationId + "\naccounts: [{id: " + keptId + ", attributes: {bankid: \"400300\"}}]"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+
+			_, err := ParseManifest([]byte(c.manifest))
+
+			// Assert
+
+			if !errors.Is(err, ErrInvalidManifest) {
+				t.Errorf("unexpected error: got %v want %v", err, ErrInvalidManifest)
+			}
+		})
+	}
+}
+
+func TestParseManifest_UpperCaseIds_AreCanonicalised(t *testing.T) {
+
+	// Arrange
+
+	manifest := "version: 1\norganisation_id: " + strings.ToUpper(organisationId) + "\nprune: true\nprotected: [" + strings.ToUpper(protectedId) + "]" +
+		"\naccounts: [{id: " + strings.ToUpper(keptId) + ", attributes: {bank_id: \"400300\", country: GB}}]"
+
+	// Act
+
+	parsed, err := ParseManifest([]byte(manifest))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	plan, err := NewEngine(liveMock()).Plan(context.Background(), parsed)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if parsed.OrganisationID != organisationId || parsed.Protected[0] != protectedId || parsed.Accounts[0].ID != keptId {
+		t.Errorf("unexpected ids: got %s, %v and %s", parsed.OrganisationID, parsed.Protected, parsed.Accounts[0].ID)
+	}
+
+	for _, action := range plan.Actions {
+		if action.AccountID == protectedId {
+			t.Errorf("unexpected action on the protected account: got %s", action.Kind)
+		}
+	}
+
+	if !reflect.DeepEqual(plan.Skipped, []Skipped{{AccountID: protectedId, Reason: SkipProtected}}) {
+		t.Errorf("unexpected skipped accounts: got %v", plan.Skipped)
+	}
+}
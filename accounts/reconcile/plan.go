@@ -0,0 +1,252 @@
+package reconcile
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/snapshot"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ActionKind is the operation an Action performs
+type ActionKind string
+
+// Kinds of Action, in the order Apply executes them
+const (
+	ActionDelete ActionKind = "delete"
+	ActionUpdate ActionKind = "update"
+	ActionCreate ActionKind = "create"
+)
+
+var actionOrder = map[ActionKind]int{ActionDelete: 0, ActionUpdate: 1, ActionCreate: 2}
+
+// Skip reasons of a live account left untouched although the manifest does not declare it
+const (
+	SkipProtected         = "protected"
+	SkipPruneDisabled     = "prune disabled"
+	SkipOtherOrganisation = "other organisation"
+)
+
+// Action is a change Apply makes to converge an account
+type Action struct {
+	Kind      ActionKind
+	AccountID string
+
+	// Current is the live account, nil for a create
+	Current *accounts.Data
+
+	// Desired is the declared account, nil for a delete
+	Desired *accounts.Data
+
+	// Fields lists the attributes an update changes
+	Fields []snapshot.FieldChange
+}
+
+// Skipped is a live account missing from the manifest which is kept
+type Skipped struct {
+	AccountID string
+	Reason    string
+}
+
+// Plan lists the actions converging the live accounts to a manifest
+type Plan struct {
+	OrganisationID string
+	Actions        []Action
+	Skipped        []Skipped
+}
+
+// Engine plans and applies manifests through an accounts service
+type Engine struct {
+	service accounts.AccountsService
+}
+
+// NewEngine constructs an Engine managing accounts through service
+func NewEngine(service accounts.AccountsService) *Engine {
+	return &Engine{service: service}
+}
+
+// Plan compares the live accounts of the manifest organisation with the declared ones. Undeclared accounts are
+// deleted only when the manifest prunes and does not protect them, and never when they belong to another
+// organisation
+func (e *Engine) Plan(ctx context.Context, manifest *Manifest) (*Plan, error) {
+
+	live, err := snapshot.Take(ctx, e.service, manifest.OrganisationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	desired := &snapshot.Snapshot{Accounts: manifest.desired()}
+	plan := &Plan{OrganisationID: manifest.OrganisationID}
+
+	for _, change := range snapshot.Compare(live, desired).Changes {
+
+		switch change.Kind {
+		case snapshot.Added:
+			plan.Actions = append(plan.Actions, Action{Kind: ActionCreate, AccountID: change.AccountID, Desired: change.After})
+
+		case snapshot.Modified:
+			plan.Actions = append(plan.Actions, Action{Kind: ActionUpdate, AccountID: change.AccountID, Current: change.Before, Desired: change.After, Fields: change.Fields})
+
+		case snapshot.Removed:
+			switch {
+			case change.Before.OrganisationID != manifest.OrganisationID:
+				plan.Skipped = append(plan.Skipped, Skipped{AccountID: change.AccountID, Reason: SkipOtherOrganisation})
+			case manifest.isProtected(change.AccountID):
+				plan.Skipped = append(plan.Skipped, Skipped{AccountID: change.AccountID, Reason: SkipProtected})
+			case !manifest.Prune:
+				plan.Skipped = append(plan.Skipped, Skipped{AccountID: change.AccountID, Reason: SkipPruneDisabled})
+			default:
+				plan.Actions = append(plan.Actions, Action{Kind: ActionDelete, AccountID: change.AccountID, Current: change.Before})
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Actions, func(i, j int) bool {
+		return actionOrder[plan.Actions[i].Kind] < actionOrder[plan.Actions[j].Kind]
+	})
+
+	return plan, nil
+}
+
+// Empty reports whether the live accounts already match the manifest
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// Count returns the number of actions of the given kind
+func (p *Plan) Count(kind ActionKind) int {
+
+	count := 0
+
+	for _, action := range p.Actions {
+		if action.Kind == kind {
+			count++
+		}
+	}
+
+	return count
+}
+
+// String renders the plan with one line per action, the changed attributes of updates, the skipped accounts and
+// a summary
+func (p *Plan) String() string {
+
+	var b strings.Builder
+
+	prefixes := map[ActionKind]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}
+
+	for _, action := range p.Actions {
+
+		fmt.Fprintf(&b, "%s %s %s", prefixes[action.Kind], action.Kind, action.AccountID)
+
+		if action.Kind == ActionDelete {
+			fmt.Fprintf(&b, " (version %d)", action.Current.Version)
+		}
+
+		b.WriteString("\n")
+
+		for _, field := range action.Fields {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", field.Field, field.Before, field.After)
+		}
+	}
+
+	for _, skipped := range p.Skipped {
+		fmt.Fprintf(&b, "  keep %s (%s)\n", skipped.AccountID, skipped.Reason)
+	}
+
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete\n", p.Count(ActionCreate), p.Count(ActionUpdate), p.Count(ActionDelete))
+
+	return b.String()
+}
+
+// ActionResult is the outcome of an applied Action
+type ActionResult struct {
+	Action Action
+	Err    error
+}
+
+// ErrApplyFailed is returned by Apply when at least one action failed
+var ErrApplyFailed = errors.New("Some actions could not be applied")
+
+// Apply executes the plan actions in order, deletes first, and carries on past failures. Deletes and updates are
+// made at the version seen while planning, so an account changed in between fails rather than being overwritten.
+// A delete of an account outside the plan organisation fails with ValidationError without being sent. Creates are
+// idempotent: an account already holding the desired attributes counts as created
+func (e *Engine) Apply(ctx context.Context, plan *Plan) ([]ActionResult, error) {
+
+	results := make([]ActionResult, 0, len(plan.Actions))
+	failed := 0
+
+	for _, action := range plan.Actions {
+
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		err := e.apply(ctx, plan.OrganisationID, action)
+
+		if err != nil {
+			failed++
+		}
+
+		results = append(results, ActionResult{Action: action, Err: err})
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%w | %d of %d", ErrApplyFailed, failed, len(plan.Actions))
+	}
+
+	return results, nil
+}
+
+func (e *Engine) apply(ctx context.Context, organisationID string, action Action) error {
+
+	accountId, err := uuid.Parse(action.AccountID)
+
+	if err != nil {
+		return fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, err)
+	}
+
+	switch action.Kind {
+	case ActionDelete:
+		if action.Current == nil || action.Current.OrganisationID != organisationID {
+			return fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest,
+				fmt.Sprintf("account %s does not belong to organisation %s", action.AccountID, organisationID))
+		}
+
+		return e.service.Delete(ctx, accountId, action.Current.Version)
+
+	case ActionUpdate:
+		desired := *action.Desired
+		desired.Version = action.Current.Version
+		_, err := e.service.Update(ctx, &accounts.AccountData{Data: &desired})
+		return err
+	}
+
+	_, err = e.service.Create(ctx, &accounts.AccountData{Data: action.Desired})
+
+	if err == nil {
+		return nil
+	}
+
+	existing, fetchErr := e.service.Fetch(ctx, accountId)
+
+	if fetchErr != nil || existing == nil || existing.AccountData == nil || existing.Data == nil {
+		return err
+	}
+
+	before := &snapshot.Snapshot{Accounts: []*accounts.Data{existing.Data}}
+	after := &snapshot.Snapshot{Accounts: []*accounts.Data{action.Desired}}
+
+	if !snapshot.Compare(before, after).Empty() {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package reconcile
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/accountsmock"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func liveAccount(id string, bankId string, version int) *accounts.Data {
+	return &accounts.Data{
+		ID:             id,
+		OrganisationID: organisationId,
+		Type:           "accounts",
+		Version:        version,
+		Attributes:     &accounts.AccountAttributes{BankID: bankId, Country: "GB"},
+	}
+}
+
+func testManifest(prune bool) *Manifest {
+	return &Manifest{
+		Version:        ManifestVersion,
+		OrganisationID: organisationId,
+		Prune:          prune,
+		Protected:      []string{protectedId},
+		Accounts: []ManifestAccount{
+			{ID: keptId, Attributes: accounts.AccountAttributes{BankID: "400301", Country: "GB"}},
+			{ID: newId, Attributes: accounts.AccountAttributes{BankID: "400300", Country: "GB"}},
+		},
+	}
+}
+
+func liveMock() *accountsmock.Mock {
+	return accountsmock.New().Script(accountsmock.MethodList, accountsmock.Result{Response: &accounts.AccountListResponse{Data: []*accounts.Data{
+		liveAccount(keptId, "400300", 2),
+		liveAccount(strayId, "400300", 5),
+		liveAccount(protectedId, "400300", 0),
+	}}})
+}
+
+func TestPlan(t *testing.T) {
+
+	cases := map[string]struct {
+		prune   bool
+		actions []string
+		skipped []Skipped
+	}{
+		"prune": {
+			prune:   true,
+			actions: []string{"delete " + strayId, "update " + keptId, "create " + newId},
+			skipped: []Skipped{{AccountID: protectedId, Reason: SkipProtected}},
+		},
+		"no prune": {
+			actions: []string{"update " + keptId, "create " + newId},
+			skipped: []Skipped{{AccountID: protectedId, Reason: SkipProtected}, {AccountID: strayId, Reason: SkipPruneDisabled}},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+
+			plan, err := NewEngine(liveMock()).Plan(context.Background(), testManifest(c.prune))
+
+			// Assert
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			actions := []string{}
+
+			for _, action := range plan.Actions {
+				actions = append(actions, string(action.Kind)+" "+action.AccountID)
+			}
+
+			if !reflect.DeepEqual(actions, c.actions) {
+				t.Errorf("unexpected actions: got %v want %v", actions, c.actions)
+			}
+
+			if !reflect.DeepEqual(plan.Skipped, c.skipped) {
+				t.Errorf("unexpected skipped accounts: got %v want %v", plan.Skipped, c.skipped)
+			}
+		})
+	}
+}
+
+func TestApply_UsesThePlannedVersions(t *testing.T) {
+
+	// Arrange
+
+	mock := liveMock().
+		Script(accountsmock.MethodDelete, accountsmock.Result{}).
+		Script(accountsmock.MethodUpdate, accountsmock.Result{Response: &accounts.AccountResponse{}}).
+		Script(accountsmock.MethodCreate, accountsmock.Result{Response: &accounts.AccountResponse{}})
+	engine := NewEngine(mock)
+
+	plan, err := engine.Plan(context.Background(), testManifest(true))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	results, err := engine.Apply(context.Background(), plan)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("unexpected number of results: got %v want 3", len(results))
+	}
+
+	deleteArgs := mock.CallsTo(accountsmock.MethodDelete)[0].Args
+
+	if deleteArgs[0] != uuid.MustParse(strayId) || deleteArgs[1] != 5 {
+		t.Errorf("unexpected delete: got %v want %v at version 5", deleteArgs, strayId)
+	}
+
+	updated := mock.CallsTo(accountsmock.MethodUpdate)[0].Args[0].(*accounts.AccountData).Data
+
+	if updated.ID != keptId || updated.Version != 2 || updated.Attributes.BankID != "400301" {
+		t.Errorf("unexpected update: got %+v want %v at version 2", updated, keptId)
+	}
+
+	created := mock.CallsTo(accountsmock.MethodCreate)[0].Args[0].(*accounts.AccountData).Data
+
+	if created.ID != newId || created.OrganisationID != organisationId {
+		t.Errorf("unexpected create: got %+v want %v", created, newId)
+	}
+}
+
+func TestApply_CreatesAreIdempotent(t *testing.T) {
+
+	conflict := errors.New("conflict")
+
+	cases := map[string]struct {
+		existing *accounts.Data
+		wantErr  bool
+	}{
+		"account already as declared": {existing: liveAccount(newId, "400300", 0)},
+		"account differs":             {existing: liveAccount(newId, "400399", 0), wantErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			mock := accountsmock.New().
+				Script(accountsmock.MethodCreate, accountsmock.Result{Err: conflict}).
+				Script(accountsmock.MethodFetch, accountsmock.Result{Response: &accounts.AccountResponse{AccountData: &accounts.AccountData{Data: c.existing}}})
+
+			plan := &Plan{Actions: []Action{{Kind: ActionCreate, AccountID: newId, Desired: liveAccount(newId, "400300", 0)}}}
+
+			// Act
+
+			results, err := NewEngine(mock).Apply(context.Background(), plan)
+
+			// Assert
+
+			if c.wantErr {
+				if !errors.Is(err, ErrApplyFailed) || !errors.Is(results[0].Err, conflict) {
+					t.Errorf("unexpected errors: got %v and %v want %v", err, results[0].Err, conflict)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: got %v want nil", err)
+			}
+		})
+	}
+}
+
+func TestPlan_AccountsOfOtherOrganisations_AreNeverDeleted(t *testing.T) {
+
+	// Arrange
+
+	foreign := liveAccount(strayId, "400300", 5)
+	foreign.OrganisationID = "0b2b7a7e-6a8e-4a47-9b5e-6e5b2ef0a1c7"
+
+	mock := accountsmock.New().Script(accountsmock.MethodList, accountsmock.Result{Response: &accounts.AccountListResponse{Data: []*accounts.Data{
+		liveAccount(keptId, "400301", 2),
+		foreign,
+	}}})
+
+	// Act
+
+	plan, err := NewEngine(mock).Plan(context.Background(), testManifest(true))
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if plan.Count(ActionDelete) != 0 {
+		t.Errorf("unexpected actions: got %+v want no delete", plan.Actions)
+	}
+}
+
+func TestApply_DeleteOutsideThePlanOrganisation_Fails(t *testing.T) {
+
+	foreign := liveAccount(strayId, "400300", 5)
+	foreign.OrganisationID = "0b2b7a7e-6a8e-4a47-9b5e-6e5b2ef0a1c7"
+
+	cases := map[string]struct {
+		current *accounts.Data
+	}{
+		"account of another organisation": {current: foreign},
+		"account without current state":   {current: nil},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			mock := accountsmock.New().Script(accountsmock.MethodDelete, accountsmock.Result{})
+			plan := &Plan{OrganisationID: organisationId, Actions: []Action{{Kind: ActionDelete, AccountID: strayId, Current: c.current}}}
+
+			// Act
+
+			results, err := NewEngine(mock).Apply(context.Background(), plan)
+
+			// Assert
+
+			if !errors.Is(err, ErrApplyFailed) || !errors.Is(results[0].Err, accounts.ValidationError) {
+				t.Errorf("unexpected errors: got %v and %v want %v", err, results[0].Err, accounts.ValidationError)
+			}
+
+			if calls := mock.CallsTo(accountsmock.MethodDelete); len(calls) != 0 {
+				t.Errorf("unexpected deletes: got %v want none", calls)
+			}
+		})
+	}
+}
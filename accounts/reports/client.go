@@ -0,0 +1,106 @@
+// Package reports requests, waits for and streams the download of account statements and reports, built on the
+// accounts client core
+package reports
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default values used by the reports client
+const (
+	ReportsPath         = "/v1/reports"
+	DefaultPollInterval = accounts.DefaultPollInterval
+	DefaultMaxResumes   = 3
+	reportType          = "reports"
+	contentSuffix       = "content"
+)
+
+// Client may be used to make reports requests to the Form3 API
+type Client struct {
+	core    *accounts.Client
+	reports *accounts.Resource[Report]
+}
+
+// NewClient constructs a reports Client sending its requests through core. Circuit breaker endpoints are
+// prefixed with "reports."
+func NewClient(core *accounts.Client) *Client {
+	return &Client{
+		core:    core,
+		reports: accounts.NewResource[Report](core, "reports", ReportsPath),
+	}
+}
+
+// Request asks for the generation of a report, which starts pending. A nil report fails with
+// accounts.ValidationError
+func (c *Client) Request(ctx context.Context, report *Report) (*accounts.Response[Report], error) {
+
+	if report == nil {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, "report is required")
+	}
+
+	if report.Type == "" {
+		report.Type = reportType
+	}
+
+	return c.reports.Create(ctx, &accounts.Document[Report]{Data: report})
+}
+
+// RequestStatement asks for the statement of the given account between two YYYY-MM-DD dates. A nil account fails
+// with accounts.ValidationError
+func (c *Client) RequestStatement(ctx context.Context, account *accounts.Data, format Format, fromDate string, toDate string) (*accounts.Response[Report], error) {
+
+	if account == nil {
+		return nil, fmt.Errorf("%w | %d | %s", accounts.ValidationError, http.StatusBadRequest, "account is required")
+	}
+
+	return c.Request(ctx, &Report{
+		ID:             uuid.New().String(),
+		OrganisationID: account.OrganisationID,
+		Attributes: &ReportAttributes{
+			Format:     format,
+			ReportType: TypeStatement,
+			FromDate:   fromDate,
+			ToDate:     toDate,
+		},
+		Relationships: accounts.Relationships{
+			RelationshipAccount: {Data: []accounts.ResourceIdentifier{{ID: account.ID, Type: "accounts"}}},
+		},
+	})
+}
+
+// Fetch retrieves the report with the given id
+func (c *Client) Fetch(ctx context.Context, reportId uuid.UUID) (*accounts.Response[Report], error) {
+	return c.reports.Fetch(ctx, reportId.String())
+}
+
+// WaitUntilReady polls the report every interval until it is ready, failing when its generation fails. The last
+// fetched report is returned along with the context error when the context is done first
+func (c *Client) WaitUntilReady(ctx context.Context, reportId uuid.UUID, interval time.Duration) (*accounts.Response[Report], error) {
+	return accounts.Poll(ctx, interval, func() (*accounts.Response[Report], bool, error) {
+
+		response, err := c.Fetch(ctx, reportId)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if response.Data.Attributes == nil {
+			return response, false, nil
+		}
+
+		switch response.Data.Attributes.Status {
+		case StatusReady:
+			return response, true, nil
+		case StatusFailed:
+			return response, true, fmt.Errorf("%w | %d | %s", accounts.ApiHttpErrorType, http.StatusUnprocessableEntity, "report generation failed: "+response.Data.Attributes.StatusReason)
+		}
+
+		return response, false, nil
+	})
+}
@@ -0,0 +1,209 @@
+package reports
+
+import (
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// EndpointDownload names report downloads for the circuit breaker
+const EndpointDownload = "reports.download"
+
+// DownloadOptions configures Download
+type DownloadOptions struct {
+
+	// Format selects the requested media type; the server default is used when empty
+	Format Format
+
+	// MaxResumes is the number of times an interrupted transfer is resumed. Zero uses DefaultMaxResumes and a
+	// negative value disables resuming
+	MaxResumes int
+}
+
+// Download streams the content of a ready report into w and returns the number of bytes written. Only a small
+// buffer is held in memory. When the transfer is interrupted it resumes where it stopped with a Range request,
+// guarded by an If-Range of the ETag, or of the Last-Modified date when there is no ETag, so a regenerated file is
+// never spliced. Without either validator the download restarts from the first byte, which requires w to be a
+// file, or another io.Seeker with a Truncate method; other writers fail. Failures to write into w are not retried
+func (c *Client) Download(ctx context.Context, reportId uuid.UUID, w io.Writer, options *DownloadOptions) (int64, error) {
+
+	if options == nil {
+		options = &DownloadOptions{}
+	}
+
+	maxResumes := options.MaxResumes
+
+	if maxResumes == 0 {
+		maxResumes = DefaultMaxResumes
+	}
+
+	path := ReportsPath + "/" + reportId.String() + "/" + contentSuffix
+	target := &trackingWriter{w: w}
+	validator := ""
+
+	for attempt := 0; ; attempt++ {
+
+		resumable, err := c.transfer(ctx, path, options.Format, target, &validator)
+
+		if err == nil || !resumable || target.err != nil || ctx.Err() != nil || attempt >= maxResumes {
+			return target.written, err
+		}
+	}
+}
+
+// transfer requests the bytes following the ones already written and copies them into target. Connection and
+// transfer failures are resumable, API errors are not
+func (c *Client) transfer(ctx context.Context, path string, format Format, target *trackingWriter, validator *string) (bool, error) {
+
+	header := http.Header{}
+
+	if format != "" {
+		header.Set("Accept", format.ContentType())
+	}
+
+	if target.written > 0 && *validator == "" {
+		if err := target.rewind(); err != nil {
+			return false, err
+		}
+	}
+
+	if target.written > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", target.written))
+		header.Set("If-Range", *validator)
+	}
+
+	httpResp, err := c.core.Raw(ctx, http.MethodGet, path, nil, header, EndpointDownload)
+
+	if err != nil {
+		return errors.Is(err, accounts.BuildingRequestError), err
+	}
+
+	if err := accounts.ResponseError(httpResp); err != nil {
+		return false, err
+	}
+
+	defer httpResp.Body.Close()
+
+	if err := position(httpResp, target.written, *validator); err != nil {
+		return false, err
+	}
+
+	if target.written == 0 {
+		*validator = validatorOf(httpResp)
+	}
+
+	if _, err := io.Copy(target, httpResp.Body); err != nil {
+		return true, fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, httpResp.StatusCode, err)
+	}
+
+	return false, nil
+}
+
+// position moves the response body to offset. A 206 must start at offset; a 200 means the range was ignored, in
+// which case the bytes already written are skipped, provided the file did not change
+func position(httpResp *http.Response, offset int64, validator string) error {
+
+	if offset == 0 {
+		return nil
+	}
+
+	if httpResp.StatusCode == http.StatusPartialContent {
+
+		if start := contentRangeStart(httpResp.Header.Get("Content-Range")); start != offset {
+			return fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, httpResp.StatusCode, fmt.Sprintf("resumed at byte %d instead of %d", start, offset))
+		}
+
+		return nil
+	}
+
+	if validatorOf(httpResp) != validator {
+		return fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, httpResp.StatusCode, "report content changed during the download")
+	}
+
+	if _, err := io.CopyN(ioutil.Discard, httpResp.Body, offset); err != nil {
+		return fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, httpResp.StatusCode, err)
+	}
+
+	return nil
+}
+
+// validatorOf returns the ETag of the response, or its Last-Modified date when it has no ETag
+func validatorOf(httpResp *http.Response) string {
+
+	if etag := httpResp.Header.Get("ETag"); etag != "" {
+		return etag
+	}
+
+	return httpResp.Header.Get("Last-Modified")
+}
+
+// contentRangeStart returns the first byte of a "bytes start-end/size" Content-Range header, or -1
+func contentRangeStart(contentRange string) int64 {
+
+	rangeSpec := strings.TrimPrefix(contentRange, "bytes ")
+	dash := strings.Index(rangeSpec, "-")
+
+	if dash < 0 {
+		return -1
+	}
+
+	start, err := strconv.ParseInt(rangeSpec[:dash], 10, 64)
+
+	if err != nil {
+		return -1
+	}
+
+	return start
+}
+
+// trackingWriter counts the bytes written into w and keeps the first write error, which stops the download
+type trackingWriter struct {
+	w       io.Writer
+	written int64
+	err     error
+}
+
+// rewind truncates w and moves back to its start, so that the download restarts from the first byte
+func (t *trackingWriter) rewind() error {
+
+	rewindable, ok := t.w.(interface {
+		io.Seeker
+		Truncate(size int64) error
+	})
+
+	if !ok {
+		return fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, http.StatusPreconditionFailed, "report content has no ETag or Last-Modified validator to resume the download")
+	}
+
+	if err := rewindable.Truncate(0); err != nil {
+		return fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, http.StatusPreconditionFailed, err)
+	}
+
+	if _, err := rewindable.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("%w | %d | %s", accounts.BuildingRequestError, http.StatusPreconditionFailed, err)
+	}
+
+	t.written = 0
+
+	return nil
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+
+	n, err := t.w.Write(p)
+	t.written += int64(n)
+
+	if err != nil && t.err == nil {
+		t.err = err
+	}
+
+	return n, err
+}
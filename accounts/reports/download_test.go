@@ -0,0 +1,340 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var reportId = uuid.MustParse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(ReportsPath+"/", handler)
+	ts := httptest.NewServer(mux)
+
+	core, err := accounts.NewClient(accounts.WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return NewClient(core), ts
+}
+
+func TestDownload_InterruptedTransfer_ResumesWithRangeRequest(t *testing.T) {
+
+	// Arrange
+
+	content := []byte(strings.Repeat("2021-08-01,100.21,GBP,Payment for Em's piano lessons\n", 2000))
+	var ranges []string
+
+	client, ts := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+
+		ranges = append(ranges, r.Header.Get("Range"))
+
+		if len(ranges) == 1 {
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.Write(content[:len(content)/3])
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "statement.csv", time.Time{}, bytes.NewReader(content))
+	})
+
+	defer ts.Close()
+
+	var downloaded bytes.Buffer
+
+	// Act
+
+	written, err := client.Download(context.Background(), reportId, &downloaded, &DownloadOptions{Format: FormatCSV})
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("download returned an error: got %v want %v", err, nil)
+	}
+
+	if written != int64(len(content)) || !bytes.Equal(downloaded.Bytes(), content) {
+		t.Errorf("download wrote unexpected content: got %d bytes want %d", written, len(content))
+	}
+
+	if len(ranges) != 2 || ranges[0] != "" || ranges[1] != "bytes="+strconv.Itoa(len(content)/3)+"-" {
+		t.Errorf("server received unexpected ranges: got %q", ranges)
+	}
+}
+
+func TestDownload_ApiError_IsNotRetried(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	client, ts := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error_message":"report not found"}`)
+	})
+
+	defer ts.Close()
+
+	// Act
+
+	written, err := client.Download(context.Background(), reportId, io.Discard, nil)
+
+	// Assert
+
+	if written != 0 || !errors.Is(err, accounts.ApiHttpErrorType) {
+		t.Errorf("download returned unexpected result: got %d, %v want %d, %v", written, err, 0, accounts.ApiHttpErrorType)
+	}
+
+	if requests != 1 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 1)
+	}
+}
+
+func TestWaitUntilReady_pollsUntilReportIsReady(t *testing.T) {
+
+	// Arrange
+
+	statuses := []Status{StatusPending, StatusPending, StatusReady}
+	polls := 0
+
+	client, ts := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[polls]
+		polls++
+		io.WriteString(w, `{"data":{"id":"`+reportId.String()+`","type":"reports","attributes":{"format":"camt.053","status":"`+string(status)+`"}}}`)
+	})
+
+	defer ts.Close()
+
+	// Act
+
+	report, err := client.WaitUntilReady(context.Background(), reportId, time.Millisecond)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("wait returned an error: got %v want %v", err, nil)
+	}
+
+	if report.Data.Attributes.Status != StatusReady || polls != len(statuses) {
+		t.Errorf("wait returned unexpected report: got %s after %d polls", report.Data.Attributes.Status, polls)
+	}
+}
+
+func TestDownload_InterruptedTransferWithoutETag_ResumesOnLastModified(t *testing.T) {
+
+	// Arrange
+
+	content := []byte(strings.Repeat("2021-08-01,100.21,GBP,Payment for Em's piano lessons\n", 2000))
+	modified := time.Date(2021, 8, 2, 6, 0, 0, 0, time.UTC)
+	var ifRanges []string
+
+	client, ts := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+
+		ifRanges = append(ifRanges, r.Header.Get("If-Range"))
+
+		if len(ifRanges) == 1 {
+			w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.Write(content[:len(content)/3])
+			return
+		}
+
+		http.ServeContent(w, r, "statement.csv", modified, bytes.NewReader(content))
+	})
+
+	defer ts.Close()
+
+	var downloaded bytes.Buffer
+
+	// Act
+
+	written, err := client.Download(context.Background(), reportId, &downloaded, nil)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("download returned an error: got %v want %v", err, nil)
+	}
+
+	if written != int64(len(content)) || !bytes.Equal(downloaded.Bytes(), content) {
+		t.Errorf("download wrote unexpected content: got %d bytes want %d", written, len(content))
+	}
+
+	if len(ifRanges) != 2 || ifRanges[1] != modified.Format(http.TimeFormat) {
+		t.Errorf("server received unexpected If-Range headers: got %q", ifRanges)
+	}
+}
+
+func TestDownload_InterruptedTransferWithoutValidator_RestartsFromTheFirstByte(t *testing.T) {
+
+	content := []byte(strings.Repeat("2021-08-01,100.21,GBP,Payment for Em's piano lessons\n", 2000))
+
+	cases := map[string]struct {
+		writer            func(t *testing.T) io.Writer
+		expectedRanges    []string
+		expectedErrorType error
+	}{
+		"File is rewritten": {
+			writer: func(t *testing.T) io.Writer {
+
+				file, err := os.CreateTemp(t.TempDir(), "statement-*.csv")
+
+				if err != nil {
+					t.Fatalf(err.Error())
+				}
+
+				t.Cleanup(func() { file.Close() })
+
+				return file
+			},
+			expectedRanges: []string{"", ""},
+		},
+		"Buffer cannot be rewound": {
+			writer:            func(t *testing.T) io.Writer { return &bytes.Buffer{} },
+			expectedRanges:    []string{""},
+			expectedErrorType: accounts.BuildingRequestError,
+		},
+	}
+
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			var ranges []string
+
+			client, ts := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+
+				ranges = append(ranges, r.Header.Get("Range"))
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+
+				if len(ranges) == 1 {
+					w.Write(content[:len(content)/3])
+					return
+				}
+
+				w.Write(content)
+			})
+
+			defer ts.Close()
+
+			writer := testCase.writer(t)
+
+			// Act
+
+			written, err := client.Download(context.Background(), reportId, writer, nil)
+
+			// Assert
+
+			if !reflect.DeepEqual(ranges, testCase.expectedRanges) {
+				t.Errorf("server received unexpected ranges: got %q want %q", ranges, testCase.expectedRanges)
+			}
+
+			if testCase.expectedErrorType != nil {
+				if !errors.Is(err, testCase.expectedErrorType) {
+					t.Errorf("unexpected error: got %v want %v", err, testCase.expectedErrorType)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("download returned an error: got %v want %v", err, nil)
+			}
+
+			downloaded, err := os.ReadFile(writer.(*os.File).Name())
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			if written != int64(len(content)) || !bytes.Equal(downloaded, content) {
+				t.Errorf("download wrote unexpected content: got %d bytes want %d", len(downloaded), len(content))
+			}
+		})
+	}
+}
+
+func TestWaitUntilReady_ReportWithoutData_ReturnsBuildingRequestError(t *testing.T) {
+
+	// Arrange
+
+	client, ts := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"data":null}`)
+	})
+
+	defer ts.Close()
+
+	// Act
+
+	report, err := client.WaitUntilReady(context.Background(), reportId, time.Millisecond)
+
+	// Assert
+
+	if report != nil {
+		t.Errorf("Returned report: got %v want %v", report, nil)
+	}
+
+	if !errors.Is(err, accounts.BuildingRequestError) {
+		t.Errorf("unexpected error: got %v want %v", err, accounts.BuildingRequestError)
+	}
+}
+
+func TestRequest_NilArgument_ReturnsValidationError(t *testing.T) {
+
+	cases := map[string]func(c *Client) (*accounts.Response[Report], error){
+		"Nil report": func(c *Client) (*accounts.Response[Report], error) {
+			return c.Request(context.Background(), nil)
+		},
+		"Nil statement account": func(c *Client) (*accounts.Response[Report], error) {
+			return c.RequestStatement(context.Background(), nil, FormatCSV, "2021-07-01", "2021-07-31")
+		},
+	}
+
+	for name, request := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			requests := 0
+
+			client, ts := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+			})
+
+			defer ts.Close()
+
+			// Act
+
+			report, err := request(client)
+
+			// Assert
+
+			if report != nil || !errors.Is(err, accounts.ValidationError) {
+				t.Errorf("request returned unexpected result: got %v, %v want %v", report, err, accounts.ValidationError)
+			}
+
+			if requests != 0 {
+				t.Errorf("server received unexpected number of requests: got %d want %d", requests, 0)
+			}
+		})
+	}
+}
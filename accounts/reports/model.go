@@ -0,0 +1,72 @@
+package reports
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"time"
+)
+
+// Format is the file format of a report
+type Format string
+
+// Report formats
+const (
+	FormatCSV     Format = "csv"
+	FormatCamt053 Format = "camt.053"
+	FormatPDF     Format = "pdf"
+)
+
+// ContentType returns the media type of the format, sent as the Accept header of downloads
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv"
+	case FormatCamt053:
+		return "application/xml"
+	case FormatPDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+// Report types
+const (
+	TypeStatement = "statement"
+)
+
+// Status is the generation status of a report
+type Status string
+
+// Report statuses
+const (
+	StatusPending Status = "pending"
+	StatusReady   Status = "ready"
+	StatusFailed  Status = "failed"
+)
+
+// RelationshipAccount names the relationship linking a statement to its account
+const RelationshipAccount = "account"
+
+// Report is a generated report, such as an account statement
+type Report struct {
+	Attributes     *ReportAttributes      `json:"attributes"`
+	CreatedOn      time.Time              `json:"created_on,omitempty"`
+	ID             string                 `json:"id"`
+	ModifiedOn     time.Time              `json:"modified_on,omitempty"`
+	OrganisationID string                 `json:"organisation_id"`
+	Relationships  accounts.Relationships `json:"relationships,omitempty"`
+	Type           string                 `json:"type"`
+	Version        int                    `json:"version"`
+}
+
+// ReportAttributes holds the parameters and generation status of a report
+type ReportAttributes struct {
+	Format     Format `json:"format"`
+	ReportType string `json:"report_type"`
+	// FromDate and ToDate use the YYYY-MM-DD layout
+	FromDate     string `json:"from_date"`
+	ToDate       string `json:"to_date"`
+	Status       Status `json:"status,omitempty"`
+	StatusReason string `json:"status_reason,omitempty"`
+	// Size is the length of the file in bytes, once ready
+	Size int64 `json:"size,omitempty"`
+}
@@ -0,0 +1,285 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Document is a JSON:API document holding a single resource of type T
+type Document[T any] struct {
+	Data  *T     `json:"data"`
+	Links *Links `json:"links,omitempty"`
+	Meta  Meta   `json:"meta,omitempty"`
+}
+
+// ListDocument is a JSON:API document holding a page of resources of type T
+type ListDocument[T any] struct {
+	Data  []*T       `json:"data"`
+	Links *ListLinks `json:"links,omitempty"`
+	Meta  Meta       `json:"meta,omitempty"`
+}
+
+// Meta holds the non-standard information attached to a JSON:API document
+type Meta map[string]interface{}
+
+// Relationships links a resource to other resources, keyed by relationship name, e.g. "beneficiary_account"
+type Relationships map[string]*Relationship
+
+// Relationship lists the resources linked to a resource under a given name
+type Relationship struct {
+	Data  []ResourceIdentifier `json:"data"`
+	Links *Links               `json:"links,omitempty"`
+}
+
+// ResourceIdentifier identifies a resource within a relationship
+type ResourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Response returned by a Resource containing a single resource and the http status of the call
+type Response[T any] struct {
+	Document[T]
+	apiCommonResult
+}
+
+// ListResponse returned by a Resource containing a page of resources and the http status of the call
+type ListResponse[T any] struct {
+	ListDocument[T]
+	apiCommonResult
+}
+
+// HasNext reports whether there is a page after this one
+func (r *ListResponse[T]) HasNext() bool {
+	return r.Links != nil && r.Links.Next != ""
+}
+
+// Resource gives access to a Form3 JSON:API collection, such as /v1/organisation/accounts, holding resources of type T.
+// Requests go through the client, sharing its base url, rate limiter and circuit breakers
+type Resource[T any] struct {
+	client *Client
+	name   string
+	config *apiConfig
+}
+
+// NewResource constructs a Resource for the collection found at path, e.g. "/v1/transaction/payments".
+// name prefixes the endpoint names used by the circuit breaker, e.g. "payments.create"
+func NewResource[T any](client *Client, name string, path string) *Resource[T] {
+	return &Resource[T]{
+		client: client,
+		name:   name,
+		config: &apiConfig{host: AccountsApiDefaultUrl.host, path: path},
+	}
+}
+
+// Path returns the path of the collection
+func (r *Resource[T]) Path() string {
+	return r.config.path
+}
+
+// Create issues a POST request storing the given document
+func (r *Resource[T]) Create(ctx context.Context, document *Document[T]) (*Response[T], error) {
+	return r.single(ctx, http.MethodPost, r.config.path, nil, nil, document, r.endpoint(EndpointCreate))
+}
+
+// Fetch retrieves the resource with the given id
+func (r *Resource[T]) Fetch(ctx context.Context, id string) (*Response[T], error) {
+	return r.single(ctx, http.MethodGet, r.config.path+"/"+id, nil, nil, nil, r.endpoint(EndpointFetch))
+}
+
+// Patch issues a PATCH request updating the resource with the given id
+func (r *Resource[T]) Patch(ctx context.Context, id string, document *Document[T]) (*Response[T], error) {
+	return r.single(ctx, http.MethodPatch, r.config.path+"/"+id, nil, nil, document, r.endpoint(EndpointUpdate))
+}
+
+// List retrieves a page of resources
+func (r *Resource[T]) List(ctx context.Context, options *ListOptions) (*ListResponse[T], error) {
+
+	if options == nil {
+		options = &ListOptions{}
+	}
+
+	httpResp, err := r.client.send(ctx, http.MethodGet, r.config, r.config.path, listQuery(options), nil, nil, r.endpoint(EndpointList))
+
+	if err != nil {
+		return nil, requestError(httpResp, err)
+	}
+
+	listResponse := &ListResponse[T]{}
+
+	if err := r.client.decodeResponse(httpResp, listResponse, &listResponse.apiCommonResult, r.endpoint(EndpointList)); err != nil {
+		return nil, err
+	}
+
+	if err := listResponse.Error(); err != nil {
+		return nil, err
+	}
+
+	return listResponse, nil
+}
+
+// Delete removes the resource with the given id and version
+func (r *Resource[T]) Delete(ctx context.Context, id string, version int) error {
+
+	query := url.Values{}
+	query.Set("version", strconv.Itoa(version))
+
+	httpResp, err := r.client.send(ctx, http.MethodDelete, r.config, r.config.path+"/"+id, query, nil, nil, r.endpoint(EndpointDelete))
+
+	if err != nil {
+		return requestError(httpResp, err)
+	}
+
+	defer httpResp.Body.Close()
+
+	body, err := readBody(httpResp)
+
+	if err != nil {
+		return fmt.Errorf("%w | %d | %s", BuildingRequestError, httpResp.StatusCode, err)
+	}
+
+	if !isHttpCodeOK(httpResp.StatusCode) {
+		return newApiError(httpResp, body)
+	}
+
+	return nil
+}
+
+// single sends a request answered by a document holding one resource. Successful responses without data, e.g. an
+// empty body or {}, fail with BuildingRequestError so that callers can rely on Data
+func (r *Resource[T]) single(ctx context.Context, method string, path string, query url.Values, header http.Header, document *Document[T], endpoint string) (*Response[T], error) {
+
+	var body interface{}
+
+	if document != nil {
+		body = document
+	}
+
+	httpResp, err := r.client.send(ctx, method, r.config, path, query, header, body, endpoint)
+
+	if err != nil {
+		return nil, requestError(httpResp, err)
+	}
+
+	response := &Response[T]{}
+
+	if err := r.client.decodeResponse(httpResp, response, &response.apiCommonResult, endpoint); err != nil {
+		return nil, err
+	}
+
+	if err := response.Error(); err != nil {
+		return nil, err
+	}
+
+	if response.Data == nil {
+		return nil, noDataError(response.Status)
+	}
+
+	return response, nil
+}
+
+// noDataError fails a successful response which holds no data
+func noDataError(status int) error {
+	return fmt.Errorf("%w | %d | %s", BuildingRequestError, status, "response has no data")
+}
+
+// endpoint returns the circuit breaker endpoint name of an operation on this resource
+func (r *Resource[T]) endpoint(operation string) string {
+	if r.name == "" {
+		return operation
+	}
+	return r.name + "." + operation
+}
+
+// Raw sends a request against path, resolved from the base url like the other operations, and returns the
+// response without reading its body, which the caller must close. It lets packages stream large responses, e.g.
+// report downloads. endpoint names the operation for the circuit breaker
+func (c *Client) Raw(ctx context.Context, method string, path string, query url.Values, header http.Header, endpoint string) (*http.Response, error) {
+
+	httpResp, err := c.send(ctx, method, &apiConfig{host: AccountsApiDefaultUrl.host, path: path}, path, query, header, nil, endpoint)
+
+	if err != nil {
+		return nil, requestError(httpResp, err)
+	}
+
+	return httpResp, nil
+}
+
+// ResponseError returns the ApiError described by a non successful response returned by Raw, reading and closing
+// its body, or nil when the response status is successful
+func ResponseError(httpResp *http.Response) error {
+
+	if isHttpCodeOK(httpResp.StatusCode) {
+		return nil
+	}
+
+	defer httpResp.Body.Close()
+
+	body, err := readBody(httpResp)
+
+	if err != nil {
+		return fmt.Errorf("%w | %d | %s", BuildingRequestError, httpResp.StatusCode, err)
+	}
+
+	return newApiError(httpResp, body)
+}
+
+// send marshals body, if any, and sends the request through the client
+func (c *Client) send(ctx context.Context, method string, config *apiConfig, path string, query url.Values, header http.Header, body interface{}, endpoint string) (*http.Response, error) {
+
+	var reader io.Reader
+	var content []byte
+
+	if body != nil {
+		var err error
+		content, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewBuffer(content)
+	}
+
+	customReq, err := c.newRequest(ctx, method, config, path, query, reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range header {
+		customReq.Header[k] = v
+	}
+
+	if err := c.runRequestHooks(ctx, customReq); err != nil {
+		return nil, err
+	}
+
+	if c.holdsBack(ctx, customReq) {
+		return c.dryRun.record(customReq, content), nil
+	}
+
+	httpResp, err := c.do(customReq, endpoint)
+
+	c.runResponseHooks(ctx, httpResp)
+
+	if c.auditSink != nil && isMutation(ctx) {
+		if auditErr := c.audit(ctx, customReq, content, httpResp, err); auditErr != nil {
+			return httpResp, auditErr
+		}
+	}
+
+	return httpResp, err
+}
+
+// decodeJSON decodes the response body into out and stamps the http status on result
+func decodeJSON(httpResp *http.Response, out interface{}, result *apiCommonResult) error {
+
+	_, err := decodeBody(httpResp, out, result)
+
+	return err
+}
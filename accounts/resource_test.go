@@ -0,0 +1,169 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type testWidget struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Version    int    `json:"version"`
+	Attributes *struct {
+		Colour string `json:"colour"`
+	} `json:"attributes"`
+	Relationships Relationships `json:"relationships,omitempty"`
+}
+
+func TestResource_Create_SendsDocumentAndDecodesEnvelope(t *testing.T) {
+
+	// Arrange
+
+	expectedCorrectBody := `{"data":{"id":"w1","type":"widgets","version":0,"attributes":{"colour":"red"}}}`
+	receivedBody := ""
+
+	ts := newTestServer(`/v1/widgets`, func(w http.ResponseWriter, r *http.Request) {
+
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+		receivedBody = string(bodyBytes)
+
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"data":{"id":"w1","type":"widgets","version":0,"attributes":{"colour":"red"},"relationships":{"owner":{"data":[{"id":"o1","type":"owners"}]}}},"links":{"self":"/v1/widgets/w1"},"meta":{"total":1}}`)
+	})
+
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	widgets := NewResource[testWidget](client, "widgets", "/v1/widgets")
+
+	widget := &testWidget{ID: "w1", Type: "widgets", Attributes: &struct {
+		Colour string `json:"colour"`
+	}{Colour: "red"}}
+
+	// Act
+
+	response, err := widgets.Create(context.Background(), &Document[testWidget]{Data: widget})
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("create returned an error: got %v want %v", err, nil)
+	}
+
+	if receivedBody != expectedCorrectBody {
+		t.Errorf("server received unexpected body: got %s want %s", receivedBody, expectedCorrectBody)
+	}
+
+	if response.Status != http.StatusCreated {
+		t.Errorf("resource returned unexpected status: got %d want %d", response.Status, http.StatusCreated)
+	}
+
+	if response.Data.Relationships["owner"].Data[0].ID != "o1" {
+		t.Errorf("resource returned unexpected relationships: got %+v", response.Data.Relationships)
+	}
+
+	if response.Links.Self != "/v1/widgets/w1" || response.Meta["total"] != float64(1) {
+		t.Errorf("resource returned unexpected links or meta: got %+v and %+v", response.Links, response.Meta)
+	}
+}
+
+func TestResource_Fetch_ApiError_ReturnsApiHttpErrorType(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(`/v1/widgets/w1`, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error_message":"record w1 does not exist"}`)
+	})
+
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	// Act
+
+	response, err := NewResource[testWidget](client, "widgets", "/v1/widgets").Fetch(context.Background(), "w1")
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	assertClientError(err, "record w1 does not exist", t, ApiHttpErrorType, http.StatusNotFound)
+}
+
+func TestResource_Fetch_SuccessWithoutData_ReturnsBuildingRequestError(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(`/v1/widgets/w1`, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{}`)
+	})
+
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	response, err := NewResource[testWidget](client, "widgets", "/v1/widgets").Fetch(context.Background(), "w1")
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	assertClientError(err, "response has no data", t, BuildingRequestError, http.StatusOK)
+}
+
+func TestResource_Fetch_Timeout_ReturnsTransportError(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer(`/v1/widgets/w1`, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+
+	_, err = NewResource[testWidget](client, "widgets", "/v1/widgets").Fetch(ctx, "w1")
+
+	// Assert
+
+	var transportErr *TransportError
+
+	if !errors.As(err, &transportErr) || !errors.Is(err, BuildingRequestError) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("fetch returned unexpected error: got %v want a TransportError wrapping %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,139 @@
+package accounts
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// ApiErrorSnippetLength is the number of body bytes kept by ApiError for diagnostics
+const ApiErrorSnippetLength = 512
+
+// ApiError describes a non successful response, whatever its body, e.g. an HTML page returned by a proxy.
+// It matches ApiHttpErrorType with errors.Is, and its message is the Form3 error_message when there is one
+type ApiError struct {
+	Status int
+
+	// Message is the error_message of a Form3 error document, or a description of a body which is not JSON
+	Message string
+
+	ContentType string
+
+	// RequestID is the id Form3 gave to the request, when the response carries one
+	RequestID string
+
+	// Snippet holds the first ApiErrorSnippetLength bytes of the body
+	Snippet string
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("%s | %d | %s", ApiHttpErrorType, e.Status, e.Message)
+}
+
+// Is makes the error match ApiHttpErrorType
+func (e *ApiError) Is(target error) bool {
+	return target == ApiHttpErrorType
+}
+
+// newApiError builds the error described by a non successful response from its body
+func newApiError(httpResp *http.Response, body []byte) *ApiError {
+
+	apiErr := &ApiError{
+		Status:      httpResp.StatusCode,
+		ContentType: httpResp.Header.Get("Content-Type"),
+		RequestID:   httpResp.Header.Get(RequestIDHeader),
+		Snippet:     snippet(body),
+	}
+
+	result := &apiCommonResult{}
+
+	switch {
+	case len(strings.TrimSpace(string(body))) == 0:
+		// e.g. an empty 500, whose status says it all
+	case json.Unmarshal(body, result) == nil:
+		apiErr.Message = result.ErrorMessage
+	case apiErr.ContentType != "":
+		apiErr.Message = fmt.Sprintf("unexpected %s response: %s", apiErr.ContentType, apiErr.Snippet)
+	default:
+		apiErr.Message = "unexpected response: " + apiErr.Snippet
+	}
+
+	return apiErr
+}
+
+// snippet truncates body to ApiErrorSnippetLength bytes, without splitting a character
+func snippet(body []byte) string {
+
+	if len(body) <= ApiErrorSnippetLength {
+		return string(body)
+	}
+
+	cut := ApiErrorSnippetLength
+
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+
+	return string(body[:cut]) + "..."
+}
+
+// readBody reads the whole response body, decompressing it when it is gzip encoded. The body is not closed
+func readBody(httpResp *http.Response) ([]byte, error) {
+
+	if httpResp.Body == nil {
+		return nil, nil
+	}
+
+	var reader io.Reader = httpResp.Body
+
+	if strings.EqualFold(httpResp.Header.Get("Content-Encoding"), "gzip") {
+
+		gzipReader, err := gzip.NewReader(httpResp.Body)
+
+		if err == io.EOF {
+			return nil, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer gzipReader.Close()
+
+		reader = gzipReader
+	}
+
+	return ioutil.ReadAll(reader)
+}
+
+// decodeBody reads and closes the response body, stamps the http status on result, then decodes the body into out.
+// Non successful responses fail with an ApiError before any decoding. The body is returned for further checks
+func decodeBody(httpResp *http.Response, out interface{}, result *apiCommonResult) ([]byte, error) {
+
+	result.Status = httpResp.StatusCode
+	result.Header = httpResp.Header
+	result.RequestID = httpResp.Header.Get(RequestIDHeader)
+
+	defer httpResp.Body.Close()
+
+	body, err := readBody(httpResp)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w | %d | %s", BuildingRequestError, httpResp.StatusCode, err)
+	}
+
+	if !isHttpCodeOK(httpResp.StatusCode) {
+		return nil, newApiError(httpResp, body)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("%w | %d | %s", BuildingRequestError, httpResp.StatusCode, err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,179 @@
+package accounts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+const responseAccountId = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+func TestFetch_ErrorResponses_AreClassifiedByStatus(t *testing.T) {
+
+	html := "<html><body><h1>502 Bad Gateway</h1>" + strings.Repeat("<p>nginx</p>", 100) + "</body></html>"
+
+	cases := map[string]struct {
+		status          int
+		contentType     string
+		body            string
+		expectedMessage string
+		expectedSnippet string
+	}{
+		"HTML from a proxy": {
+			status:          http.StatusBadGateway,
+			contentType:     "text/html",
+			body:            html,
+			expectedMessage: "unexpected text/html response: " + html[:ApiErrorSnippetLength] + "...",
+			expectedSnippet: html[:ApiErrorSnippetLength] + "...",
+		},
+		"empty body": {
+			status:      http.StatusInternalServerError,
+			contentType: "application/json",
+		},
+		"Form3 error": {
+			status:          http.StatusConflict,
+			contentType:     "application/vnd.api+json",
+			body:            `{"error_message":"Account cannot be created as it violates a duplicate constraint"}`,
+			expectedMessage: "Account cannot be created as it violates a duplicate constraint",
+			expectedSnippet: `{"error_message":"Account cannot be created as it violates a duplicate constraint"}`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			ts := newTestServer("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", c.contentType)
+				w.WriteHeader(c.status)
+				io.WriteString(w, c.body)
+			})
+
+			defer ts.Close()
+
+			accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			// Act
+
+			_, err = accountsClient.Fetch(context.Background(), uuid.MustParse(responseAccountId))
+
+			// Assert
+
+			assertClientError(err, c.expectedMessage, t, ApiHttpErrorType, c.status)
+
+			var apiErr *ApiError
+
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("unexpected error: got %v want an ApiError", err)
+			}
+
+			if apiErr.ContentType != c.contentType || apiErr.Snippet != c.expectedSnippet {
+				t.Errorf("unexpected diagnostics: got %q and %q want %q and %q", apiErr.ContentType, apiErr.Snippet, c.contentType, c.expectedSnippet)
+			}
+		})
+	}
+}
+
+func TestFetch_GzipEncodedBody_IsDecompressed(t *testing.T) {
+
+	cases := map[string]struct {
+		status          int
+		body            string
+		expectedErrType error
+	}{
+		"success": {
+			status: http.StatusOK,
+			body:   `{"data":{"id":"` + responseAccountId + `","type":"accounts","version":0}}`,
+		},
+		"error": {
+			status:          http.StatusNotFound,
+			body:            `{"error_message":"record does not exist"}`,
+			expectedErrType: ApiHttpErrorType,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			var compressed bytes.Buffer
+
+			writer := gzip.NewWriter(&compressed)
+			io.WriteString(writer, c.body)
+			writer.Close()
+
+			accountsClient := &Client{
+				baseURL: &url.URL{},
+				httpClient: &MockHttpClient{DoFunc: func(*http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: c.status,
+						Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+						Body:       ioutil.NopCloser(&compressed),
+					}, nil
+				}},
+			}
+
+			// Act
+
+			response, err := accountsClient.Fetch(context.Background(), uuid.MustParse(responseAccountId))
+
+			// Assert
+
+			if c.expectedErrType != nil {
+				assertClientError(err, "record does not exist", t, c.expectedErrType, c.status)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			if response.Data.ID != responseAccountId {
+				t.Errorf("unexpected account id: got %v want %v", response.Data.ID, responseAccountId)
+			}
+		})
+	}
+}
+
+func TestDelete_EmptyChunkedBody_Succeeds(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	err = accountsClient.Delete(context.Background(), uuid.MustParse(responseAccountId), 0)
+
+	// Assert
+
+	if err != nil {
+		t.Errorf("unexpected error: got %v want nil", err)
+	}
+}
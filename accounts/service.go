@@ -0,0 +1,23 @@
+package accounts
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AccountsService is the set of account operations offered by Client. Depend on it rather than on *Client
+// to substitute the client in tests, e.g. with the accountsmock package
+type AccountsService interface {
+	Create(ctx context.Context, accountData *AccountData) (*AccountResponse, error)
+	Fetch(ctx context.Context, accountId uuid.UUID) (*AccountResponse, error)
+	List(ctx context.Context, options *ListOptions) (*AccountListResponse, error)
+	Update(ctx context.Context, accountData *AccountData) (*AccountResponse, error)
+	Delete(ctx context.Context, accountId uuid.UUID, version int) error
+	CreateMany(ctx context.Context, accountsData []*AccountData, options *BatchOptions) ([]BatchResult, error)
+	FetchMany(ctx context.Context, accountIds []uuid.UUID, options *BatchOptions) ([]BatchResult, error)
+	DeleteMany(ctx context.Context, items []DeleteItem, options *BatchOptions) ([]BatchResult, error)
+	Ping(ctx context.Context) (*Health, error)
+}
+
+var _ AccountsService = (*Client)(nil)
@@ -0,0 +1,167 @@
+package snapshot
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ChangeKind tells how an account differs between two snapshots
+type ChangeKind string
+
+// Kinds of Change
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Modified ChangeKind = "modified"
+)
+
+// FieldChange is a field whose value differs, named by its json path, e.g. "attributes.bank_id". Values are json
+// encoded
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// Change is an account added, removed or modified between two snapshots
+type Change struct {
+	AccountID string
+	Kind      ChangeKind
+	Before    *accounts.Data
+	After     *accounts.Data
+
+	// Fields lists the differing fields of a modified account
+	Fields []FieldChange
+}
+
+// Diff lists the changes between two snapshots, sorted by account id
+type Diff struct {
+	Changes []Change
+}
+
+// Compare returns the accounts added, removed and modified from before to after. Accounts are compared on their
+// organisation, type and every attribute; the version and timestamps, which change on every update, are not
+func Compare(before *Snapshot, after *Snapshot) *Diff {
+
+	before.sort()
+	after.sort()
+
+	diff := &Diff{}
+	i, j := 0, 0
+
+	for i < len(before.Accounts) || j < len(after.Accounts) {
+
+		switch {
+		case j >= len(after.Accounts) || (i < len(before.Accounts) && before.Accounts[i].ID < after.Accounts[j].ID):
+			diff.Changes = append(diff.Changes, Change{AccountID: before.Accounts[i].ID, Kind: Removed, Before: before.Accounts[i]})
+			i++
+
+		case i >= len(before.Accounts) || after.Accounts[j].ID < before.Accounts[i].ID:
+			diff.Changes = append(diff.Changes, Change{AccountID: after.Accounts[j].ID, Kind: Added, After: after.Accounts[j]})
+			j++
+
+		default:
+			if fields := compareAccounts(before.Accounts[i], after.Accounts[j]); len(fields) > 0 {
+				diff.Changes = append(diff.Changes, Change{AccountID: after.Accounts[j].ID, Kind: Modified, Before: before.Accounts[i], After: after.Accounts[j], Fields: fields})
+			}
+			i++
+			j++
+		}
+	}
+
+	return diff
+}
+
+// Empty reports whether the snapshots hold the same accounts
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Count returns the number of changes of the given kind
+func (d *Diff) Count(kind ChangeKind) int {
+
+	count := 0
+
+	for _, change := range d.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+
+	return count
+}
+
+// String renders the diff with one line per account, prefixed by +, - or ~, followed by the differing fields of
+// modified accounts and a summary
+func (d *Diff) String() string {
+
+	var b strings.Builder
+
+	prefixes := map[ChangeKind]string{Added: "+", Removed: "-", Modified: "~"}
+
+	for _, change := range d.Changes {
+
+		fmt.Fprintf(&b, "%s %s\n", prefixes[change.Kind], change.AccountID)
+
+		for _, field := range change.Fields {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", field.Field, field.Before, field.After)
+		}
+	}
+
+	fmt.Fprintf(&b, "%d added, %d removed, %d modified\n", d.Count(Added), d.Count(Removed), d.Count(Modified))
+
+	return b.String()
+}
+
+func compareAccounts(before *accounts.Data, after *accounts.Data) []FieldChange {
+
+	var fields []FieldChange
+
+	fields = appendChange(fields, "organisation_id", before.OrganisationID, after.OrganisationID)
+	fields = appendChange(fields, "type", before.Type, after.Type)
+
+	beforeAttributes, afterAttributes := before.Attributes, after.Attributes
+
+	if beforeAttributes == nil {
+		beforeAttributes = &accounts.AccountAttributes{}
+	}
+
+	if afterAttributes == nil {
+		afterAttributes = &accounts.AccountAttributes{}
+	}
+
+	beforeValue := reflect.ValueOf(beforeAttributes).Elem()
+	afterValue := reflect.ValueOf(afterAttributes).Elem()
+
+	for k := 0; k < beforeValue.NumField(); k++ {
+
+		name := strings.Split(beforeValue.Type().Field(k).Tag.Get("json"), ",")[0]
+
+		fields = appendChange(fields, "attributes."+name, beforeValue.Field(k).Interface(), afterValue.Field(k).Interface())
+	}
+
+	return fields
+}
+
+// appendChange appends a FieldChange when the values differ, an empty slice being equal to a nil one
+func appendChange(fields []FieldChange, field string, before interface{}, after interface{}) []FieldChange {
+
+	if reflect.DeepEqual(before, after) || (isEmptySlice(before) && isEmptySlice(after)) {
+		return fields
+	}
+
+	return append(fields, FieldChange{Field: field, Before: encode(before), After: encode(after)})
+}
+
+func isEmptySlice(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Slice && v.Len() == 0
+}
+
+func encode(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,84 @@
+package snapshot
+
+import (
+	"ei09010/form3-api-client/accounts"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+
+	// Arrange
+
+	modified := account("b", organisationId, "400301")
+	modified.Version = 3
+	modified.Attributes.AlternativeNames = []string{"Sam Holder"}
+	modified.Attributes.AccountMatchingOptOut = true
+
+	bumped := account("c", organisationId, "400300")
+	bumped.Version = 7
+	bumped.Attributes.AlternativeNames = []string{}
+
+	before := &Snapshot{Accounts: []*accounts.Data{
+		account("a", organisationId, "400300"),
+		account("b", organisationId, "400300"),
+		account("c", organisationId, "400300"),
+	}}
+
+	after := &Snapshot{Accounts: []*accounts.Data{
+		bumped,
+		modified,
+		account("d", organisationId, "400300"),
+	}}
+
+	// Act
+
+	diff := Compare(before, after)
+
+	// Assert
+
+	kinds := map[string]ChangeKind{}
+
+	for _, change := range diff.Changes {
+		kinds[change.AccountID] = change.Kind
+	}
+
+	wantKinds := map[string]ChangeKind{"a": Removed, "b": Modified, "d": Added}
+
+	if !reflect.DeepEqual(kinds, wantKinds) {
+		t.Errorf("unexpected changes: got %v want %v", kinds, wantKinds)
+	}
+
+	wantFields := []FieldChange{
+		{Field: "attributes.account_matching_opt_out", Before: "false", After: "true"},
+		{Field: "attributes.alternative_names", Before: "null", After: `["Sam Holder"]`},
+		{Field: "attributes.bank_id", Before: `"400300"`, After: `"400301"`},
+	}
+
+	if !reflect.DeepEqual(diff.Changes[1].Fields, wantFields) {
+		t.Errorf("unexpected fields: got %+v want %+v", diff.Changes[1].Fields, wantFields)
+	}
+
+	if !strings.HasSuffix(diff.String(), "1 added, 1 removed, 1 modified\n") {
+		t.Errorf("unexpected report: got %v", diff.String())
+	}
+}
+
+func TestCompare_SameAccountsAreEmpty(t *testing.T) {
+
+	// Arrange
+
+	before := &Snapshot{Accounts: []*accounts.Data{account("a", organisationId, "400300")}}
+	after := &Snapshot{Accounts: []*accounts.Data{account("a", organisationId, "400300")}}
+
+	// Act
+
+	diff := Compare(before, after)
+
+	// Assert
+
+	if !diff.Empty() {
+		t.Errorf("unexpected changes: got %v want none", diff)
+	}
+}
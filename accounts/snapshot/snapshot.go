@@ -0,0 +1,196 @@
+// Package snapshot dumps the accounts of an organisation to a versioned JSONL file and compares snapshots, e.g.
+// before and after a migration
+package snapshot
+
+import (
+	"bufio"
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// Format identifies snapshot files and FormatVersion the layout written by this package
+const (
+	Format        = "form3-accounts-snapshot"
+	FormatVersion = 1
+)
+
+// DefaultPageSize is the number of accounts requested per page while taking a snapshot
+const DefaultPageSize = 100
+
+var (
+	// ErrNotSnapshot is returned when a file does not start with a snapshot header
+	ErrNotSnapshot = errors.New("Not an accounts snapshot")
+
+	// ErrUnsupportedVersion is returned when a snapshot was written by a newer layout
+	ErrUnsupportedVersion = errors.New("Unsupported snapshot version")
+)
+
+// Header is the first line of a snapshot file
+type Header struct {
+	Format         string    `json:"format"`
+	Version        int       `json:"version"`
+	OrganisationID string    `json:"organisation_id"`
+	TakenAt        time.Time `json:"taken_at"`
+	Count          int       `json:"count"`
+}
+
+// Snapshot is the state of the accounts of an organisation at a point in time, sorted by account id
+type Snapshot struct {
+	Header   Header
+	Accounts []*accounts.Data
+}
+
+// Lister is the subset of the accounts client used to take snapshots
+type Lister interface {
+	List(ctx context.Context, options *accounts.ListOptions) (*accounts.AccountListResponse, error)
+}
+
+// Take pages through the accounts of organisationID, filtered by the API with filter[organisation_id]. Accounts of
+// other organisations are dropped as well, should the API ignore the filter. An empty organisationID takes every
+// account
+func Take(ctx context.Context, lister Lister, organisationID string) (*Snapshot, error) {
+
+	snapshot := &Snapshot{Header: Header{
+		Format:         Format,
+		Version:        FormatVersion,
+		OrganisationID: organisationID,
+		TakenAt:        time.Now().UTC(),
+	}}
+
+	var filter map[string]string
+
+	if organisationID != "" {
+		filter = map[string]string{"organisation_id": organisationID}
+	}
+
+	for pageNumber := 0; ; pageNumber++ {
+
+		page, err := lister.List(ctx, &accounts.ListOptions{PageNumber: pageNumber, PageSize: DefaultPageSize, Filter: filter})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range page.Data {
+			if organisationID == "" || data.OrganisationID == organisationID {
+				snapshot.Accounts = append(snapshot.Accounts, data)
+			}
+		}
+
+		if !page.HasNext() || len(page.Data) == 0 {
+			break
+		}
+	}
+
+	snapshot.sort()
+
+	return snapshot, nil
+}
+
+func (s *Snapshot) sort() {
+	sort.Slice(s.Accounts, func(i, j int) bool { return s.Accounts[i].ID < s.Accounts[j].ID })
+	s.Header.Count = len(s.Accounts)
+}
+
+// Write encodes the header then one account per line
+func (s *Snapshot) Write(w io.Writer) error {
+
+	s.sort()
+
+	encoder := json.NewEncoder(w)
+
+	if err := encoder.Encode(s.Header); err != nil {
+		return err
+	}
+
+	for _, data := range s.Accounts {
+		if err := encoder.Encode(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteFile writes the snapshot to path
+func (s *Snapshot) WriteFile(path string) error {
+
+	file, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+
+	if err := s.Write(writer); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Read decodes a snapshot, failing with ErrNotSnapshot or ErrUnsupportedVersion when the header does not match,
+// or when the number of accounts differs from the header count
+func Read(r io.Reader) (*Snapshot, error) {
+
+	decoder := json.NewDecoder(r)
+	snapshot := &Snapshot{}
+
+	if err := decoder.Decode(&snapshot.Header); err != nil || snapshot.Header.Format != Format {
+		return nil, fmt.Errorf("%w | %s", ErrNotSnapshot, "missing header")
+	}
+
+	if snapshot.Header.Version < 1 || snapshot.Header.Version > FormatVersion {
+		return nil, fmt.Errorf("%w | %d", ErrUnsupportedVersion, snapshot.Header.Version)
+	}
+
+	for {
+		data := &accounts.Data{}
+
+		err := decoder.Decode(data)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w | %s", ErrNotSnapshot, err)
+		}
+
+		snapshot.Accounts = append(snapshot.Accounts, data)
+	}
+
+	if len(snapshot.Accounts) != snapshot.Header.Count {
+		return nil, fmt.Errorf("%w | %s", ErrNotSnapshot, fmt.Sprintf("%d accounts read, the header counts %d", len(snapshot.Accounts), snapshot.Header.Count))
+	}
+
+	return snapshot, nil
+}
+
+// ReadFile reads the snapshot stored at path
+func ReadFile(path string) (*Snapshot, error) {
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return Read(bufio.NewReader(file))
+}
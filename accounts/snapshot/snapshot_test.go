@@ -0,0 +1,190 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"ei09010/form3-api-client/accounts"
+	"ei09010/form3-api-client/accounts/accountsmock"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const organisationId = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+
+func account(id string, organisationId string, bankId string) *accounts.Data {
+	return &accounts.Data{
+		ID:             id,
+		OrganisationID: organisationId,
+		Type:           "accounts",
+		Attributes:     &accounts.AccountAttributes{BankID: bankId, Country: "GB", Name: []string{"Samantha Holder"}},
+	}
+}
+
+func TestTake_ListsTheOrganisationAccountsOfEveryPage(t *testing.T) {
+
+	// Arrange
+
+	mock := accountsmock.New().Script(accountsmock.MethodList,
+		accountsmock.Result{Response: &accounts.AccountListResponse{
+			Data:  []*accounts.Data{account("b", organisationId, "400300")},
+			Links: &accounts.ListLinks{Next: "/v1/organisation/accounts?page[number]=1"},
+		}},
+		accountsmock.Result{Response: &accounts.AccountListResponse{
+			Data: []*accounts.Data{account("a", organisationId, "400300")},
+		}},
+	)
+
+	// Act
+
+	snapshot, err := Take(context.Background(), mock, organisationId)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ids := []string{}
+
+	for _, data := range snapshot.Accounts {
+		ids = append(ids, data.ID)
+	}
+
+	if !reflect.DeepEqual(ids, []string{"a", "b"}) || snapshot.Header.Count != 2 {
+		t.Errorf("unexpected accounts: got %v counted %v want [a b]", ids, snapshot.Header.Count)
+	}
+
+	calls := mock.CallsTo(accountsmock.MethodList)
+
+	if len(calls) != 2 {
+		t.Fatalf("unexpected number of pages: got %v want 2", len(calls))
+	}
+
+	for i, call := range calls {
+
+		options := call.Args[0].(*accounts.ListOptions)
+
+		if options.PageNumber != i || !reflect.DeepEqual(options.Filter, map[string]string{"organisation_id": organisationId}) {
+			t.Errorf("unexpected list options: got %+v want page %d filtered by organisation", options, i)
+		}
+	}
+}
+
+func TestTake_ServerIgnoringTheFilter_KeepsTheOrganisationAccountsOnly(t *testing.T) {
+
+	// Arrange
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": []*accounts.Data{
+			account("a", organisationId, "400300"),
+			account("b", "0b2b7a7e-6a8e-4a47-9b5e-6e5b2ef0a1c7", "400301"),
+		}})
+	}))
+
+	defer ts.Close()
+
+	client, err := accounts.NewClient(accounts.WithBaseURL(ts.URL))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	snapshot, err := Take(context.Background(), client, organisationId)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(snapshot.Accounts) != 1 || snapshot.Accounts[0].ID != "a" || snapshot.Header.Count != 1 {
+		t.Errorf("unexpected accounts: got %+v want the account of %s only", snapshot.Accounts, organisationId)
+	}
+}
+
+func TestWriteFileThenReadFile(t *testing.T) {
+
+	// Arrange
+
+	path := filepath.Join(t.TempDir(), "before.jsonl")
+	snapshot := &Snapshot{
+		Header:   Header{Format: Format, Version: FormatVersion, OrganisationID: organisationId},
+		Accounts: []*accounts.Data{account("b", organisationId, "400300"), account("a", organisationId, "400301")},
+	}
+
+	// Act
+
+	if err := snapshot.WriteFile(path); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	read, err := ReadFile(path)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !reflect.DeepEqual(read.Header, snapshot.Header) || !reflect.DeepEqual(read.Accounts, snapshot.Accounts) {
+		t.Errorf("unexpected snapshot: got %+v want %+v", read, snapshot)
+	}
+}
+
+func TestRead_RejectsOtherFiles(t *testing.T) {
+
+	cases := map[string]struct {
+		content string
+		err     error
+	}{
+		"no header":       {content: `{"id":"a"}` + "\n", err: ErrNotSnapshot},
+		"newer version":   {content: `{"format":"form3-accounts-snapshot","version":2,"count":0}` + "\n", err: ErrUnsupportedVersion},
+		"truncated":       {content: `{"format":"form3-accounts-snapshot","version":1,"count":2}` + "\n" + `{"id":"a"}` + "\n", err: ErrNotSnapshot},
+		"malformed lines": {content: `{"format":"form3-accounts-snapshot","version":1,"count":1}` + "\n" + `{"id":` + "\n", err: ErrNotSnapshot},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Act
+
+			_, err := Read(strings.NewReader(c.content))
+
+			// Assert
+
+			if !errors.Is(err, c.err) {
+				t.Errorf("unexpected error: got %v want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestWrite_StartsWithTheHeader(t *testing.T) {
+
+	// Arrange
+
+	snapshot := &Snapshot{Header: Header{Format: Format, Version: FormatVersion}}
+	var b bytes.Buffer
+
+	// Act
+
+	err := snapshot.Write(&b)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !strings.HasPrefix(b.String(), `{"format":"form3-accounts-snapshot","version":1,`) {
+		t.Errorf("unexpected content: got %v", b.String())
+	}
+}
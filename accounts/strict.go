@@ -0,0 +1,253 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownField is a response field the client models do not declare, typically an attribute recently added by Form3
+type UnknownField struct {
+
+	// Endpoint is the operation which returned the field, e.g. EndpointFetch or "payments.list"
+	Endpoint string
+
+	// Path locates the field in the response document, e.g. "data.attributes.processing_service"
+	Path string
+}
+
+// strictDecoding holds the settings of WithStrictDecoding
+type strictDecoding struct {
+	onUnknownField func(field UnknownField)
+}
+
+// WithStrictDecoding checks successful responses against the client models. Responses holding a resource without
+// id, version or type fail with ValidationError, and onUnknownField, when not nil, is called once per response for
+// every field the models do not declare, e.g. to log schema drift or feed a metric
+func WithStrictDecoding(onUnknownField func(field UnknownField)) ClientOption {
+	return func(c *Client) error {
+		c.strict = &strictDecoding{onUnknownField: onUnknownField}
+		return nil
+	}
+}
+
+// decodeResponse decodes the response body into out like decodeJSON, checking it when strict decoding is enabled
+func (c *Client) decodeResponse(httpResp *http.Response, out interface{}, result *apiCommonResult, endpoint string) error {
+
+	if c.strict == nil {
+		return decodeJSON(httpResp, out, result)
+	}
+
+	body, err := decodeBody(httpResp, out, result)
+
+	if err != nil {
+		return err
+	}
+
+	return c.strict.check(body, out, httpResp.StatusCode, endpoint)
+}
+
+// check verifies the successful response body decoded into out. Error responses are left to the caller
+func (s *strictDecoding) check(body []byte, out interface{}, status int, endpoint string) error {
+
+	if !isHttpCodeOK(status) {
+		return nil
+	}
+
+	var document interface{}
+
+	if err := json.Unmarshal(body, &document); err != nil {
+		return fmt.Errorf("%w | %d | %s", BuildingRequestError, status, err)
+	}
+
+	if err := requireIdentity(document, status); err != nil {
+		return err
+	}
+
+	if s.onUnknownField == nil {
+		return nil
+	}
+
+	for _, path := range unknownFields(reflect.TypeOf(out), document) {
+		s.onUnknownField(UnknownField{Endpoint: endpoint, Path: path})
+	}
+
+	return nil
+}
+
+// requireIdentity fails when the document has no data, or when one of its resources misses id, version or type
+func requireIdentity(document interface{}, status int) error {
+
+	object, _ := document.(map[string]interface{})
+
+	var resources []interface{}
+
+	_, list := object["data"].([]interface{})
+
+	switch data := object["data"].(type) {
+	case []interface{}:
+		resources = data
+	case map[string]interface{}:
+		resources = []interface{}{data}
+	default:
+		return fmt.Errorf("%w | %d | %s", ValidationError, status, "response has no data")
+	}
+
+	for i, resource := range resources {
+
+		fields, _ := resource.(map[string]interface{})
+
+		var missing []string
+
+		for _, field := range []string{"id", "version", "type"} {
+			if fields[field] == nil {
+				missing = append(missing, field)
+			}
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		location := "data"
+
+		if list {
+			location = fmt.Sprintf("data[%d]", i)
+		}
+
+		return fmt.Errorf("%w | %d | %s", ValidationError, status, fmt.Sprintf("response %s is missing %s", location, strings.Join(missing, ", ")))
+	}
+
+	return nil
+}
+
+var jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns the sorted paths of the document fields which t does not declare
+func unknownFields(t reflect.Type, document interface{}) []string {
+
+	found := map[string]bool{}
+
+	walkUnknownFields(t, document, "", found)
+
+	paths := make([]string, 0, len(found))
+
+	for path := range found {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func walkUnknownFields(t reflect.Type, value interface{}, path string, found map[string]bool) {
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if reflect.PtrTo(t).Implements(jsonUnmarshaler) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+
+		if !ok {
+			return
+		}
+
+		fields := jsonFields(t)
+
+		for key, fieldValue := range object {
+
+			fieldType, known := fields[strings.ToLower(key)]
+
+			if !known {
+				found[joinPath(path, key)] = true
+				continue
+			}
+
+			walkUnknownFields(fieldType, fieldValue, joinPath(path, key), found)
+		}
+
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+
+		if !ok {
+			return
+		}
+
+		for key, entry := range object {
+			walkUnknownFields(t.Elem(), entry, joinPath(path, key), found)
+		}
+
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+
+		if !ok {
+			return
+		}
+
+		for _, item := range items {
+			walkUnknownFields(t.Elem(), item, path, found)
+		}
+	}
+}
+
+// jsonFields maps the lower cased json names of the fields of struct type t, promoted fields included, to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+
+	fields := map[string]reflect.Type{}
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+
+		embedded := field.Type
+
+		for embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+
+		if field.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			for promoted, promotedType := range jsonFields(embedded) {
+				if _, shadowed := fields[promoted]; !shadowed {
+					fields[promoted] = promotedType
+				}
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[strings.ToLower(name)] = field.Type
+	}
+
+	return fields
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
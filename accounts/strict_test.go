@@ -0,0 +1,183 @@
+package accounts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const strictAccountId = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+func TestWithStrictDecoding_Fetch(t *testing.T) {
+
+	cases := map[string]struct {
+		body          string
+		expectedError string
+		unknownFields []UnknownField
+	}{
+		"known fields only": {
+			body: `{"data":{"id":"` + strictAccountId + `","type":"accounts","version":0,"attributes":{"country":"GB"}},"links":{"self":"/v1/organisation/accounts"}}`,
+		},
+		"unknown fields": {
+			body: `{"data":{"id":"` + strictAccountId + `","type":"accounts","version":0,"status":"confirmed","attributes":{"country":"GB","processing_service":"ABC Bank","name":["A"]}},"meta":{}}`,
+			unknownFields: []UnknownField{
+				{Endpoint: EndpointFetch, Path: "data.attributes.processing_service"},
+				{Endpoint: EndpointFetch, Path: "data.status"},
+			},
+		},
+		"missing version and type": {
+			body:          `{"data":{"id":"` + strictAccountId + `","attributes":{"country":"GB"}}}`,
+			expectedError: "response data is missing version, type",
+		},
+		"missing data": {
+			body:          `{"links":{"self":"/v1/organisation/accounts"}}`,
+			expectedError: "response has no data",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			ts := newTestServer("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, c.body)
+			})
+
+			defer ts.Close()
+
+			var unknownFields []UnknownField
+
+			accountsClient, err := NewClient(WithBaseURL(ts.URL), WithStrictDecoding(func(field UnknownField) {
+				unknownFields = append(unknownFields, field)
+			}))
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			// Act
+
+			response, err := accountsClient.Fetch(context.Background(), uuid.MustParse(strictAccountId))
+
+			// Assert
+
+			if c.expectedError != "" {
+				if response != nil {
+					t.Errorf("Returned reponse: got %v want %v", response, nil)
+				}
+				assertClientError(err, c.expectedError, t, ValidationError, http.StatusOK)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			if !reflect.DeepEqual(unknownFields, c.unknownFields) {
+				t.Errorf("unexpected unknown fields: got %v want %v", unknownFields, c.unknownFields)
+			}
+		})
+	}
+}
+
+func TestWithStrictDecoding_List_MissingIdentity_ReturnsValidationError(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"data":[{"id":"`+strictAccountId+`","type":"accounts","version":1},{"type":"accounts","version":0}]}`)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithStrictDecoding(nil))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	response, err := accountsClient.List(context.Background(), &ListOptions{})
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	assertClientError(err, "response data[1] is missing id", t, ValidationError, http.StatusOK)
+}
+
+func TestWithStrictDecoding_ErrorResponse_KeepsTheApiError(t *testing.T) {
+
+	// Arrange
+
+	ts := newTestServer("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error_message":"record does not exist"}`)
+	})
+
+	defer ts.Close()
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithStrictDecoding(nil))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	_, err = accountsClient.Fetch(context.Background(), uuid.MustParse(strictAccountId))
+
+	// Assert
+
+	assertClientError(err, "record does not exist", t, ApiHttpErrorType, http.StatusNotFound)
+}
+
+func TestFetch_AnotherAccountReturned_ReturnsValidationError(t *testing.T) {
+
+	otherId := "9b4c55e4-3b1d-4b4b-a0e5-3003ea9cc4dc"
+
+	cases := map[string][]ClientOption{
+		"without cache": nil,
+		"with cache":    {WithCache(NewLRUCache(10), time.Minute, time.Minute)},
+	}
+
+	for name, options := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			ts := newTestServer("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, `{"data":{"id":"`+otherId+`","type":"accounts","version":0}}`)
+			})
+
+			defer ts.Close()
+
+			accountsClient, err := NewClient(append(options, WithBaseURL(ts.URL))...)
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			// Act
+
+			response, err := accountsClient.Fetch(context.Background(), uuid.MustParse(strictAccountId))
+
+			// Assert
+
+			if response != nil {
+				t.Errorf("Returned reponse: got %v want %v", response, nil)
+			}
+
+			assertClientError(err, "fetched account "+otherId+" instead of "+strictAccountId, t, ValidationError, http.StatusOK)
+		})
+	}
+}
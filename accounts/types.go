@@ -16,10 +16,48 @@ const (
 // Error Standard Types
 var (
 	ApiHttpErrorType     = errors.New("Error message returned by the API")
+	AuditError           = errors.New("Unable to record the audit trail")
 	BuildingRequestError = errors.New("Error while building the request")
 	ClientCreationError  = errors.New("Unable to create the client")
+	ErrCircuitOpen       = errors.New("Circuit breaker is open")
+	ValidationError      = errors.New("Invalid request content")
 )
 
+// TransportError is returned when a request was sent but the API could not be reached or did not answer, e.g. on a
+// refused connection or a timeout. It matches BuildingRequestError with errors.Is and unwraps to the error of the
+// http client, so errors.Is(err, context.DeadlineExceeded) holds after a timeout
+type TransportError struct {
+	Status int
+	Err    error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("%s | %d | %s", BuildingRequestError, e.Status, e.Err)
+}
+
+// Is makes the error match BuildingRequestError
+func (e *TransportError) Is(target error) bool {
+	return target == BuildingRequestError
+}
+
+// Unwrap returns the error of the http client
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// transportFailure marks the errors returned by the http client until requestError turns them into a TransportError
+type transportFailure struct {
+	err error
+}
+
+func (f *transportFailure) Error() string {
+	return f.err.Error()
+}
+
+func (f *transportFailure) Unwrap() error {
+	return f.err
+}
+
 // apiCommonResult contains the error message returned by the Form3 API and it's http code. This is used internally.
 type apiCommonResult struct {
 
@@ -29,6 +67,12 @@ type apiCommonResult struct {
 	// Status is a field mapped from the http response status code. It concerns the http status code from the client call and
 	// is meant to help you track down any bug
 	Status int
+
+	// Header holds the http response headers. It is empty for responses served from the cache
+	Header http.Header `json:"-"`
+
+	// RequestID is the id Form3 gave to the request, taken from the RequestIDHeader response header
+	RequestID string `json:"-"`
 }
 
 func isHttpCodeOK(httpCode int) bool {
@@ -45,6 +89,30 @@ func (e *apiCommonResult) Error() error {
 	return nil
 }
 
+// requestError classifies an error returned while sending a request. Errors raised by the client itself,
+// such as an open circuit or a failed audit, are returned untouched, and those of the http client become a
+// TransportError
+func requestError(httpResp *http.Response, err error) error {
+
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, AuditError) {
+		return err
+	}
+
+	status := http.StatusBadRequest
+
+	if httpResp != nil {
+		status = httpResp.StatusCode
+	}
+
+	var failure *transportFailure
+
+	if errors.As(err, &failure) {
+		return &TransportError{Status: status, Err: failure.err}
+	}
+
+	return fmt.Errorf("%w | %d | %s", BuildingRequestError, status, err)
+}
+
 func addHeaders(customReq *http.Request) {
 	customReq.Header.Set("Content-Type", "application/json")
 	customReq.Header.Set("User-Agent", "form3-go rest-client/0.1 go1.17")
@@ -0,0 +1,35 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Update issues an API request patching the account identified by accountData.Data.ID with the given attributes.
+// accountData.Data.Version must match the stored version, otherwise the API answers 409 Conflict and Update fails
+// with ApiHttpErrorType. Fetch the account again to get its current version before retrying
+func (c *Client) Update(ctx context.Context, accountData *AccountData) (*AccountResponse, error) {
+
+	if accountData == nil || accountData.Data == nil {
+		return nil, fmt.Errorf("%w | %d | %s", BuildingRequestError, http.StatusBadRequest, "account data is required")
+	}
+
+	accountId, err := uuid.Parse(accountData.Data.ID)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w | %d | %s", BuildingRequestError, http.StatusBadRequest, err)
+	}
+
+	defer c.invalidate(ctx, accountId)
+
+	response, err := c.accountsResource().Patch(withMutation(ctx), accountId.String(), accountDocument(accountData))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccountResponse(response), nil
+}
@@ -0,0 +1,200 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestUpdate_validAccountData_patchesAccountAndReturnsNewVersion(t *testing.T) {
+
+	// Arrange
+
+	receivedMethod := ""
+	receivedBody := ""
+
+	ts := newTestServer(`/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc`, func(w http.ResponseWriter, r *http.Request) {
+
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+
+		receivedMethod = r.Method
+		receivedBody = string(bodyBytes)
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"data":{"attributes":{"bank_id":"400300","country":"GB","name":["New Name"]},"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts","version":1}}`)
+	})
+
+	defer ts.Close()
+
+	accountClient, err := NewClient(WithBaseURL(ts.URL), WithTimeout(time.Duration(1000*time.Millisecond)))
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	accountData := &AccountData{Data: &Data{
+		ID:         "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		Type:       "accounts",
+		Attributes: &AccountAttributes{Name: []string{"New Name"}},
+	}}
+
+	// Act
+
+	response, err := accountClient.Update(context.Background(), accountData)
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("update returned an error: got %v want %v", err, nil)
+	}
+
+	if receivedMethod != http.MethodPatch {
+		t.Errorf("server received unexpected method: got %s want %s", receivedMethod, http.MethodPatch)
+	}
+
+	if receivedBody == "" {
+		t.Errorf("server received an empty body")
+	}
+
+	if response.Data.Version != 1 {
+		t.Errorf("handler returned unexpected version: got %d want %d", response.Data.Version, 1)
+	}
+}
+
+func TestUpdate_InvalidAccountId_ReturnsBuildingRequestError(t *testing.T) {
+
+	// Arrange
+
+	accountClient, err := NewClient()
+
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	// Act
+
+	response, err := accountClient.Update(context.Background(), &AccountData{Data: &Data{ID: "notValidContent"}})
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	assertClientError(err, "invalid UUID length: 15", t, BuildingRequestError, http.StatusBadRequest)
+}
+
+func TestUpdate_VersionConflicts(t *testing.T) {
+
+	errorCases := map[string]struct {
+		version              int
+		storedVersion        int
+		expectedHttpStatus   int
+		expectedErrorMessage string
+	}{
+		"Stale version": {
+			version:              0,
+			storedVersion:        2,
+			expectedHttpStatus:   http.StatusConflict,
+			expectedErrorMessage: "invalid version",
+		},
+		"Version ahead of the stored one": {
+			version:              3,
+			storedVersion:        2,
+			expectedHttpStatus:   http.StatusConflict,
+			expectedErrorMessage: "invalid version",
+		},
+		"Matching version": {
+			version:       2,
+			storedVersion: 2,
+		},
+	}
+
+	for name, errCase := range errorCases {
+		t.Run(name, func(t *testing.T) {
+
+			// Arrange
+
+			var receivedVersion int
+
+			ts := newTestServer(`/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc`, func(w http.ResponseWriter, r *http.Request) {
+
+				document := &AccountData{}
+				json.NewDecoder(r.Body).Decode(document)
+
+				receivedVersion = document.Data.Version
+
+				if receivedVersion != errCase.storedVersion {
+					w.WriteHeader(http.StatusConflict)
+					io.WriteString(w, `{"error_message":"invalid version"}`)
+					return
+				}
+
+				io.WriteString(w, `{"data":{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts","version":3}}`)
+			})
+
+			defer ts.Close()
+
+			accountClient, err := NewClient(WithBaseURL(ts.URL))
+
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			// Act
+
+			response, err := accountClient.Update(context.Background(), &AccountData{Data: &Data{
+				ID:         "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+				Type:       "accounts",
+				Version:    errCase.version,
+				Attributes: &AccountAttributes{Name: []string{"New Name"}},
+			}})
+
+			// Assert
+
+			if receivedVersion != errCase.version {
+				t.Errorf("server received unexpected version: got %d want %d", receivedVersion, errCase.version)
+			}
+
+			if errCase.expectedHttpStatus == 0 {
+				if err != nil || response.Data.Version != 3 {
+					t.Errorf("unexpected update outcome: got %v and %v want version 3", response, err)
+				}
+				return
+			}
+
+			if response != nil {
+				t.Errorf("Returned reponse: got %v want %v", response, nil)
+			}
+
+			assertClientError(err, errCase.expectedErrorMessage, t, ApiHttpErrorType, errCase.expectedHttpStatus)
+		})
+	}
+}
+
+func TestUpdate_NilAccountData_ReturnsBuildingRequestError(t *testing.T) {
+
+	// Arrange
+
+	accountClient, err := NewClient()
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	response, err := accountClient.Update(context.Background(), &AccountData{})
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	assertClientError(err, "account data is required", t, BuildingRequestError, http.StatusBadRequest)
+}
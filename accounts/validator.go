@@ -0,0 +1,42 @@
+package accounts
+
+import (
+	"context"
+)
+
+// AccountValidator checks, and may complete, an account before Create sends it, e.g. filling a missing bic.
+// Returning an error, typically wrapping ValidationError, stops the request
+type AccountValidator interface {
+	ValidateAccount(ctx context.Context, account *Data) error
+}
+
+// AccountValidatorFunc adapts a function to the AccountValidator interface
+type AccountValidatorFunc func(ctx context.Context, account *Data) error
+
+// ValidateAccount calls f
+func (f AccountValidatorFunc) ValidateAccount(ctx context.Context, account *Data) error {
+	return f(ctx, account)
+}
+
+// WithAccountValidator runs the given validators, in order, on every account passed to Create
+func WithAccountValidator(validators ...AccountValidator) ClientOption {
+	return func(c *Client) error {
+		c.validators = append(c.validators, validators...)
+		return nil
+	}
+}
+
+func (c *Client) validateAccount(ctx context.Context, account *Data) error {
+
+	if account == nil {
+		return nil
+	}
+
+	for _, validator := range c.validators {
+		if err := validator.ValidateAccount(ctx, account); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestWithAccountValidator_CompletesAccountBeforeCreate(t *testing.T) {
+
+	// Arrange
+
+	var received AccountData
+
+	ts := newTestServer(`/v1/organisation/accounts`, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(bodyBytes, &received)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(bodyBytes)
+	})
+
+	defer ts.Close()
+
+	fillBic := AccountValidatorFunc(func(ctx context.Context, account *Data) error {
+		account.Attributes.Bic = "NWBKGB22"
+		return nil
+	})
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithAccountValidator(fillBic))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	response, err := accountsClient.Create(context.Background(), &AccountData{Data: &Data{Attributes: &AccountAttributes{BankID: "400300"}}})
+
+	// Assert
+
+	if err != nil {
+		t.Fatalf("create returned an error: got %v want %v", err, nil)
+	}
+
+	if received.Data.Attributes.Bic != "NWBKGB22" || response.Data.Attributes.Bic != "NWBKGB22" {
+		t.Errorf("server received unexpected bic: got %s want %s", received.Data.Attributes.Bic, "NWBKGB22")
+	}
+}
+
+func TestWithAccountValidator_RejectedAccount_IsNotSent(t *testing.T) {
+
+	// Arrange
+
+	requests := 0
+
+	ts := newTestServer(`/v1/organisation/accounts`, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+
+	defer ts.Close()
+
+	reject := AccountValidatorFunc(func(ctx context.Context, account *Data) error {
+		return fmt.Errorf("%w | %d | %s", ValidationError, http.StatusBadRequest, "unknown bank id")
+	})
+
+	accountsClient, err := NewClient(WithBaseURL(ts.URL), WithAccountValidator(reject))
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Act
+
+	response, err := accountsClient.Create(context.Background(), &AccountData{Data: &Data{Attributes: &AccountAttributes{BankID: "999999"}}})
+
+	// Assert
+
+	if response != nil {
+		t.Errorf("Returned reponse: got %v want %v", response, nil)
+	}
+
+	if !errors.Is(err, ValidationError) {
+		t.Errorf("create returned unexpected error: got %v want %v", err, ValidationError)
+	}
+
+	if requests != 0 {
+		t.Errorf("server received unexpected number of requests: got %d want %d", requests, 0)
+	}
+}